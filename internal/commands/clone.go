@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/huh"
 	"github.com/raisedadead/git-wt/internal/config"
@@ -16,18 +17,35 @@ import (
 
 var (
 	forceClone      bool
+	resumeClone     bool
 	rootFlag        string
 	timeoutFlag     int
 	hookTimeoutFlag int
+	worktreesFlag   []string
+	strictClone     bool
+	intoFlag        string
+	httpsClone      bool
 )
 
 // CloneData represents the JSON output for the clone command
 type CloneData struct {
-	Project       string `json:"project"`
-	Path          string `json:"path"`
-	BarePath      string `json:"bare_path"`
-	DefaultBranch string `json:"default_branch"`
-	WorktreePath  string `json:"worktree_path"`
+	Project          string             `json:"project"`
+	Path             string             `json:"path"`
+	BarePath         string             `json:"bare_path"`
+	DefaultBranch    string             `json:"default_branch"`
+	WorktreePath     string             `json:"worktree_path"`
+	Resumed          bool               `json:"resumed"`
+	Worktrees        []ClonedWorktree   `json:"worktrees"`
+	SkippedWorktrees []string           `json:"skipped_worktrees,omitempty"`
+	Hooks            []hooks.HookResult `json:"hooks,omitempty"`
+	HooksSkipped     bool               `json:"hooks_skipped,omitempty"`
+	DurationMs       int64              `json:"duration_ms"`
+}
+
+// ClonedWorktree represents a worktree created by clone
+type ClonedWorktree struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path"`
 }
 
 var cloneCmd = &cobra.Command{
@@ -38,6 +56,10 @@ var cloneCmd = &cobra.Command{
 Supports GitHub shorthand (like gh CLI):
   git wt clone owner/repo
   git wt clone freeCodeCamp/freeCodeCamp
+  git wt clone owner/repo --https
+
+Shorthand expands to an SSH URL on default_forge_host (github.com unless
+configured, e.g. a self-hosted GitLab or Gitea), or HTTPS with --https.
 
 Or full URLs:
   git wt clone git@github.com:owner/repo.git
@@ -51,7 +73,10 @@ This creates:
   <name>/
   ├── .bare/     (bare git repository)
   ├── .git       (pointer to .bare)
-  └── main/      (default worktree)`,
+  └── main/      (default worktree)
+
+Use "." as the name (or --into <dir>) to set this up inside an existing
+directory instead of a new subdirectory.`,
 	Args:               cobra.ArbitraryArgs,
 	DisableFlagParsing: false,
 	RunE:               runClone,
@@ -59,13 +84,20 @@ This creates:
 
 func init() {
 	cloneCmd.Flags().BoolVarP(&forceClone, "force", "f", false, "Remove existing directory and re-clone")
+	cloneCmd.Flags().BoolVar(&resumeClone, "resume", false, "Resume an interrupted clone in an existing directory")
 	cloneCmd.Flags().StringVar(&rootFlag, "root", "", "Override worktree_root for this clone")
 	cloneCmd.Flags().IntVar(&timeoutFlag, "timeout", 0, "Override git operation timeout (seconds)")
 	cloneCmd.Flags().IntVar(&hookTimeoutFlag, "hook-timeout", 0, "Override hook timeout (seconds)")
+	cloneCmd.Flags().BoolVar(&strictClone, "strict", false, "Fail instead of assuming main when the default branch cannot be detected")
+	cloneCmd.Flags().StringVar(&intoFlag, "into", "", "Clone into this existing directory (e.g. .) instead of a new subdirectory")
+	cloneCmd.Flags().BoolVar(&httpsClone, "https", false, "Expand owner/repo shorthand to an HTTPS URL instead of SSH")
+	cloneCmd.Flags().StringArrayVar(&worktreesFlag, "worktree", nil, "Also create a worktree for this existing branch (repeatable)")
 	rootCmd.AddCommand(cloneCmd)
 }
 
 func runClone(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+
 	var url, name string
 	var gitArgs []string
 
@@ -109,11 +141,43 @@ func runClone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("repository URL is required")
 	}
 
+	// Load config
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return err
+	}
+
+	// Apply flag overrides
+	if rootFlag != "" {
+		cfg.WorktreeRoot = rootFlag
+	}
+	if timeoutFlag > 0 {
+		cfg.GitLongTimeout = timeoutFlag
+	}
+	if hookTimeoutFlag > 0 {
+		cfg.HookTimeout = hookTimeoutFlag
+	}
+	if httpsClone {
+		cfg.CloneHTTPS = config.Bool(true)
+	}
+
 	// Expand shorthand (owner/repo) to full URL like gh CLI
-	url = expandRepoShorthand(url)
+	url = expandRepoShorthand(url, cfg.DefaultForgeHost, cfg.HTTPSShorthand())
+
+	// "." as the name clones into the current directory
+	if len(args) >= 2 && args[1] == "." && intoFlag == "" {
+		intoFlag = "."
+	}
+	inPlace := intoFlag != ""
 
 	// Get name (extract from URL if not provided)
-	if len(args) >= 2 {
+	if inPlace {
+		intoDir, err := filepath.Abs(intoFlag)
+		if err != nil {
+			return fmt.Errorf("failed to resolve --into directory: %w", err)
+		}
+		name = filepath.Base(intoDir)
+	} else if len(args) >= 2 {
 		name = args[1]
 	} else {
 		// Extract default name from URL
@@ -146,32 +210,22 @@ func runClone(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Validate project name for safety
-	if err := git.ValidateProjectName(name); err != nil {
-		return fmt.Errorf("invalid project name: %w", err)
-	}
-
-	// Load config
-	cfg, err := config.LoadGlobal()
-	if err != nil {
-		return err
-	}
-
-	// Apply flag overrides
-	if rootFlag != "" {
-		cfg.WorktreeRoot = rootFlag
-	}
-	if timeoutFlag > 0 {
-		cfg.GitLongTimeout = timeoutFlag
-	}
-	if hookTimeoutFlag > 0 {
-		cfg.HookTimeout = hookTimeoutFlag
+	// Validate project name for safety (an --into directory is used as is)
+	if !inPlace {
+		if err := git.ValidateProjectName(name); err != nil {
+			return fmt.Errorf("invalid project name: %w", err)
+		}
 	}
 
 	// Determine target directory
 	// Use worktree_root if configured, otherwise use current directory
 	var targetDir string
-	if cfg.WorktreeRoot != "" {
+	if inPlace {
+		targetDir, err = filepath.Abs(intoFlag)
+		if err != nil {
+			return fmt.Errorf("failed to resolve --into directory: %w", err)
+		}
+	} else if cfg.WorktreeRoot != "" {
 		targetDir = filepath.Join(cfg.WorktreeRoot, name)
 	} else {
 		cwd, err := os.Getwd()
@@ -182,8 +236,54 @@ func runClone(cmd *cobra.Command, args []string) error {
 	}
 
 	// Handle existing directory
+	resumed := false
+	exists := false
 	if _, err := os.Stat(targetDir); err == nil {
-		if forceClone {
+		exists = true
+	}
+	if inPlace && resumeClone {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeValidation, "--resume cannot be combined with --into"))
+		}
+		return fmt.Errorf("--resume cannot be combined with --into")
+	}
+	if inPlace && exists {
+		if err := checkCloneInto(targetDir, forceClone); err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeAlreadyExists, err.Error()))
+			}
+			return err
+		}
+	} else if exists {
+		if !forceClone && git.IsResumableClone(targetDir) {
+			if resumeClone || IsJSONOutput() {
+				resumed = resumeClone
+			} else {
+				form := huh.NewForm(
+					huh.NewGroup(
+						huh.NewConfirm().
+							Title(fmt.Sprintf("Found an interrupted clone in %s. Resume it?", targetDir)).
+							Affirmative("Yes, resume").
+							Negative("Cancel").
+							Value(&resumed),
+					),
+				)
+
+				if err := form.Run(); err != nil {
+					return err
+				}
+			}
+		}
+
+		switch {
+		case resumed:
+			// Keep the partial clone, it is completed below
+		case resumeClone:
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeValidation, fmt.Sprintf("cannot resume: no usable bare repository in %s (use --force to re-clone)", targetDir)))
+			}
+			return fmt.Errorf("cannot resume: no usable bare repository in %s (use --force to re-clone)", targetDir)
+		case forceClone:
 			if !IsJSONOutput() {
 				fmt.Println(ui.WarningMsg(fmt.Sprintf("Removing existing directory: %s", targetDir)))
 			}
@@ -193,84 +293,143 @@ func runClone(cmd *cobra.Command, args []string) error {
 				}
 				return fmt.Errorf("failed to remove existing directory: %w", err)
 			}
-		} else {
+		default:
 			if IsJSONOutput() {
-				return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeAlreadyExists, fmt.Sprintf("directory already exists: %s (use --force to overwrite)", targetDir)))
+				return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeAlreadyExists, fmt.Sprintf("directory already exists: %s (use --force to overwrite, or --resume to continue an interrupted clone)", targetDir)))
 			}
-			return fmt.Errorf("directory already exists: %s (use --force to overwrite)", targetDir)
+			return fmt.Errorf("directory already exists: %s (use --force to overwrite, or --resume to continue an interrupted clone)", targetDir)
 		}
 	}
 
 	// Create target directory atomically (avoids TOCTOU race)
 	// os.Mkdir fails if directory already exists
-	if err := os.Mkdir(targetDir, 0755); err != nil {
-		// Parent directory might not exist, try to create it
-		if err := os.MkdirAll(filepath.Dir(targetDir), 0755); err != nil {
-			return fmt.Errorf("failed to create parent directory: %w", err)
-		}
-		// Try again
+	if !resumed && !(inPlace && exists) {
 		if err := os.Mkdir(targetDir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory: %w", err)
+			// Parent directory might not exist, try to create it
+			if err := os.MkdirAll(filepath.Dir(targetDir), 0755); err != nil {
+				return fmt.Errorf("failed to create parent directory: %w", err)
+			}
+			// Try again
+			if err := os.Mkdir(targetDir, 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
 		}
 	}
 
-	if !IsJSONOutput() {
-		fmt.Println(ui.SubtleStyle.Render("Cloning repository..."))
-	}
+	if resumed {
+		if !IsJSONOutput() {
+			fmt.Println(ui.SubtleStyle.Render("Resuming interrupted clone..."))
+		}
 
-	// Clone as bare (pass through any extra git args)
-	if err := git.BareCloneWithTimeout(url, targetDir, cfg.GitLongTimeout, gitArgs...); err != nil {
-		_ = os.RemoveAll(targetDir) // Clean up on failure
-		if IsJSONOutput() {
-			return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		// Keep the partial clone on failure so it can be resumed again
+		if err := git.ResumeBareClone(targetDir, cfg.GitLongTimeout); err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+			}
+			return err
+		}
+	} else {
+		if !IsJSONOutput() {
+			fmt.Println(ui.SubtleStyle.Render("Cloning repository..."))
+		}
+
+		// Clone as bare (pass through any extra git args)
+		if err := git.BareCloneWithTimeout(url, targetDir, cfg.GitLongTimeout, gitArgs...); err != nil {
+			cleanupFailedClone(targetDir, inPlace && exists)
+			if git.IsAuthError(err) {
+				err = fmt.Errorf("%w\nauthentication required: use an SSH URL, or set up a credential helper (e.g. gh auth setup-git)", err)
+			}
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+			}
+			return err
 		}
-		return err
 	}
 	if !IsJSONOutput() {
 		fmt.Println(ui.SuccessMsg("Bare clone complete"))
 	}
 
 	// Get default branch
-	defaultBranch, err := git.GetDefaultBranch(targetDir)
+	defaultBranch, err := resolveCloneDefaultBranch(targetDir, strictClone)
 	if err != nil {
-		defaultBranch = git.DefaultBranch
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
 	}
 
-	// Create main worktree
-	mainPath, err := git.CreateWorktreeFromBranch(targetDir, defaultBranch)
-	if err != nil {
-		if IsJSONOutput() {
-			return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeGit, fmt.Sprintf("failed to create main worktree: %v", err)))
+	// Create main worktree (a resumed clone may already have it)
+	mainPath := filepath.Join(targetDir, git.FlattenBranchName(defaultBranch))
+	if _, statErr := os.Stat(mainPath); !resumed || statErr != nil {
+		mainPath, err = git.CreateWorktreeFromBranch(targetDir, defaultBranch, git.WorktreeOptions{Path: mainPath, NoRelativePaths: !cfg.RelativePaths()})
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "clone", nil, ui.NewCLIError(ui.ErrCodeGit, fmt.Sprintf("failed to create main worktree: %v", err)))
+			}
+			return fmt.Errorf("failed to create main worktree: %w", err)
 		}
-		return fmt.Errorf("failed to create main worktree: %w", err)
 	}
 	if !IsJSONOutput() {
 		fmt.Println(ui.SuccessMsg(fmt.Sprintf("Created %s/ worktree", defaultBranch)))
 	}
+	created := []ClonedWorktree{{Branch: defaultBranch, Path: mainPath}}
 
-	// Run post_clone hooks
-	hookCtx := hooks.Context{
-		Path:          mainPath,
-		Branch:        defaultBranch,
-		ProjectRoot:   targetDir,
-		DefaultBranch: defaultBranch,
+	// Create worktrees for additional long-lived branches
+	initialBranches := cfg.InitialWorktrees
+	if len(worktreesFlag) > 0 {
+		initialBranches = worktreesFlag
 	}
-	if warnings := hooks.RunWithTimeout(cfg.Hooks.PostClone, hookCtx, cfg.HookTimeout); len(warnings) > 0 {
-		for _, w := range warnings {
-			if !IsJSONOutput() {
-				fmt.Println(ui.WarningMsg("Hook: " + w))
+	toCreate, skipped := planInitialWorktrees(targetDir, defaultBranch, initialBranches)
+	for _, branch := range skipped {
+		if !IsJSONOutput() {
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("Skipping %s: branch does not exist", branch)))
+		}
+	}
+	for _, branch := range toCreate {
+		path := filepath.Join(targetDir, git.FlattenBranchName(branch))
+		if _, statErr := os.Stat(path); !resumed || statErr != nil {
+			path, err = git.CreateWorktreeFromBranch(targetDir, branch, git.WorktreeOptions{Path: path, NoRelativePaths: !cfg.RelativePaths()})
+			if err != nil {
+				skipped = append(skipped, branch)
+				if !IsJSONOutput() {
+					fmt.Println(ui.WarningMsg(fmt.Sprintf("Failed to create %s worktree: %v", branch, err)))
+				}
+				continue
 			}
 		}
+		created = append(created, ClonedWorktree{Branch: branch, Path: path})
+		if !IsJSONOutput() {
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Created %s/ worktree", git.FlattenBranchName(branch))))
+		}
+	}
+
+	// Run post_clone hooks; with --json their output is captured into the
+	// result instead of corrupting it
+	var hookResults []hooks.HookResult
+	if !HooksDisabled() {
+		hookCtx := hooks.Context{
+			Path:          mainPath,
+			Branch:        defaultBranch,
+			ProjectRoot:   targetDir,
+			DefaultBranch: defaultBranch,
+		}
+		hookResults = runWorktreeHooks(cfg.Hooks.PostClone, hookCtx, cfg)
 	}
 
 	// JSON output
 	if IsJSONOutput() {
 		data := CloneData{
-			Project:       name,
-			Path:          targetDir,
-			BarePath:      filepath.Join(targetDir, ".bare"),
-			DefaultBranch: defaultBranch,
-			WorktreePath:  mainPath,
+			Project:          name,
+			Path:             targetDir,
+			BarePath:         filepath.Join(targetDir, ".bare"),
+			DefaultBranch:    defaultBranch,
+			WorktreePath:     mainPath,
+			Resumed:          resumed,
+			Worktrees:        created,
+			SkippedWorktrees: skipped,
+			Hooks:            hookResults,
+			HooksSkipped:     HooksDisabled(),
+			DurationMs:       time.Since(start).Milliseconds(),
 		}
 		return ui.OutputJSON(os.Stdout, "clone", data, nil)
 	}
@@ -281,10 +440,30 @@ func runClone(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// expandRepoShorthand expands owner/repo shorthand to full GitHub URL
-// Supports: owner/repo -> git@github.com:owner/repo.git
-// Passes through full URLs unchanged
-func expandRepoShorthand(input string) string {
+// planInitialWorktrees splits the configured initial branches into those to
+// create and those that don't exist, dropping duplicates and the default branch
+func planInitialWorktrees(projectRoot, defaultBranch string, branches []string) (create, missing []string) {
+	seen := map[string]bool{defaultBranch: true}
+	for _, branch := range branches {
+		branch = strings.TrimSpace(branch)
+		if branch == "" || seen[branch] {
+			continue
+		}
+		seen[branch] = true
+
+		if git.BranchExists(projectRoot, branch) {
+			create = append(create, branch)
+		} else {
+			missing = append(missing, branch)
+		}
+	}
+	return create, missing
+}
+
+// expandRepoShorthand expands owner/repo shorthand to a full URL on host
+// Supports: owner/repo -> git@host:owner/repo.git (https://host/owner/repo.git
+// with https). Passes through full URLs unchanged
+func expandRepoShorthand(input, host string, https bool) string {
 	// Already a full URL (HTTPS or other protocol)
 	if strings.Contains(input, "://") {
 		return input
@@ -301,13 +480,54 @@ func expandRepoShorthand(input string) string {
 		// Looks like owner/repo shorthand
 		owner := parts[0]
 		repo := strings.TrimSuffix(parts[1], ".git")
-		return fmt.Sprintf("git@github.com:%s/%s.git", owner, repo)
+		if host == "" {
+			host = defaultForgeHost
+		}
+		if https {
+			return fmt.Sprintf("https://%s/%s/%s.git", host, owner, repo)
+		}
+		return fmt.Sprintf("git@%s:%s/%s.git", host, owner, repo)
 	}
 
 	// Return as-is (might be a local path or other format)
 	return input
 }
 
+// defaultForgeHost is the host owner/repo shorthand expands to when
+// default_forge_host is not set
+const defaultForgeHost = "github.com"
+
+// checkCloneInto verifies an existing directory can hold an in-place clone:
+// it must not already be a git-wt project or git repository, and must be
+// empty unless force is set. Existing files are never removed.
+func checkCloneInto(dir string, force bool) error {
+	for _, name := range []string{git.BareDir, git.GitPointerFile} {
+		if _, err := os.Lstat(filepath.Join(dir, name)); err == nil {
+			return fmt.Errorf("%s is already a git-wt project or git repository", dir)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+	if len(entries) > 0 && !force {
+		return fmt.Errorf("directory is not empty: %s (use --force to clone into it anyway)", dir)
+	}
+	return nil
+}
+
+// cleanupFailedClone removes what a failed clone created. A directory that
+// existed before the clone (--into) is kept, along with any other files in it.
+func cleanupFailedClone(targetDir string, keepDir bool) {
+	if !keepDir {
+		_ = os.RemoveAll(targetDir)
+		return
+	}
+	_ = os.RemoveAll(filepath.Join(targetDir, git.BareDir))
+	_ = os.Remove(filepath.Join(targetDir, git.GitPointerFile))
+}
+
 // extractRepoName extracts the repository name from a URL
 func extractRepoName(url string) string {
 	// Handle SSH URLs: git@github.com:user/repo.git
@@ -328,3 +548,17 @@ func extractRepoName(url string) string {
 
 	return "repo"
 }
+
+// resolveCloneDefaultBranch detects the default branch of a fresh clone.
+// Detection failures fall back to main unless strict is set, in which case
+// they are an error so automation never lands on the wrong branch.
+func resolveCloneDefaultBranch(projectRoot string, strict bool) (string, error) {
+	defaultBranch, err := git.GetDefaultBranch(projectRoot)
+	if err == nil {
+		return defaultBranch, nil
+	}
+	if strict {
+		return "", fmt.Errorf("%w: origin/HEAD is not set, so the default branch is ambiguous and --strict refuses to assume %s", err, git.DefaultBranch)
+	}
+	return git.DefaultBranch, nil
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+// initCommandTestRepo creates a repository with a commit on main and the
+// given extra branches
+func initCommandTestRepo(t *testing.T, branches ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "--initial-branch=main")
+	run("commit", "--allow-empty", "-m", "initial")
+	for _, branch := range branches {
+		run("branch", branch)
+	}
+	return dir
+}
+
+func TestPlanInitialWorktrees(t *testing.T) {
+	dir := initCommandTestRepo(t, "develop", "staging")
+
+	create, missing := planInitialWorktrees(dir, "main", []string{"develop", "main", "missing", "staging", "develop", ""})
+
+	if want := []string{"develop", "staging"}; !reflect.DeepEqual(create, want) {
+		t.Errorf("expected create %v, got %v", want, create)
+	}
+	if want := []string{"missing"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("expected missing %v, got %v", want, missing)
+	}
+}
+
+func TestPlanInitialWorktrees_Empty(t *testing.T) {
+	dir := initCommandTestRepo(t)
+
+	create, missing := planInitialWorktrees(dir, "main", nil)
+	if len(create) != 0 || len(missing) != 0 {
+		t.Errorf("expected nothing to do, got create=%v missing=%v", create, missing)
+	}
+}
+
+func TestResolveCloneDefaultBranch_Strict(t *testing.T) {
+	src := initCommandTestRepo(t)
+	if out, err := exec.Command("git", "-C", src, "branch", "-m", "main", "develop").CombinedOutput(); err != nil {
+		t.Fatalf("git branch -m failed: %v\n%s", err, out)
+	}
+	projectRoot := t.TempDir()
+	if err := git.BareCloneWithTimeout(src, projectRoot, 60); err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+
+	// Lenient mode keeps the historical main fallback
+	branch, err := resolveCloneDefaultBranch(projectRoot, false)
+	if err != nil || branch != git.DefaultBranch {
+		t.Errorf("expected lenient fallback to %s, got %q (%v)", git.DefaultBranch, branch, err)
+	}
+
+	_, err = resolveCloneDefaultBranch(projectRoot, true)
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected ambiguity error in strict mode, got %v", err)
+	}
+}
+
+func TestCheckCloneInto(t *testing.T) {
+	empty := t.TempDir()
+	if err := checkCloneInto(empty, false); err != nil {
+		t.Errorf("expected empty directory to be accepted, got %v", err)
+	}
+
+	nonEmpty := t.TempDir()
+	if err := os.WriteFile(filepath.Join(nonEmpty, "notes.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkCloneInto(nonEmpty, false); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("expected not empty error, got %v", err)
+	}
+	if err := checkCloneInto(nonEmpty, true); err != nil {
+		t.Errorf("expected --force to allow a non-empty directory, got %v", err)
+	}
+
+	// Existing projects are refused even with --force
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, git.GitPointerFile), []byte("gitdir: ./.bare\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkCloneInto(project, true); err == nil || !strings.Contains(err.Error(), "already a git-wt project") {
+		t.Errorf("expected existing project error, got %v", err)
+	}
+}
+
+func TestExpandRepoShorthand(t *testing.T) {
+	tests := []struct {
+		input string
+		host  string
+		https bool
+		want  string
+	}{
+		{"owner/repo", "", false, "git@github.com:owner/repo.git"},
+		{"owner/repo.git", "github.com", false, "git@github.com:owner/repo.git"},
+		{"owner/repo", "gitlab.example.com", false, "git@gitlab.example.com:owner/repo.git"},
+		{"owner/repo", "gitea.example.com", true, "https://gitea.example.com/owner/repo.git"},
+		{"owner/repo", "", true, "https://github.com/owner/repo.git"},
+		// Full URLs and other inputs pass through unchanged
+		{"https://github.com/owner/repo.git", "gitlab.example.com", false, "https://github.com/owner/repo.git"},
+		{"git@github.com:owner/repo.git", "gitlab.example.com", true, "git@github.com:owner/repo.git"},
+		{"../local/repo.git", "gitlab.example.com", false, "../local/repo.git"},
+	}
+
+	for _, tt := range tests {
+		if got := expandRepoShorthand(tt.input, tt.host, tt.https); got != tt.want {
+			t.Errorf("expandRepoShorthand(%q, %q, %v) = %q, want %q", tt.input, tt.host, tt.https, got, tt.want)
+		}
+	}
+}
+
+func TestRunClone_IntoCurrentDir(t *testing.T) {
+	src := initCommandTestRepo(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	target := t.TempDir()
+	if err := os.WriteFile(filepath.Join(target, "notes.txt"), []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(target); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { intoFlag, forceClone = "", false })
+
+	// A non-empty directory needs --force and is left untouched otherwise
+	if err := runClone(cloneCmd, []string{src, "."}); err == nil {
+		t.Fatal("expected error for non-empty directory")
+	}
+	if _, err := os.Stat(filepath.Join(target, git.BareDir)); !os.IsNotExist(err) {
+		t.Error("expected no bare repository after refused clone")
+	}
+
+	// The main worktree is created with --relative-paths (Git 2.48+)
+	if out, _ := exec.Command("git", "worktree", "add", "-h").CombinedOutput(); !strings.Contains(string(out), "--relative-paths") {
+		t.Skip("git worktree add --relative-paths not supported")
+	}
+
+	forceClone = true
+	if err := runClone(cloneCmd, []string{src, "."}); err != nil {
+		t.Fatalf("expected in-place clone to succeed, got %v", err)
+	}
+	for _, name := range []string{git.BareDir, git.GitPointerFile, "main", "notes.txt"} {
+		if _, err := os.Stat(filepath.Join(target, name)); err != nil {
+			t.Errorf("expected %s in the target directory: %v", name, err)
+		}
+	}
+
+	// Cloning again into the same project is refused
+	if err := runClone(cloneCmd, []string{src, "."}); err == nil || !strings.Contains(err.Error(), "already a git-wt project") {
+		t.Errorf("expected existing project error, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/raisedadead/git-wt/internal/config"
@@ -16,6 +17,8 @@ var (
 	configGlobal bool
 	configLocal  bool
 	configForce  bool
+	configExport bool
+	configAppend bool
 )
 
 var configCmd = &cobra.Command{
@@ -37,38 +40,132 @@ Use --global to create ~/.config/git-wt/config.toml instead.`,
 var configShowCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Show effective configuration with sources",
-	Long:  `Display the current effective configuration, showing where each value comes from.`,
-	RunE:  runConfigShow,
+	Long: `Display the current effective configuration, showing where each value comes from.
+
+Use --export-env to print the configuration as shell export statements:
+
+  eval "$(git wt config show --export-env)"`,
+	RunE: runConfigShow,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration value",
+	Long: `Set a configuration value in the repo config (.git-wt.toml) or, with
+--global, in the global config. Other settings and comments are kept.
+
+List options such as hooks.post_add take a JSON or TOML array; a plain value
+is a one-item list. Use --append to add to the existing list.
+
+Examples:
+  git wt config set default_remote upstream
+  git wt config set --global hooks.post_add '["npm install", "make setup"]'
+  git wt config set hooks.post_add --append 'direnv allow'`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Show the effective value of a configuration option",
+	Long: `Show the effective value of a configuration option and where it comes
+from: the repo config, the global config, or the default.
+
+Examples:
+  git wt config get default_remote
+  git wt config get hooks.post_add`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigGet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration value",
+	Long: `Remove a configuration value from the repo config (.git-wt.toml) or, with
+--global, from the global config, so it falls back to the next config or the
+default. Other settings and comments are kept.
+
+Examples:
+  git wt config unset default_remote
+  git wt config unset --global hooks.post_add`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigUnset,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check configuration files for unknown keys and invalid values",
+	Long: `Check the global config and, inside a project, the repo config for
+problems that loading would otherwise ignore: unknown keys (e.g. a misspelled
+git_timout), non-positive timeouts, a branch_template without any variables,
+and an empty default_remote.
+
+Exits with a validation error when any problem is found.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+var configSetRemoteCmd = &cobra.Command{
+	Use:   "set-remote <name> [url]",
+	Short: "Change the default remote and reconfigure git to match",
+	Long: `Set default_remote in the repo config (or, with --global, the global
+config) and make the bare repository agree with it:
+
+  - an existing remote gets its URL updated when a different url is given
+  - a missing remote is added with url, or renamed from the current
+    default remote when no url is given
+  - the remote's fetch refspec is set to fetch all branches, as clone does
+
+Examples:
+  git wt config set-remote upstream
+  git wt config set-remote upstream git@github.com:org/repo.git`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runConfigSetRemote,
 }
 
 func init() {
 	configInitCmd.Flags().BoolVar(&configGlobal, "global", false, "Create global config (~/.config/git-wt/config.toml)")
 	configInitCmd.Flags().BoolVar(&configLocal, "local", false, "Create repo config (.git-wt.toml) [default]")
 	configInitCmd.Flags().BoolVar(&configForce, "force", false, "Overwrite existing config file")
+	configSetCmd.Flags().BoolVar(&configGlobal, "global", false, "Set in global config (~/.config/git-wt/config.toml)")
+	configSetCmd.Flags().BoolVar(&configAppend, "append", false, "Append to a list option instead of replacing it")
+	configUnsetCmd.Flags().BoolVar(&configGlobal, "global", false, "Unset in global config (~/.config/git-wt/config.toml)")
+	configSetRemoteCmd.Flags().BoolVar(&configGlobal, "global", false, "Set in global config (~/.config/git-wt/config.toml)")
+	configShowCmd.Flags().BoolVar(&configExport, "export-env", false, "Print effective config as shell export statements")
 
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configSetRemoteCmd)
+	configCmd.AddCommand(configValidateCmd)
 	rootCmd.AddCommand(configCmd)
 }
 
-func runConfigInit(cmd *cobra.Command, args []string) error {
-	var configPath string
-
+// configTargetPath returns the config file to write: the global config with
+// --global, otherwise the repo config (or .git-wt.toml in the current
+// directory outside a project)
+func configTargetPath() (string, error) {
 	if configGlobal {
-		configPath = config.GetConfigPath()
-	} else {
-		// Default to local (repo) config
-		projectRoot, err := git.GetProjectRoot(".")
+		return config.GetConfigPath(), nil
+	}
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		// Not in a project, use current directory
+		cwd, err := os.Getwd()
 		if err != nil {
-			// Not in a project, use current directory
-			cwd, err := os.Getwd()
-			if err != nil {
-				return fmt.Errorf("failed to get current directory: %w", err)
-			}
-			configPath = filepath.Join(cwd, ".git-wt.toml")
-		} else {
-			configPath = config.GetRepoConfigPath(projectRoot)
+			return "", fmt.Errorf("failed to get current directory: %w", err)
 		}
+		return filepath.Join(cwd, ".git-wt.toml"), nil
+	}
+	return config.GetRepoConfigPath(projectRoot), nil
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	configPath, err := configTargetPath()
+	if err != nil {
+		return err
 	}
 
 	// Check if file exists
@@ -101,6 +198,237 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+
+	configPath, err := configTargetPath()
+	if err != nil {
+		return err
+	}
+
+	written, err := config.SetValue(configPath, key, value, configAppend)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "config set", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+		}
+		return err
+	}
+
+	if IsJSONOutput() {
+		data := map[string]interface{}{
+			"path":  configPath,
+			"key":   key,
+			"value": written,
+		}
+		return ui.OutputJSON(os.Stdout, "config set", data, nil)
+	}
+
+	display := fmt.Sprintf("%v", written)
+	if list, ok := written.([]string); ok {
+		display = formatStringList(list)
+	}
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Set %s = %s in %s", key, display, shortenConfigPath(configPath))))
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	key := args[0]
+
+	// Repo config is optional outside a project
+	projectRoot, _ := git.GetProjectRoot(".")
+
+	cfg, sources, err := config.LoadEffective(config.GetConfigPath(), projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "config get", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+		}
+		return err
+	}
+
+	value, ok := config.Value(cfg, key)
+	if !ok {
+		msg := fmt.Sprintf("unknown config key: %s", key)
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "config get", nil, ui.NewCLIError(ui.ErrCodeValidation, msg))
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	source := sources[key]
+	if source == "" {
+		source = "default"
+	}
+
+	if IsJSONOutput() {
+		data := map[string]interface{}{
+			"key":    key,
+			"value":  value,
+			"source": source,
+		}
+		return ui.OutputJSON(os.Stdout, "config get", data, nil)
+	}
+
+	fmt.Printf("%s  # %s\n", formatConfigValue(value), configSourceDisplay(source))
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := args[0]
+
+	configPath, err := configTargetPath()
+	if err != nil {
+		return err
+	}
+
+	removed, err := config.UnsetValue(configPath, key)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "config unset", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+		}
+		return err
+	}
+
+	if IsJSONOutput() {
+		data := map[string]interface{}{
+			"path":    configPath,
+			"key":     key,
+			"removed": removed,
+		}
+		return ui.OutputJSON(os.Stdout, "config unset", data, nil)
+	}
+
+	if !removed {
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("%s is not set in %s", key, shortenConfigPath(configPath))))
+		return nil
+	}
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Unset %s in %s", key, shortenConfigPath(configPath))))
+	return nil
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	paths := []string{config.GetConfigPath()}
+	if projectRoot, err := git.GetProjectRoot("."); err == nil {
+		paths = append(paths, config.GetRepoConfigPath(projectRoot))
+	}
+
+	var checked []string
+	var problems []config.Problem
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		found, err := config.Validate(path)
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "config validate", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+			}
+			return err
+		}
+		checked = append(checked, path)
+		problems = append(problems, found...)
+	}
+
+	if len(problems) > 0 {
+		lines := make([]string, len(problems))
+		for i, p := range problems {
+			lines[i] = p.String()
+		}
+		msg := fmt.Sprintf("%d config problem(s) found", len(problems))
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "config validate", nil,
+				ui.NewCLIError(ui.ErrCodeValidation, msg+": "+strings.Join(lines, "; ")))
+		}
+		for _, line := range lines {
+			fmt.Println(ui.WarningMsg(line))
+		}
+		// Problems are already listed; usage help would only bury them
+		cmd.SilenceUsage = true
+		return ui.NewCLIError(ui.ErrCodeValidation, msg)
+	}
+
+	if IsJSONOutput() {
+		data := map[string]interface{}{"files": checked}
+		return ui.OutputJSON(os.Stdout, "config validate", data, nil)
+	}
+
+	if len(checked) == 0 {
+		fmt.Println(ui.SubtleStyle.Render("No config files found"))
+		return nil
+	}
+	for _, path := range checked {
+		fmt.Println(ui.SuccessMsg(shortenConfigPath(path)))
+	}
+	fmt.Println(ui.SuccessMsg("No problems found"))
+	return nil
+}
+
+func runConfigSetRemote(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	var url string
+	if len(args) > 1 {
+		url = args[1]
+	}
+
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "config set-remote", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	cfg, err := config.LoadWithRepo(config.GetConfigPath(), projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "config set-remote", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	configPath, err := configTargetPath()
+	if err != nil {
+		return err
+	}
+
+	actions, err := setDefaultRemote(projectRoot, configPath, name, url, cfg.DefaultRemote)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "config set-remote", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	if IsJSONOutput() {
+		remoteURL, _ := git.RemoteURL(projectRoot, name)
+		data := map[string]interface{}{
+			"path":    configPath,
+			"remote":  name,
+			"url":     remoteURL,
+			"actions": actions,
+		}
+		return ui.OutputJSON(os.Stdout, "config set-remote", data, nil)
+	}
+
+	for _, action := range actions {
+		fmt.Println(ui.SubtleStyle.Render(action))
+	}
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Set default_remote = %q in %s", name, shortenConfigPath(configPath))))
+	return nil
+}
+
+// setDefaultRemote configures the remote in the bare repository, then records
+// it as default_remote in the config file at configPath. The git side goes
+// first so a failure leaves the config untouched.
+func setDefaultRemote(projectRoot, configPath, name, url, current string) ([]string, error) {
+	actions, err := git.ConfigureRemote(projectRoot, name, url, current)
+	if err != nil {
+		return actions, err
+	}
+	if _, err := config.SetValue(configPath, "default_remote", name, false); err != nil {
+		return actions, err
+	}
+	return actions, nil
+}
+
 func runConfigShow(cmd *cobra.Command, args []string) error {
 	// Try to find project root for repo config
 	projectRoot, _ := git.GetProjectRoot(".")
@@ -113,6 +441,13 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if configExport {
+		for _, line := range exportEnvLines(cfg) {
+			fmt.Println(line)
+		}
+		return nil
+	}
+
 	if IsJSONOutput() {
 		data := map[string]interface{}{
 			"config":  cfg,
@@ -121,33 +456,104 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 		return ui.OutputJSON(os.Stdout, "config show", data, nil)
 	}
 
-	// Pretty print with sources
-	printConfigValue("worktree_root", cfg.WorktreeRoot, sources["worktree_root"])
-	printConfigValue("default_remote", cfg.DefaultRemote, sources["default_remote"])
-	printConfigValue("default_base_branch", cfg.DefaultBaseBranch, sources["default_base_branch"])
-	printConfigValue("branch_template", cfg.BranchTemplate, sources["branch_template"])
-	printConfigValue("git_timeout", fmt.Sprintf("%d", cfg.GitTimeout), sources["git_timeout"])
-	printConfigValue("git_long_timeout", fmt.Sprintf("%d", cfg.GitLongTimeout), sources["git_long_timeout"])
-	printConfigValue("hook_timeout", fmt.Sprintf("%d", cfg.HookTimeout), sources["hook_timeout"])
+	// Pretty print with sources; table options follow as key.entry lines
+	for _, key := range config.Keys() {
+		if value, ok := config.Value(cfg, key); ok {
+			printConfigValue(key, value, sources[key])
+		}
+	}
+
+	printConfigMap("worktree_git_config", cfg.WorktreeGitConfig, sources["worktree_git_config"])
+	printConfigMap("label_subdirs", cfg.LabelSubdirs, sources["label_subdirs"])
+	for _, command := range sortedKeys(cfg.CommandDefaults) {
+		printConfigMap("command_defaults."+command, cfg.CommandDefaults[command], sources["command_defaults"])
+	}
 
 	return nil
 }
 
-func printConfigValue(key, value, source string) {
-	if value == "" {
-		value = `""`
-	} else if key != "git_timeout" && key != "git_long_timeout" && key != "hook_timeout" {
-		value = fmt.Sprintf("%q", value)
+// printConfigMap prints each entry of a table option as prefix.key, sorted
+func printConfigMap[V any](prefix string, values map[string]V, source string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		printConfigValue(prefix+"."+key, values[key], source)
+	}
+}
+
+// sortedKeys returns the command names in command_defaults in sorted order
+func sortedKeys(defaults config.CommandDefaults) []string {
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// exportEnvLines renders the scalar config values as shell export statements,
+// e.g. export GIT_WT_DEFAULT_REMOTE='origin'. List and table options are left
+// out.
+func exportEnvLines(cfg *config.Config) []string {
+	var lines []string
+	for _, key := range config.Keys() {
+		value, ok := config.Value(cfg, key)
+		if !ok {
+			continue
+		}
+		if _, isList := value.([]string); isList {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("export GIT_WT_%s=%s", strings.ToUpper(key), shellQuote(fmt.Sprintf("%v", value))))
+	}
+	return lines
+}
+
+// shellQuote wraps a value in single quotes, escaping embedded single quotes
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// formatStringList renders a list option in TOML array syntax
+func formatStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
 	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
 
-	var sourceDisplay string
+// printConfigValue prints one option in TOML syntax with where it came from
+func printConfigValue(key string, value interface{}, source string) {
+	fmt.Printf("%s = %-40s # %s\n", key, formatConfigValue(value), configSourceDisplay(source))
+}
+
+// configSourceDisplay renders where a value came from: "default" or the
+// config file path(s), comma-separated for hooks appended across files
+func configSourceDisplay(source string) string {
 	if source == "default" {
-		sourceDisplay = ui.SubtleStyle.Render("default")
-	} else {
-		sourceDisplay = ui.SubtleStyle.Render(shortenConfigPath(source))
+		return ui.SubtleStyle.Render("default")
+	}
+	paths := strings.Split(source, ", ")
+	for i, path := range paths {
+		paths[i] = shortenConfigPath(path)
 	}
+	return ui.SubtleStyle.Render(strings.Join(paths, ", "))
+}
 
-	fmt.Printf("%s = %-40s # %s\n", key, value, sourceDisplay)
+// formatConfigValue renders a typed config value in TOML syntax
+func formatConfigValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []string:
+		return formatStringList(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
 }
 
 func shortenConfigPath(path string) string {
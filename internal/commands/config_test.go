@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/config"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "''"},
+		{"origin", "'origin'"},
+		{"~/DEV/worktrees", "'~/DEV/worktrees'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME `cmd`", "'$HOME `cmd`'"},
+	}
+
+	for _, tt := range tests {
+		if got := shellQuote(tt.input); got != tt.expected {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestExportEnvLines(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorktreeRoot = "/tmp/my worktrees"
+	cfg.BranchTemplate = "{{type}}-{{number}}-{{slug}}"
+	cfg.DefaultBaseBranch = "it's-main"
+
+	lines := exportEnvLines(cfg)
+
+	want := []struct {
+		name  string
+		value string
+	}{
+		{"GIT_WT_WORKTREE_ROOT", "/tmp/my worktrees"},
+		{"GIT_WT_DEFAULT_REMOTE", "origin"},
+		{"GIT_WT_DEFAULT_BASE_BRANCH", "it's-main"},
+		{"GIT_WT_BRANCH_TEMPLATE", "{{type}}-{{number}}-{{slug}}"},
+		{"GIT_WT_GIT_TIMEOUT", "120"},
+		{"GIT_WT_WORKTREE_SUBDIR", ""},
+	}
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "export GIT_WT_") {
+			t.Errorf("expected export statement, got %s", line)
+		}
+	}
+
+	// Evaluate the lines in a real shell to make sure the quoting is valid
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+	script := strings.Join(lines, "\n")
+	for _, w := range want {
+		script += "\nprintf '%s\\n' \"$" + w.name + "\""
+	}
+	out, err := exec.Command(sh, "-c", script).Output()
+	if err != nil {
+		t.Fatalf("export lines failed to evaluate: %v\n%s", err, script)
+	}
+
+	got := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("expected %d values, got %d: %q", len(want), len(got), got)
+	}
+	for i, w := range want {
+		if got[i] != w.value {
+			t.Errorf("%s = %q, want %q", w.name, got[i], w.value)
+		}
+	}
+}
+
+func TestSetDefaultRemote(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	if out, err := exec.Command("git", "-C", dir, "remote", "add", "origin", "https://example.com/repo.git").CombinedOutput(); err != nil {
+		t.Fatalf("git remote add failed: %v\n%s", err, out)
+	}
+	configPath := filepath.Join(t.TempDir(), ".git-wt.toml")
+
+	actions, err := setDefaultRemote(dir, configPath, "upstream", "", "origin")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(actions) != 1 || actions[0] != "git remote rename origin upstream" {
+		t.Errorf("expected rename action, got %v", actions)
+	}
+
+	// The config and the git remote both follow the new name
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.DefaultRemote != "upstream" {
+		t.Errorf("expected default_remote upstream, got %q", cfg.DefaultRemote)
+	}
+	out, err := exec.Command("git", "-C", dir, "config", "--get", "remote.upstream.fetch").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "+refs/heads/*:refs/remotes/upstream/*" {
+		t.Errorf("unexpected upstream fetch refspec %q (%v)", out, err)
+	}
+
+	// A git failure leaves the config alone
+	if _, err := setDefaultRemote(dir, configPath, "missing", "", "nope"); err == nil {
+		t.Error("expected error for unknown remote without URL")
+	}
+	if cfg, _ := config.Load(configPath); cfg.DefaultRemote != "upstream" {
+		t.Errorf("expected config unchanged, got %q", cfg.DefaultRemote)
+	}
+}
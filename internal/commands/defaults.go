@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// commandDefaults returns a PreRunE that applies [command_defaults.<name>]
+// from config to the flags the user did not pass explicitly
+func commandDefaults(name string) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectRoot, _ := git.GetProjectRoot(".")
+		cfg, err := config.LoadWithRepo(config.GetConfigPath(), projectRoot)
+		if err != nil {
+			// Config errors are reported by the command itself
+			return nil
+		}
+
+		if err := applyCommandDefaults(cmd, name, cfg.CommandDefaults[name]); err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, name, nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+			}
+			return ui.NewCLIError(ui.ErrCodeValidation, err.Error())
+		}
+		return nil
+	}
+}
+
+// applyCommandDefaults sets each flag in defaults unless it was given on the
+// command line (cobra marks those as Changed)
+func applyCommandDefaults(cmd *cobra.Command, name string, defaults map[string]interface{}) error {
+	flags := make([]string, 0, len(defaults))
+	for flag := range defaults {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+
+	for _, flag := range flags {
+		f := cmd.Flags().Lookup(flag)
+		if f == nil {
+			return fmt.Errorf("invalid command_defaults.%s: unknown flag --%s", name, flag)
+		}
+		if f.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, fmt.Sprintf("%v", defaults[flag])); err != nil {
+			return fmt.Errorf("invalid command_defaults.%s.%s: %w", name, flag, err)
+		}
+	}
+	return nil
+}
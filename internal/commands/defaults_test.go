@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newDefaultsTestCmd() (*cobra.Command, *bool, *bool) {
+	var force, yes bool
+	cmd := &cobra.Command{Use: "delete"}
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "")
+	return cmd, &force, &yes
+}
+
+func TestApplyCommandDefaults(t *testing.T) {
+	cmd, force, yes := newDefaultsTestCmd()
+
+	if err := applyCommandDefaults(cmd, "delete", map[string]interface{}{"force": true, "yes": true}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !*force || !*yes {
+		t.Errorf("expected defaults to apply, got force=%t yes=%t", *force, *yes)
+	}
+}
+
+func TestApplyCommandDefaults_ExplicitFlagWins(t *testing.T) {
+	cmd, force, yes := newDefaultsTestCmd()
+	if err := cmd.ParseFlags([]string{"--force=false"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applyCommandDefaults(cmd, "delete", map[string]interface{}{"force": true, "yes": true}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if *force {
+		t.Error("expected explicit --force=false to override the config default")
+	}
+	if !*yes {
+		t.Error("expected yes default to apply")
+	}
+}
+
+func TestApplyCommandDefaults_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults map[string]interface{}
+	}{
+		{"unknown flag", map[string]interface{}{"no-such-flag": true}},
+		{"bad value", map[string]interface{}{"force": "sometimes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, _, _ := newDefaultsTestCmd()
+			if err := applyCommandDefaults(cmd, "delete", tt.defaults); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestCommandDefaults_FromConfig(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[command_defaults.delete]
+force = true
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config.SetConfigPath(configPath)
+	defer config.SetConfigPath("")
+
+	cmd, force, yes := newDefaultsTestCmd()
+	if err := commandDefaults("delete")(cmd, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !*force {
+		t.Error("expected force from command_defaults.delete")
+	}
+	if *yes {
+		t.Error("expected yes to keep its default")
+	}
+
+	other, force, _ := newDefaultsTestCmd()
+	if err := commandDefaults("prune")(other, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if *force {
+		t.Error("expected delete defaults not to apply to prune")
+	}
+}
@@ -9,17 +9,29 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/raisedadead/git-wt/internal/config"
 	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/hooks"
 	"github.com/raisedadead/git-wt/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 // DeleteData represents the JSON output for the delete command
 type DeleteData struct {
-	Branch        string `json:"branch"`
-	Path          string `json:"path"`
-	BranchDeleted bool   `json:"branch_deleted"`
-	DryRun        bool   `json:"dry_run,omitempty"`
-	Status        string `json:"status,omitempty"`
+	Branch          string             `json:"branch"`
+	Path            string             `json:"path"`
+	BranchDeleted   bool               `json:"branch_deleted"`
+	DryRun          bool               `json:"dry_run,omitempty"`
+	Status          string             `json:"status,omitempty"`
+	UnmergedCommits int                `json:"unmerged_commits"`
+	Skipped         string             `json:"skipped,omitempty"`
+	Hooks           []hooks.HookResult `json:"hooks,omitempty"`
+}
+
+// DeleteRecursiveData represents the JSON output for delete --recursive
+type DeleteRecursiveData struct {
+	Prefix    string       `json:"prefix"`
+	Worktrees []DeleteData `json:"worktrees"`
+	Removed   int          `json:"removed"`
+	DryRun    bool         `json:"dry_run,omitempty"`
 }
 
 var (
@@ -27,13 +39,20 @@ var (
 	dryRunDelete      bool
 	yesDelete         bool
 	deleteTimeoutFlag int
+	recursiveDelete   bool
 )
 
 var deleteCmd = &cobra.Command{
 	Use:     "delete [branch]",
 	Aliases: []string{"rm"},
 	Short:   "Remove a worktree and its branch",
+	Long: `Remove a worktree and its branch.
+
+With --recursive, remove every worktree whose branch is nested under the
+given prefix, e.g. "git wt delete --recursive feature/x" removes
+feature/x/part1 and feature/x/part2.`,
 	Args:    cobra.MaximumNArgs(1),
+	PreRunE: commandDefaults("delete"),
 	RunE:    runDelete,
 }
 
@@ -42,6 +61,7 @@ func init() {
 	deleteCmd.Flags().BoolVar(&dryRunDelete, "dry-run", false, "Show what would be deleted without deleting")
 	deleteCmd.Flags().BoolVarP(&yesDelete, "yes", "y", false, "Skip confirmation prompt")
 	deleteCmd.Flags().IntVar(&deleteTimeoutFlag, "timeout", 0, "Override git operation timeout (seconds)")
+	deleteCmd.Flags().BoolVarP(&recursiveDelete, "recursive", "r", false, "Delete all worktrees whose branch is nested under the given prefix")
 	rootCmd.AddCommand(deleteCmd)
 }
 
@@ -66,6 +86,16 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		cfg.GitTimeout = deleteTimeoutFlag
 	}
 
+	if recursiveDelete {
+		if len(args) == 0 {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "delete", nil, ui.NewCLIError(ui.ErrCodeValidation, "--recursive requires a branch prefix"))
+			}
+			return fmt.Errorf("--recursive requires a branch prefix")
+		}
+		return runDeleteRecursive(projectRoot, cfg, args[0])
+	}
+
 	if len(args) > 0 {
 		branchName = args[0]
 	} else {
@@ -89,8 +119,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		// Build options excluding default branch and .bare
 		var options []huh.Option[string]
 		for _, wt := range worktrees {
-			if wt.Branch == "" || wt.Branch == defaultBranch ||
-				strings.HasSuffix(wt.Path, "/.bare") {
+			if wt.Branch == "" || wt.Branch == defaultBranch || wt.Bare {
 				continue
 			}
 			options = append(options, huh.NewOption(wt.Branch, wt.Branch))
@@ -115,9 +144,15 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Use flattened branch name for directory path
-	worktreeDir := git.FlattenBranchName(branchName)
-	worktreePath := filepath.Join(projectRoot, worktreeDir)
+	// Resolve the worktree from git (handles worktree_subdir and moved
+	// worktrees), falling back to the flattened branch name
+	worktreePath := filepath.Join(projectRoot, git.FlattenBranchName(branchName))
+	var locked bool
+	var lockReason string
+	if wt, err := git.FindWorktreeForBranch(projectRoot, branchName); err == nil && wt != nil {
+		worktreePath = wt.Path
+		locked, lockReason = wt.Locked, wt.LockReason
+	}
 
 	// Check if worktree exists
 	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
@@ -127,36 +162,62 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("worktree not found: %s", branchName)
 	}
 
+	// An unreadable status is treated as dirty so it still requires --force
+	status, statusErr := git.GetWorktreeStatus(worktreePath)
+	statusText := status.String()
+	if statusErr != nil {
+		statusText = "unknown"
+	}
+	dirty := statusErr != nil || !status.IsClean()
+
+	// Count commits that would be lost by force-deleting the branch
+	defaultBranch, _ := git.GetDefaultBranch(projectRoot)
+	unmerged, _ := git.CountUnmergedCommits(projectRoot, branchName, defaultBranch)
+
+	// Untracked-only worktrees may be deleted without --force when configured
+	untrackedOnly := statusErr == nil && status.UntrackedOnly() && cfg.IgnoreUntracked()
+
 	// Dry run mode
 	if dryRunDelete {
-		status, _ := git.GetWorktreeStatus(worktreePath)
 		if IsJSONOutput() {
 			data := DeleteData{
-				Branch: branchName,
-				Path:   worktreePath,
-				DryRun: true,
-				Status: status,
+				Branch:          branchName,
+				Path:            worktreePath,
+				DryRun:          true,
+				Status:          statusText,
+				UnmergedCommits: unmerged,
 			}
 			return ui.OutputJSON(os.Stdout, "delete", data, nil)
 		}
 		fmt.Println(ui.InfoMsg("Dry run - would delete:"))
 		fmt.Printf("  Worktree: %s\n", worktreePath)
 		fmt.Printf("  Branch: %s\n", branchName)
-		if status != "clean" {
-			fmt.Println(ui.WarningMsg(fmt.Sprintf("  Status: %s", status)))
+		if dirty {
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("  Status: %s", statusText)))
+		}
+		if unmerged > 0 {
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("  Unmerged commits: %d (will be lost)", unmerged)))
 		}
 		return nil
 	}
 
+	// A locked worktree was explicitly marked to keep
+	if locked && !forceDelete {
+		msg := fmt.Sprintf("worktree is %s, use --force to delete", lockedText(lockReason))
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "delete", nil, ui.NewCLIError(ui.ErrCodeValidation, msg))
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
 	// Check for uncommitted changes
-	status, _ := git.GetWorktreeStatus(worktreePath)
-	if status != "clean" && !forceDelete {
+	if dirty && !untrackedOnly && !forceDelete {
 		// Dirty worktrees require --force flag
 		if IsJSONOutput() {
-			return ui.OutputJSON(os.Stdout, "delete", nil, ui.NewCLIError(ui.ErrCodeValidation, fmt.Sprintf("worktree has uncommitted changes, use --force to delete (status: %s)", status)))
+			return ui.OutputJSON(os.Stdout, "delete", nil, ui.NewCLIError(ui.ErrCodeValidation, fmt.Sprintf("worktree has %s, use --force to delete (status: %s)", dirtyReason(status), statusText)))
 		}
 
-		fmt.Println(ui.WarningMsg(fmt.Sprintf("%s has uncommitted changes:", branchName)))
+		fmt.Println(ui.WarningMsg(fmt.Sprintf("%s has %s:", branchName, dirtyReason(status))))
 
 		// Show changed files
 		output, _ := git.RunInDirWithTimeout(worktreePath, cfg.GitTimeout, "status", "--porcelain")
@@ -172,18 +233,29 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	if !yesDelete && !IsJSONOutput() {
 		title := fmt.Sprintf("Delete worktree '%s'?", branchName)
 		affirmative := "Yes, delete"
-		if status != "clean" {
-			title = fmt.Sprintf("Delete worktree '%s' with uncommitted changes?", branchName)
+		negative := "Cancel"
+		if untrackedOnly {
+			title = fmt.Sprintf("Delete worktree '%s' with %d untracked files?", branchName, status.Untracked)
+			affirmative = "Yes, discard untracked files"
+			negative = "No, keep worktree"
+		} else if dirty {
+			title = fmt.Sprintf("Delete worktree '%s' with %s?", branchName, dirtyReason(status))
 			affirmative = "Yes, discard changes"
 		}
 
+		var description string
+		if unmerged > 0 {
+			description = fmt.Sprintf("%d unmerged commits on %s will be lost", unmerged, branchName)
+		}
+
 		var confirm bool
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewConfirm().
 					Title(title).
+					Description(description).
 					Affirmative(affirmative).
-					Negative("Cancel").
+					Negative(negative).
 					Value(&confirm),
 			),
 		)
@@ -198,16 +270,33 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// pre_delete hooks can veto the deletion, e.g. of protected branches. They
+	// run last, once the deletion is otherwise going ahead.
+	if err := runPreDeleteHooks(projectRoot, worktreePath, branchName, cfg); err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "delete", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+		}
+		return err
+	}
+
 	if !IsJSONOutput() {
+		if unmerged > 0 && yesDelete {
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("Deleting %s with %d unmerged commits", branchName, unmerged)))
+		}
 		fmt.Println(ui.SubtleStyle.Render("Deleting worktree..."))
 	}
 
-	// Remove worktree
+	// Remove worktree; git refuses to remove a locked one
 	var removeErr error
-	if forceDelete {
-		removeErr = git.RemoveWorktreeForce(projectRoot, worktreePath)
-	} else {
-		removeErr = git.RemoveWorktree(projectRoot, worktreePath)
+	if locked {
+		removeErr = git.UnlockWorktree(projectRoot, worktreePath)
+	}
+	if removeErr == nil {
+		if forceDelete || untrackedOnly {
+			removeErr = git.RemoveWorktreeForce(projectRoot, worktreePath)
+		} else {
+			removeErr = git.RemoveWorktree(projectRoot, worktreePath)
+		}
 	}
 
 	if removeErr != nil {
@@ -233,12 +322,16 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	hookResults := runRemovalHooks(cfg.Hooks.PostDelete, projectRoot, worktreePath, branchName, cfg.HookTimeout)
+
 	// JSON output
 	if IsJSONOutput() {
 		data := DeleteData{
-			Branch:        branchName,
-			Path:          worktreePath,
-			BranchDeleted: branchDeleted,
+			Branch:          branchName,
+			Path:            worktreePath,
+			BranchDeleted:   branchDeleted,
+			UnmergedCommits: unmerged,
+			Hooks:           hookResults,
 		}
 		return ui.OutputJSON(os.Stdout, "delete", data, nil)
 	}
@@ -246,9 +339,227 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// lockedText describes a worktree lock, e.g. "locked (on a USB drive)"
+func lockedText(reason string) string {
+	if reason == "" {
+		return "locked"
+	}
+	return fmt.Sprintf("locked (%s)", reason)
+}
+
 func splitByNewline(s string) []string {
 	if s == "" {
 		return nil
 	}
 	return strings.Split(strings.TrimSpace(s), "\n")
 }
+
+// runDeleteRecursive removes every worktree in a branch family, checking each
+// for uncommitted changes individually
+func runDeleteRecursive(projectRoot string, cfg *config.Config, prefix string) error {
+	worktrees, err := git.ListWorktrees(projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "delete", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	defaultBranch, _ := git.GetDefaultBranch(projectRoot)
+	cwd, _ := os.Getwd()
+
+	family := branchFamily(worktrees, prefix, defaultBranch, cwd)
+	if len(family) == 0 {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "delete", nil, ui.NewCLIError(ui.ErrCodeNotFound, fmt.Sprintf("no worktrees found under %s/", prefix)))
+		}
+		return fmt.Errorf("no worktrees found under %s/", prefix)
+	}
+
+	// Check every worktree up front so the confirmation shows the full plan
+	results := make([]DeleteData, len(family))
+	for i, wt := range family {
+		status, statusErr := git.GetWorktreeStatus(wt.Path)
+		statusText := status.String()
+		if statusErr != nil {
+			statusText = "unknown"
+		}
+		unmerged, _ := git.CountUnmergedCommits(projectRoot, wt.Branch, defaultBranch)
+
+		results[i] = DeleteData{
+			Branch:          wt.Branch,
+			Path:            wt.Path,
+			DryRun:          dryRunDelete,
+			Status:          statusText,
+			UnmergedCommits: unmerged,
+		}
+
+		dirty := statusErr != nil || !status.IsClean()
+		untrackedOnly := statusErr == nil && status.UntrackedOnly() && cfg.IgnoreUntracked()
+		if wt.Locked && !forceDelete {
+			results[i].Skipped = lockedText(wt.LockReason) + " (use --force)"
+		} else if dirty && !untrackedOnly && !forceDelete {
+			results[i].Skipped = dirtyReason(status) + " (use --force)"
+		}
+	}
+
+	if !IsJSONOutput() {
+		fmt.Printf("Worktrees under %s/:\n", prefix)
+		for _, res := range results {
+			line := "  • " + res.Branch
+			if res.Skipped != "" {
+				line += ui.WarningStyle.Render(" (skipped: " + res.Skipped + ")")
+			} else if res.Status != "clean" {
+				line += ui.SubtleStyle.Render(" (" + res.Status + ")")
+			}
+			if res.UnmergedCommits > 0 {
+				line += ui.WarningStyle.Render(fmt.Sprintf(" [%d unmerged commits]", res.UnmergedCommits))
+			}
+			fmt.Println(line)
+		}
+		fmt.Println()
+	}
+
+	if dryRunDelete {
+		if IsJSONOutput() {
+			data := DeleteRecursiveData{Prefix: prefix, Worktrees: results, DryRun: true}
+			return ui.OutputJSON(os.Stdout, "delete", data, nil)
+		}
+		fmt.Println(ui.InfoMsg("Dry run - no changes made"))
+		return nil
+	}
+
+	// Confirmation prompt (skip with --yes or --json)
+	if !yesDelete && !IsJSONOutput() {
+		var confirm bool
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(fmt.Sprintf("Delete these worktrees under '%s/'?", prefix)).
+					Affirmative("Yes, delete").
+					Negative("Cancel").
+					Value(&confirm),
+			),
+		)
+
+		if err := form.Run(); err != nil {
+			return err
+		}
+
+		if !confirm {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	removed := 0
+	for i := range results {
+		res := &results[i]
+		if res.Skipped != "" {
+			continue
+		}
+
+		if err := runPreDeleteHooks(projectRoot, res.Path, res.Branch, cfg); err != nil {
+			res.Skipped = err.Error()
+			if !IsJSONOutput() {
+				fmt.Println(ui.WarningMsg(fmt.Sprintf("Skipped %s: %v", res.Branch, err)))
+			}
+			continue
+		}
+
+		var removeErr error
+		if family[i].Locked {
+			removeErr = git.UnlockWorktree(projectRoot, res.Path)
+		}
+		if removeErr == nil {
+			if res.Status != "clean" {
+				removeErr = git.RemoveWorktreeForce(projectRoot, res.Path)
+			} else {
+				removeErr = git.RemoveWorktree(projectRoot, res.Path)
+			}
+		}
+		if removeErr != nil {
+			res.Skipped = removeErr.Error()
+			if !IsJSONOutput() {
+				fmt.Println(ui.WarningMsg(fmt.Sprintf("Failed to remove %s: %v", res.Branch, removeErr)))
+			}
+			continue
+		}
+		removed++
+
+		if err := git.DeleteBranch(projectRoot, res.Branch); err != nil {
+			if !IsJSONOutput() {
+				fmt.Println(ui.WarningMsg(fmt.Sprintf("Could not delete branch %s: %v", res.Branch, err)))
+			}
+		} else {
+			res.BranchDeleted = true
+		}
+		if !IsJSONOutput() {
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Deleted %s", res.Branch)))
+		}
+		res.Hooks = runRemovalHooks(cfg.Hooks.PostDelete, projectRoot, res.Path, res.Branch, cfg.HookTimeout)
+	}
+
+	if IsJSONOutput() {
+		data := DeleteRecursiveData{Prefix: prefix, Worktrees: results, Removed: removed}
+		return ui.OutputJSON(os.Stdout, "delete", data, nil)
+	}
+
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Removed %d worktrees under %s/", removed, prefix)))
+	return nil
+}
+
+// runPreDeleteHooks runs the pre_delete hooks for a worktree; an error means
+// a hook vetoed its deletion
+func runPreDeleteHooks(projectRoot, worktreePath, branchName string, cfg *config.Config) error {
+	if HooksDisabled() || len(cfg.Hooks.PreDelete) == 0 {
+		return nil
+	}
+	hookCtx := newHookContext(projectRoot, worktreePath, branchName)
+	workDir, err := hooks.ResolveWorkDir(cfg.HookWorkdir, worktreePath, projectRoot)
+	if err != nil {
+		return fmt.Errorf("pre_delete hook failed: %w", err)
+	}
+	hookCtx.WorkDir = workDir
+	if err := hooks.RunBlocking(cfg.Hooks.PreDelete, hookCtx, cfg.HookTimeout); err != nil {
+		return fmt.Errorf("pre_delete hook failed: %w", err)
+	}
+	return nil
+}
+
+// branchFamily returns the worktrees whose branch is the prefix itself or is
+// nested under it (prefix/...). The default branch and the worktree
+// containing cwd are never part of a family, so a recursive delete cannot
+// remove them.
+func branchFamily(worktrees []git.Worktree, prefix, defaultBranch, cwd string) []git.Worktree {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if resolved, err := filepath.EvalSymlinks(cwd); err == nil {
+		cwd = resolved
+	}
+	var family []git.Worktree
+	for _, wt := range worktrees {
+		if wt.Branch == "" || wt.Branch == defaultBranch {
+			continue
+		}
+		path := wt.Path
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		if cwd == path || isSubpath(path, cwd) {
+			continue
+		}
+		if wt.Branch == prefix || strings.HasPrefix(wt.Branch, prefix+"/") {
+			family = append(family, wt)
+		}
+	}
+	return family
+}
+
+// dirtyReason describes the changes that make a worktree need --force,
+// calling out merge conflicts, which are easy to forget mid-merge
+func dirtyReason(status git.WorktreeStatus) string {
+	if status.Conflicted > 0 {
+		return "unresolved merge conflicts"
+	}
+	return "uncommitted changes"
+}
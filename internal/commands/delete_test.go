@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+func TestBranchFamily(t *testing.T) {
+	worktrees := []git.Worktree{
+		{Path: "/proj/.bare", Bare: true},
+		{Path: "/proj/main", Branch: "main"},
+		{Path: "/proj/feature-x-part1", Branch: "feature/x/part1"},
+		{Path: "/proj/feature-x-part2", Branch: "feature/x/part2"},
+		{Path: "/proj/feature-x-part2-fix", Branch: "feature/x/part2/fix"},
+		{Path: "/proj/feature-xy", Branch: "feature/xy"},
+		{Path: "/proj/feature-y", Branch: "feature/y"},
+	}
+
+	family := branchFamily(worktrees, "feature/x", "main", "/elsewhere")
+
+	want := []string{"feature/x/part1", "feature/x/part2", "feature/x/part2/fix"}
+	if len(family) != len(want) {
+		t.Fatalf("expected %d worktrees, got %+v", len(want), family)
+	}
+	for i, wt := range family {
+		if wt.Branch != want[i] {
+			t.Errorf("expected %s, got %s", want[i], wt.Branch)
+		}
+	}
+
+	// A trailing slash on the prefix is accepted
+	if got := branchFamily(worktrees, "feature/x/", "main", "/elsewhere"); len(got) != len(want) {
+		t.Errorf("expected trailing slash to match %d worktrees, got %d", len(want), len(got))
+	}
+
+	// The exact prefix branch is part of its family
+	if got := branchFamily(worktrees, "feature/y", "main", "/elsewhere"); len(got) != 1 || got[0].Branch != "feature/y" {
+		t.Errorf("expected only feature/y, got %+v", got)
+	}
+
+	if got := branchFamily(worktrees, "bugfix", "main", "/elsewhere"); len(got) != 0 {
+		t.Errorf("expected no matches, got %+v", got)
+	}
+
+	// The default branch is never part of a family, even as the prefix
+	if got := branchFamily(worktrees, "main", "main", "/elsewhere"); len(got) != 0 {
+		t.Errorf("expected the default branch to be excluded, got %+v", got)
+	}
+
+	// Nor is the worktree being run from
+	got := branchFamily(worktrees, "feature/x", "main", "/proj/feature-x-part2/src")
+	if len(got) != 2 || got[0].Branch != "feature/x/part1" || got[1].Branch != "feature/x/part2/fix" {
+		t.Errorf("expected the current worktree to be excluded, got %+v", got)
+	}
+}
+
+func TestRunPreDeleteHooks(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	cfg := config.DefaultConfig()
+	cfg.Hooks.PreDelete = []string{`test "$GIT_WT_BRANCH" != protected`}
+
+	if err := runPreDeleteHooks(dir, dir, "feature", cfg); err != nil {
+		t.Errorf("expected hook to allow deletion, got %v", err)
+	}
+
+	err := runPreDeleteHooks(dir, dir, "protected", cfg)
+	if err == nil || !strings.Contains(err.Error(), "pre_delete hook failed") {
+		t.Errorf("expected hook to veto deletion, got %v", err)
+	}
+
+	noHooksFlag = true
+	t.Cleanup(func() { noHooksFlag = false })
+	if err := runPreDeleteHooks(dir, dir, "protected", cfg); err != nil {
+		t.Errorf("expected --no-hooks to skip the hook, got %v", err)
+	}
+}
+
+func TestDirtyReason(t *testing.T) {
+	if got := dirtyReason(git.WorktreeStatus{Modified: 1}); got != "uncommitted changes" {
+		t.Errorf("unexpected reason %q", got)
+	}
+	if got := dirtyReason(git.WorktreeStatus{Modified: 1, Conflicted: 2}); got != "unresolved merge conflicts" {
+		t.Errorf("unexpected reason %q", got)
+	}
+}
+
+func TestLockedText(t *testing.T) {
+	tests := map[string]string{
+		"":               "locked",
+		"on a USB drive": "locked (on a USB drive)",
+	}
+	for reason, want := range tests {
+		if got := lockedText(reason); got != want {
+			t.Errorf("lockedText(%q) = %q, want %q", reason, got, want)
+		}
+	}
+}
+
+func TestDelete_PreDeleteHookRunsAfterChecks(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	projectRoot := initDoctorTestProject(t)
+	marker := filepath.Join(t.TempDir(), "hook-ran")
+	repoCfg := "[hooks]\npre_delete = [\"touch '" + marker + "'\"]\n"
+	if err := os.WriteFile(config.GetRepoConfigPath(projectRoot), []byte(repoCfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"branch", "feature/x/locked", "main"},
+		{"branch", "feature/x/dirty", "main"},
+		{"worktree", "add", "-q", filepath.Join(projectRoot, "feature-x-locked"), "feature/x/locked"},
+		{"worktree", "add", "-q", filepath.Join(projectRoot, "feature-x-dirty"), "feature/x/dirty"},
+		{"worktree", "lock", filepath.Join(projectRoot, "feature-x-locked")},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", projectRoot}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(projectRoot, "feature-x-dirty", "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(projectRoot); err != nil {
+		t.Fatal(err)
+	}
+	jsonOutputFlag = true
+	t.Cleanup(func() { jsonOutputFlag = false; recursiveDelete = false })
+	stdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout; _ = devNull.Close() }()
+
+	// Refused deletions must not run pre_delete hooks
+	for _, branch := range []string{"feature/x/locked", "feature/x/dirty"} {
+		_ = runDelete(deleteCmd, []string{branch})
+		if _, err := os.Stat(marker); err == nil {
+			t.Fatalf("pre_delete hook ran for refused delete of %s", branch)
+		}
+	}
+	recursiveDelete = true
+	_ = runDelete(deleteCmd, []string{"feature/x"})
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("pre_delete hook ran for skipped worktrees in a recursive delete")
+	}
+}
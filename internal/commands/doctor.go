@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Doctor check names
+const (
+	CheckGitPointer    = "git_pointer"
+	CheckWorktreeLinks = "worktree_links"
+	CheckStaleEntries  = "stale_entries"
+	CheckRemoteHead    = "remote_head"
+)
+
+// Fix statuses: attempted means the fix ran but the problem is still there
+const (
+	FixSucceeded = "succeeded"
+	FixAttempted = "attempted"
+	FixFailed    = "failed"
+)
+
+// DoctorData represents the JSON output for the doctor command
+type DoctorData struct {
+	ProjectRoot string        `json:"project_root"`
+	Checks      []DoctorCheck `json:"checks"`
+	Healthy     bool          `json:"healthy"`
+}
+
+// DoctorCheck is the result of one health check
+type DoctorCheck struct {
+	Name    string     `json:"name"`
+	OK      bool       `json:"ok"`
+	Detail  string     `json:"detail,omitempty"`
+	Fixable bool       `json:"fixable"`
+	Fix     *DoctorFix `json:"fix,omitempty"`
+}
+
+// DoctorFix reports a remediation run by doctor --fix
+type DoctorFix struct {
+	Action string `json:"action"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// doctorCheck pairs a health check with its (non-destructive) fix. A nil fix
+// means the problem has to be resolved by hand.
+type doctorCheck struct {
+	name   string
+	action string
+	check  func() (detail string, ok bool)
+	fix    func() error
+}
+
+var fixDoctor bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the project for common problems",
+	Long: `Check the current git-wt project for common problems: a broken .git
+pointer, worktrees whose links need repair, stale worktree entries, and a
+missing remote HEAD.
+
+With --fix, apply the safe remediations (rewrite the .git pointer, git
+worktree repair, git worktree prune, git remote set-head --auto). Fixes that
+could lose data are never applied automatically.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&fixDoctor, "fix", false, "Attempt safe fixes for the problems found")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	// A broken .git pointer hides the project from GetProjectRoot
+	projectRoot, err := git.FindBareRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "doctor", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	cfg, err := config.LoadWithRepo(config.GetConfigPath(), projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "doctor", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	checks := runDoctorChecks(doctorChecks(projectRoot, cfg), fixDoctor)
+	data := DoctorData{ProjectRoot: projectRoot, Checks: checks, Healthy: true}
+	for _, c := range checks {
+		if !c.OK {
+			data.Healthy = false
+		}
+	}
+
+	if IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "doctor", data, nil)
+	}
+
+	fixable := 0
+	for _, c := range checks {
+		switch {
+		case c.OK && c.Fix != nil:
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("%s: fixed (%s)", c.Name, c.Fix.Action)))
+		case c.OK:
+			fmt.Println(ui.SuccessMsg(c.Name))
+		default:
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("%s: %s", c.Name, c.Detail)))
+			if c.Fix != nil && c.Fix.Error != "" {
+				fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("    %s failed: %s", c.Fix.Action, c.Fix.Error)))
+			}
+			if c.Fixable && c.Fix == nil {
+				fixable++
+			}
+		}
+	}
+
+	if data.Healthy {
+		fmt.Println()
+		fmt.Println(ui.SuccessMsg("No problems found"))
+	} else if fixable > 0 {
+		fmt.Println()
+		fmt.Println(ui.InfoMsg("Run git wt doctor --fix to attempt safe fixes"))
+	}
+	return nil
+}
+
+// doctorChecks lists the checks for a project, in the order fixes should be
+// applied (the .git pointer first, since git commands depend on it)
+func doctorChecks(projectRoot string, cfg *config.Config) []doctorCheck {
+	remote := cfg.DefaultRemote
+
+	return []doctorCheck{
+		{
+			name:   CheckGitPointer,
+			action: "rewrite .git pointer",
+			check: func() (string, bool) {
+				if err := git.CheckGitPointer(projectRoot); err != nil {
+					return err.Error(), false
+				}
+				return "", true
+			},
+			fix: func() error {
+				// Never replace a .git directory: that would delete a repository
+				if info, err := os.Stat(filepath.Join(projectRoot, git.GitPointerFile)); err == nil && info.IsDir() {
+					return fmt.Errorf("refusing to replace a .git directory")
+				}
+				return git.WriteGitPointer(projectRoot)
+			},
+		},
+		{
+			name:   CheckWorktreeLinks,
+			action: "git worktree repair",
+			check: func() (string, bool) {
+				broken, err := git.CheckWorktreeLinks(projectRoot)
+				if err != nil {
+					return err.Error(), false
+				}
+				if len(broken) == 0 {
+					return "", true
+				}
+				paths := make([]string, 0, len(broken))
+				for _, link := range broken {
+					paths = append(paths, shortenPath(link.Path))
+				}
+				return fmt.Sprintf("%d worktree(s) need repair: %s", len(broken), strings.Join(paths, ", ")), false
+			},
+			fix: func() error {
+				broken, err := git.CheckWorktreeLinks(projectRoot)
+				if err != nil {
+					return err
+				}
+				var paths []string
+				for _, link := range broken {
+					if _, err := os.Stat(filepath.Join(link.Path, git.GitPointerFile)); err == nil {
+						paths = append(paths, link.Path)
+					}
+				}
+				_, err = git.RepairWorktrees(projectRoot, paths...)
+				return err
+			},
+		},
+		{
+			name:   CheckStaleEntries,
+			action: "git worktree prune",
+			check: func() (string, bool) {
+				worktrees, err := git.ListWorktrees(projectRoot)
+				if err != nil {
+					return err.Error(), false
+				}
+				var stale []string
+				for _, wt := range worktrees {
+					if wt.Prunable != "" {
+						stale = append(stale, shortenPath(wt.Path))
+					}
+				}
+				if len(stale) == 0 {
+					return "", true
+				}
+				return fmt.Sprintf("%d stale worktree entr(ies): %s", len(stale), strings.Join(stale, ", ")), false
+			},
+			fix: func() error {
+				return git.PruneWorktrees(projectRoot)
+			},
+		},
+		{
+			name:   CheckRemoteHead,
+			action: fmt.Sprintf("git remote set-head %s --auto", remote),
+			check: func() (string, bool) {
+				if _, err := git.RunInDir(projectRoot, "remote", "get-url", remote); err != nil {
+					// Nothing to check for projects without the remote
+					return "", true
+				}
+				if !git.HasRemoteHead(projectRoot, remote) {
+					return fmt.Sprintf("%s/HEAD is not set", remote), false
+				}
+				return "", true
+			},
+			fix: func() error {
+				return git.SetRemoteHead(projectRoot, remote, cfg.GitTimeout)
+			},
+		},
+	}
+}
+
+// runDoctorChecks runs each check and, when fix is set, the fix for each
+// failing check followed by a re-check to confirm it worked
+func runDoctorChecks(checks []doctorCheck, fix bool) []DoctorCheck {
+	results := make([]DoctorCheck, 0, len(checks))
+	for _, c := range checks {
+		detail, ok := c.check()
+		result := DoctorCheck{Name: c.name, OK: ok, Detail: detail, Fixable: c.fix != nil}
+
+		if !ok && fix && c.fix != nil {
+			result.Fix = &DoctorFix{Action: c.action}
+			if err := c.fix(); err != nil {
+				result.Fix.Status = FixFailed
+				result.Fix.Error = err.Error()
+			} else if detail, ok := c.check(); ok {
+				result.Fix.Status = FixSucceeded
+				result.OK = true
+				result.Detail = ""
+			} else {
+				result.Fix.Status = FixAttempted
+				result.Detail = detail
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
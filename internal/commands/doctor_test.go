@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+// initDoctorTestProject creates a git-wt project cloned from a local repo,
+// with remote-tracking refs fetched but origin/HEAD unset
+func initDoctorTestProject(t *testing.T) string {
+	t.Helper()
+	src := initCommandTestRepo(t)
+	projectRoot := filepath.Join(t.TempDir(), "project")
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	run(src, "clone", "--bare", src, filepath.Join(projectRoot, git.BareDir))
+	if err := git.WriteGitPointer(projectRoot); err != nil {
+		t.Fatal(err)
+	}
+	run(projectRoot, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
+	run(projectRoot, "fetch", "-q", "origin")
+	run(projectRoot, "worktree", "add", "-q", filepath.Join(projectRoot, "main"), "main")
+	return projectRoot
+}
+
+// doctorResults runs the project's checks, optionally with fixes, by name
+func doctorResults(t *testing.T, projectRoot string, fix bool) map[string]DoctorCheck {
+	t.Helper()
+	results := make(map[string]DoctorCheck)
+	for _, r := range runDoctorChecks(doctorChecks(projectRoot, config.DefaultConfig()), fix) {
+		results[r.Name] = r
+	}
+	return results
+}
+
+func TestDoctor_RemoteHead(t *testing.T) {
+	projectRoot := initDoctorTestProject(t)
+
+	if r := doctorResults(t, projectRoot, false)[CheckRemoteHead]; r.OK || !r.Fixable || r.Fix != nil {
+		t.Fatalf("expected missing origin/HEAD to be reported without fixing, got %+v", r)
+	}
+
+	r := doctorResults(t, projectRoot, true)[CheckRemoteHead]
+	if !r.OK || r.Fix == nil || r.Fix.Status != FixSucceeded {
+		t.Errorf("expected origin/HEAD fix to succeed, got %+v (fix %+v)", r, r.Fix)
+	}
+	if !git.HasRemoteHead(projectRoot, "origin") {
+		t.Error("expected origin/HEAD to be set")
+	}
+}
+
+func TestDoctor_GitPointer(t *testing.T) {
+	projectRoot := initDoctorTestProject(t)
+	pointer := filepath.Join(projectRoot, git.GitPointerFile)
+	if err := os.WriteFile(pointer, []byte("gitdir: /old/location/.bare\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := doctorResults(t, projectRoot, true)[CheckGitPointer]
+	if !r.OK || r.Fix == nil || r.Fix.Status != FixSucceeded {
+		t.Errorf("expected .git pointer fix to succeed, got %+v (fix %+v)", r, r.Fix)
+	}
+	if err := git.CheckGitPointer(projectRoot); err != nil {
+		t.Errorf("expected valid pointer after fix, got %v", err)
+	}
+}
+
+func TestDoctor_GitPointerDirectoryNotReplaced(t *testing.T) {
+	projectRoot := initDoctorTestProject(t)
+	pointer := filepath.Join(projectRoot, git.GitPointerFile)
+	if err := os.Remove(pointer); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(pointer, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	r := doctorResults(t, projectRoot, true)[CheckGitPointer]
+	if r.OK || r.Fix == nil || r.Fix.Status != FixFailed {
+		t.Errorf("expected fix to refuse a .git directory, got %+v (fix %+v)", r, r.Fix)
+	}
+	if info, err := os.Stat(pointer); err != nil || !info.IsDir() {
+		t.Error("expected .git directory to be left alone")
+	}
+}
+
+func TestDoctor_StaleEntries(t *testing.T) {
+	projectRoot := initDoctorTestProject(t)
+	if err := os.RemoveAll(filepath.Join(projectRoot, "main")); err != nil {
+		t.Fatal(err)
+	}
+
+	if r := doctorResults(t, projectRoot, false)[CheckStaleEntries]; r.OK || !strings.Contains(r.Detail, "main") {
+		t.Fatalf("expected stale entry for main, got %+v", r)
+	}
+
+	r := doctorResults(t, projectRoot, true)[CheckStaleEntries]
+	if !r.OK || r.Fix == nil || r.Fix.Status != FixSucceeded {
+		t.Errorf("expected prune fix to succeed, got %+v (fix %+v)", r, r.Fix)
+	}
+}
+
+func TestDoctor_WorktreeLinks(t *testing.T) {
+	projectRoot := initDoctorTestProject(t)
+	if err := os.Rename(filepath.Join(projectRoot, "main"), filepath.Join(projectRoot, "main-moved")); err != nil {
+		t.Fatal(err)
+	}
+
+	if r := doctorResults(t, projectRoot, false)[CheckWorktreeLinks]; r.OK {
+		t.Fatalf("expected moved worktree to need repair, got %+v", r)
+	}
+
+	r := doctorResults(t, projectRoot, true)[CheckWorktreeLinks]
+	if !r.OK || r.Fix == nil || r.Fix.Status != FixSucceeded {
+		t.Errorf("expected repair fix to succeed, got %+v (fix %+v)", r, r.Fix)
+	}
+}
+
+func TestDoctor_Healthy(t *testing.T) {
+	projectRoot := initDoctorTestProject(t)
+	if err := git.SetRemoteHead(projectRoot, "origin", 30); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, r := range doctorResults(t, projectRoot, true) {
+		if !r.OK || r.Fix != nil {
+			t.Errorf("%s: expected healthy with no fix, got %+v", name, r)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ExecData represents the JSON output for the exec command
+type ExecData struct {
+	Command []string     `json:"command"`
+	Results []ExecResult `json:"results"`
+	Skipped []ExecSkip   `json:"skipped,omitempty"`
+	Failed  int          `json:"failed"`
+}
+
+// ExecSkip is a worktree left out by --dirty-only
+type ExecSkip struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// ExecResult is the outcome of running the command in one worktree
+type ExecResult struct {
+	Branch   string `json:"branch"`
+	Path     string `json:"path"`
+	ExitCode int    `json:"exit_code"`
+	Output   string `json:"output"`
+	Error    string `json:"error,omitempty"`
+}
+
+var (
+	continueOnError bool
+	execDirtyOnly   bool
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec -- <command> [args...]",
+	Short: "Run a command in every worktree",
+	Long: `Run a command in each worktree of the project, one after another,
+reporting the exit status of each. The command is run directly, not through
+a shell; use sh -c for pipes or globs.
+
+Stops at the first failure unless --continue-on-error is given. With
+--dirty-only, worktrees without uncommitted changes are skipped.
+
+Examples:
+  git wt exec -- git status -s
+  git wt exec --continue-on-error -- pnpm install
+  git wt exec --dirty-only -- git stash
+  git wt exec -- sh -c 'git log -1 --oneline'`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runExec,
+}
+
+func init() {
+	execCmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Keep going after the command fails in a worktree")
+	execCmd.Flags().BoolVar(&execDirtyOnly, "dirty-only", false, "Only run in worktrees with uncommitted changes")
+	// Flags after the command belong to it, e.g. git wt exec git status -s
+	execCmd.Flags().SetInterspersed(false)
+	rootCmd.AddCommand(execCmd)
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "exec", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	worktrees, err := git.ListWorktrees(projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "exec", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	// Ctrl-C stops the running command (and its children) and the loop
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	targets := execTargets(worktrees)
+	var skipped []ExecSkip
+	if execDirtyOnly {
+		targets, skipped = dirtyTargets(targets)
+		if !IsJSONOutput() {
+			for _, s := range skipped {
+				fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Skipping %s: %s", s.Branch, s.Reason)))
+			}
+		}
+	}
+
+	results := execInWorktrees(ctx, targets, args, continueOnError, IsJSONOutput())
+	failed := 0
+	for _, r := range results {
+		if r.ExitCode != 0 {
+			failed++
+		}
+	}
+
+	if IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "exec", ExecData{Command: args, Results: results, Skipped: skipped, Failed: failed}, nil)
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("command failed in %d of %d worktrees", failed, len(results))
+	}
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Ran in %d worktrees", len(results))))
+	return nil
+}
+
+// execTargets returns the worktrees exec runs in, skipping the bare
+// repository and detached worktrees
+func execTargets(worktrees []git.Worktree) []git.Worktree {
+	var targets []git.Worktree
+	for _, wt := range worktrees {
+		if wt.Branch == "" || wt.Bare {
+			continue
+		}
+		targets = append(targets, wt)
+	}
+	return targets
+}
+
+// dirtyTargets splits worktrees into those with uncommitted changes and the
+// skipped rest. A worktree whose status cannot be read is skipped too, since
+// --dirty-only is often used for sweeping commands like git add -A.
+func dirtyTargets(worktrees []git.Worktree) ([]git.Worktree, []ExecSkip) {
+	var dirty []git.Worktree
+	var skipped []ExecSkip
+	for _, wt := range worktrees {
+		status, err := git.GetWorktreeStatus(wt.Path)
+		switch {
+		case err != nil:
+			skipped = append(skipped, ExecSkip{Branch: wt.Branch, Path: wt.Path, Reason: "status unknown: " + err.Error()})
+		case status.IsClean():
+			skipped = append(skipped, ExecSkip{Branch: wt.Branch, Path: wt.Path, Reason: "clean"})
+		default:
+			dirty = append(dirty, wt)
+		}
+	}
+	return dirty, skipped
+}
+
+// execInWorktrees runs argv in each worktree in turn, stopping at the first
+// failure unless keepGoing is set, or when ctx is cancelled. With capture,
+// each command's combined output is collected into its result instead of
+// being streamed under a per-worktree header.
+func execInWorktrees(ctx context.Context, worktrees []git.Worktree, argv []string, keepGoing, capture bool) []ExecResult {
+	results := make([]ExecResult, 0, len(worktrees))
+	for _, wt := range worktrees {
+		result := ExecResult{Branch: wt.Branch, Path: wt.Path}
+
+		var output bytes.Buffer
+		stdout, stderr := io.Writer(os.Stdout), io.Writer(os.Stderr)
+		if capture {
+			stdout, stderr = &output, &output
+		} else {
+			fmt.Println(ui.InfoMsg(ui.BoldStyle.Render(wt.Branch)))
+		}
+
+		result.ExitCode, result.Error = runInWorktree(ctx, wt.Path, argv, stdout, stderr)
+		result.Output = output.String()
+		results = append(results, result)
+
+		if result.ExitCode != 0 && !capture {
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("%s: %s", wt.Branch, result.Error)))
+		}
+		if ctx.Err() != nil || (result.ExitCode != 0 && !keepGoing) {
+			break
+		}
+	}
+	return results
+}
+
+// runInWorktree runs argv with dir as the working directory, returning its
+// exit code (-1 if it could not be started or was killed) and a description
+// of the failure
+func runInWorktree(ctx context.Context, dir string, argv []string, stdout, stderr io.Writer) (int, string) {
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	// Own process group, signalled as a whole on cancellation (Unix)
+	setExecProcAttrs(cmd)
+
+	// WaitDelay ensures process cleanup even if context is cancelled
+	cmd.WaitDelay = 3 * time.Second
+
+	err := cmd.Run()
+	if err == nil {
+		return 0, ""
+	}
+	if ctx.Err() != nil {
+		return -1, "interrupted"
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		return exitErr.ExitCode(), err.Error()
+	}
+	return -1, err.Error()
+}
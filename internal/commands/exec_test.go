@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+func TestExecTargets(t *testing.T) {
+	worktrees := []git.Worktree{
+		{Path: "/proj/.bare", Bare: true},
+		{Path: "/proj/main", Branch: "main"},
+		{Path: "/proj/detached"},
+		{Path: "/proj/feature", Branch: "feature"},
+	}
+
+	targets := execTargets(worktrees)
+	if len(targets) != 2 || targets[0].Branch != "main" || targets[1].Branch != "feature" {
+		t.Errorf("expected main and feature, got %+v", targets)
+	}
+}
+
+func TestExecInWorktrees(t *testing.T) {
+	dir := initCommandTestRepo(t, "feature", "other")
+	worktrees := []git.Worktree{{Path: dir, Branch: "main"}}
+	for _, branch := range []string{"feature", "other"} {
+		path := filepath.Join(dir, branch)
+		if out, err := exec.Command("git", "-C", dir, "worktree", "add", path, branch).CombinedOutput(); err != nil {
+			t.Fatalf("git worktree add failed: %v\n%s", err, out)
+		}
+		worktrees = append(worktrees, git.Worktree{Path: path, Branch: branch})
+	}
+
+	// Each worktree runs the command in its own directory
+	argv := []string{"git", "rev-parse", "--abbrev-ref", "HEAD"}
+	results := execInWorktrees(context.Background(), worktrees, argv, false, true)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.ExitCode != 0 || strings.TrimSpace(r.Output) != r.Branch {
+			t.Errorf("expected %s to print its branch, got %+v", r.Branch, r)
+		}
+	}
+
+	// Fails only in the feature worktree (the second one)
+	argv = []string{"sh", "-c", `test "$(git rev-parse --abbrev-ref HEAD)" != feature || exit 4`}
+	results = execInWorktrees(context.Background(), worktrees, argv, false, true)
+	if len(results) != 2 || results[1].ExitCode != 4 {
+		t.Errorf("expected to stop after the failure in feature, got %+v", results)
+	}
+
+	results = execInWorktrees(context.Background(), worktrees, argv, true, true)
+	if len(results) != 3 || results[1].ExitCode != 4 || results[2].ExitCode != 0 {
+		t.Errorf("expected --continue-on-error to run every worktree, got %+v", results)
+	}
+
+	// A command that cannot start is a failure, not a crash
+	results = execInWorktrees(context.Background(), worktrees, []string{"git-wt-no-such-command"}, false, true)
+	if len(results) != 1 || results[0].ExitCode != -1 || results[0].Error == "" {
+		t.Errorf("expected a start failure, got %+v", results)
+	}
+}
+
+func TestDirtyTargets(t *testing.T) {
+	dir := initCommandTestRepo(t, "clean", "dirty")
+	var worktrees []git.Worktree
+	for _, branch := range []string{"clean", "dirty"} {
+		path := filepath.Join(dir, branch)
+		if out, err := exec.Command("git", "-C", dir, "worktree", "add", path, branch).CombinedOutput(); err != nil {
+			t.Fatalf("git worktree add failed: %v\n%s", err, out)
+		}
+		worktrees = append(worktrees, git.Worktree{Path: path, Branch: branch})
+	}
+	if err := os.WriteFile(filepath.Join(dir, "dirty", "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	worktrees = append(worktrees, git.Worktree{Path: filepath.Join(dir, "gone"), Branch: "gone"})
+
+	targets, skipped := dirtyTargets(worktrees)
+	if len(targets) != 1 || targets[0].Branch != "dirty" {
+		t.Errorf("expected only the dirty worktree, got %+v", targets)
+	}
+	if len(skipped) != 2 || skipped[0].Branch != "clean" || skipped[0].Reason != "clean" || skipped[1].Branch != "gone" {
+		t.Errorf("expected clean and gone to be skipped, got %+v", skipped)
+	}
+}
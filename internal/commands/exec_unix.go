@@ -0,0 +1,18 @@
+//go:build unix
+
+package commands
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setExecProcAttrs starts the command in a new process group and, when its
+// context is cancelled, signals the whole group so children of the command
+// are not orphaned
+func setExecProcAttrs(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package commands
+
+import "os/exec"
+
+// setExecProcAttrs is a no-op on Windows
+// Windows doesn't support Unix-style process groups
+func setExecProcAttrs(cmd *exec.Cmd) {
+	// No-op on Windows
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// FetchData represents the JSON output for the fetch command
+type FetchData struct {
+	Remote  string `json:"remote"`
+	Fetched bool   `json:"fetched"`
+}
+
+var (
+	fetchRemoteFlag  string
+	fetchAllFlag     bool
+	fetchTimeoutFlag int
+)
+
+var fetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Update remote-tracking branches for the project",
+	Long: `Fetch the default remote (or --remote) into the project, pruning
+remote-tracking branches that were deleted upstream. All worktrees share the
+fetched refs, so this refreshes status, list and prune for every worktree.
+
+Examples:
+  git wt fetch
+  git wt fetch --remote upstream
+  git wt fetch --all`,
+	Args: cobra.NoArgs,
+	RunE: runFetch,
+}
+
+func init() {
+	fetchCmd.Flags().StringVar(&fetchRemoteFlag, "remote", "", "Override default remote")
+	fetchCmd.Flags().BoolVar(&fetchAllFlag, "all", false, "Fetch every remote")
+	fetchCmd.Flags().IntVar(&fetchTimeoutFlag, "timeout", 0, "Override git fetch timeout (seconds)")
+	rootCmd.AddCommand(fetchCmd)
+}
+
+func runFetch(cmd *cobra.Command, args []string) error {
+	if fetchAllFlag && fetchRemoteFlag != "" {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "fetch", nil, ui.NewCLIError(ui.ErrCodeValidation, "--all cannot be used with --remote"))
+		}
+		return fmt.Errorf("--all cannot be used with --remote")
+	}
+
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "fetch", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	cfg, err := config.LoadWithRepo(config.GetConfigPath(), projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "fetch", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+	if fetchRemoteFlag != "" {
+		cfg.DefaultRemote = fetchRemoteFlag
+	}
+	if fetchTimeoutFlag > 0 {
+		cfg.GitLongTimeout = fetchTimeoutFlag
+	}
+
+	remote := cfg.DefaultRemote
+	if fetchAllFlag {
+		remote = ""
+	}
+	fetchArgs := fetchCommandArgs(remote)
+
+	// git's progress output would corrupt the JSON document
+	if IsJSONOutput() {
+		if _, err := git.RunInDirWithTimeout(projectRoot, cfg.GitLongTimeout, fetchArgs...); err != nil {
+			return ui.OutputJSON(os.Stdout, "fetch", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return ui.OutputJSON(os.Stdout, "fetch", FetchData{Remote: fetchLabel(remote), Fetched: true}, nil)
+	}
+
+	fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Fetching %s...", fetchLabel(remote))))
+	if err := git.RunWithProgressAndTimeout(projectRoot, cfg.GitLongTimeout, fetchArgs...); err != nil {
+		return err
+	}
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Fetched %s", fetchLabel(remote))))
+	return nil
+}
+
+// fetchCommandArgs returns the git fetch arguments for a remote, or for every
+// remote when remote is empty
+func fetchCommandArgs(remote string) []string {
+	if remote == "" {
+		return []string{"fetch", "--all", "--prune"}
+	}
+	return []string{"fetch", "--prune", remote}
+}
+
+// fetchLabel names what was fetched: the remote, or "all" for every remote
+func fetchLabel(remote string) string {
+	if remote == "" {
+		return "all"
+	}
+	return remote
+}
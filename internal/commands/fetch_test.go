@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFetchCommandArgs(t *testing.T) {
+	if got := fetchCommandArgs("upstream"); !reflect.DeepEqual(got, []string{"fetch", "--prune", "upstream"}) {
+		t.Errorf("unexpected args %v", got)
+	}
+	if got := fetchCommandArgs(""); !reflect.DeepEqual(got, []string{"fetch", "--all", "--prune"}) {
+		t.Errorf("unexpected args %v", got)
+	}
+	if got := fetchLabel(""); got != "all" {
+		t.Errorf("expected 'all', got %q", got)
+	}
+}
@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/hooks"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// HooksRunData represents the JSON output for the hooks run command
+type HooksRunData struct {
+	Hook     string             `json:"hook"`
+	Branch   string             `json:"branch"`
+	Path     string             `json:"path"`
+	Commands int                `json:"commands"`
+	Warnings []string           `json:"warnings,omitempty"`
+	Results  []hooks.HookResult `json:"results,omitempty"`
+}
+
+var hooksTimeoutFlag int
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage worktree hooks",
+	Long:  `Inspect and run the hooks configured for git-wt operations.`,
+}
+
+var hooksRunCmd = &cobra.Command{
+	Use:   "run <post_add|post_clone> [branch]",
+	Short: "Re-run hooks against an existing worktree",
+	Long: `Re-run configured hooks against an existing worktree.
+
+Useful when a hook was interrupted and the worktree setup is incomplete.
+Without a branch, post_add runs against the current worktree and
+post_clone runs against the default branch worktree.`,
+	Args:      cobra.RangeArgs(1, 2),
+	ValidArgs: []string{"post_add", "post_clone"},
+	RunE:      runHooksRun,
+}
+
+func init() {
+	hooksRunCmd.Flags().IntVar(&hooksTimeoutFlag, "hook-timeout", 0, "Override hook timeout (seconds)")
+	hooksCmd.AddCommand(hooksRunCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
+
+func runHooksRun(cmd *cobra.Command, args []string) error {
+	hookName := args[0]
+
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "hooks run", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	cfg, err := config.LoadWithRepo(config.GetConfigPath(), projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "hooks run", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+	if hooksTimeoutFlag > 0 {
+		cfg.HookTimeout = hooksTimeoutFlag
+	}
+
+	var commands []string
+	switch hookName {
+	case "post_add":
+		commands = cfg.Hooks.PostAdd
+	case "post_clone":
+		commands = cfg.Hooks.PostClone
+	default:
+		msg := fmt.Sprintf("unknown hook: %s (expected post_add or post_clone)", hookName)
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "hooks run", nil, ui.NewCLIError(ui.ErrCodeValidation, msg))
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	wt, err := resolveHookWorktree(projectRoot, hookName, args[1:])
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "hooks run", nil, ui.NewCLIError(ui.ErrCodeNotFound, err.Error()))
+		}
+		return err
+	}
+
+	if len(commands) == 0 {
+		if IsJSONOutput() {
+			data := HooksRunData{Hook: hookName, Branch: wt.Branch, Path: wt.Path}
+			return ui.OutputJSON(os.Stdout, "hooks run", data, nil)
+		}
+		fmt.Println(ui.InfoMsg(fmt.Sprintf("No %s hooks configured", hookName)))
+		return nil
+	}
+
+	workDir, err := hooks.ResolveWorkDir(cfg.HookWorkdir, wt.Path, projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if !IsJSONOutput() {
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Running %d %s hooks in %s...", len(commands), hookName, wt.Branch)))
+	}
+
+	hookCtx := newHookContext(projectRoot, wt.Path, wt.Branch)
+	hookCtx.WorkDir = workDir
+
+	if IsJSONOutput() {
+		results := hooks.RunCaptured(commands, hookCtx, cfg.HookTimeout)
+		data := HooksRunData{
+			Hook:     hookName,
+			Branch:   wt.Branch,
+			Path:     wt.Path,
+			Commands: len(commands),
+			Warnings: hooks.Warnings(results),
+			Results:  results,
+		}
+		return ui.OutputJSON(os.Stdout, "hooks run", data, nil)
+	}
+
+	if warnings := runPostHooks(commands, hookCtx, cfg.HookTimeout); len(warnings) == 0 {
+		fmt.Println(ui.SuccessMsg(fmt.Sprintf("Ran %d %s hooks", len(commands), hookName)))
+	}
+	return nil
+}
+
+// resolveHookWorktree finds the worktree to run hooks against: the named
+// branch, else the current worktree (post_add) or default branch (post_clone)
+func resolveHookWorktree(projectRoot, hookName string, args []string) (*git.Worktree, error) {
+	if len(args) > 0 {
+		wt, err := git.FindWorktreeForBranch(projectRoot, args[0])
+		if err != nil {
+			return nil, err
+		}
+		if wt == nil {
+			return nil, fmt.Errorf("worktree not found: %s", args[0])
+		}
+		return wt, nil
+	}
+
+	if hookName == "post_clone" {
+		defaultBranch, err := git.GetDefaultBranch(projectRoot)
+		if err != nil {
+			defaultBranch = git.DefaultBranch
+		}
+		wt, err := git.FindWorktreeForBranch(projectRoot, defaultBranch)
+		if err != nil {
+			return nil, err
+		}
+		if wt == nil {
+			return nil, fmt.Errorf("worktree not found: %s", defaultBranch)
+		}
+		return wt, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	worktrees, err := git.ListWorktrees(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	cwd, _ = filepath.EvalSymlinks(cwd)
+	for i, wt := range worktrees {
+		path, _ := filepath.EvalSymlinks(wt.Path)
+		if wt.Branch != "" && (cwd == path || isSubpath(path, cwd)) {
+			return &worktrees[i], nil
+		}
+	}
+	return nil, fmt.Errorf("not inside a worktree, specify a branch")
+}
+
+// runPostHooks runs post hooks, streaming their output and printing a warning
+// for each failure. It returns the warnings.
+func runPostHooks(commands []string, ctx hooks.Context, timeoutSec int) []string {
+	warnings := hooks.RunWithTimeout(commands, ctx, timeoutSec)
+	for _, w := range warnings {
+		fmt.Println(ui.WarningMsg("Hook: " + w))
+	}
+	return warnings
+}
+
+// runWorktreeHooks runs post_add or post_clone hooks in the directory
+// hook_workdir selects. Failures, including an invalid hook_workdir, are only
+// warnings; with --json the results are returned instead of printed.
+func runWorktreeHooks(commands []string, ctx hooks.Context, cfg *config.Config) []hooks.HookResult {
+	if len(commands) == 0 {
+		return nil
+	}
+	workDir, err := hooks.ResolveWorkDir(cfg.HookWorkdir, ctx.Path, ctx.ProjectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			results := make([]hooks.HookResult, 0, len(commands))
+			for _, cmdStr := range commands {
+				results = append(results, hooks.HookResult{Command: cmdStr, ExitCode: -1, Error: err.Error()})
+			}
+			return results
+		}
+		fmt.Println(ui.WarningMsg("Hook: " + err.Error()))
+		return nil
+	}
+	ctx.WorkDir = workDir
+	if IsJSONOutput() {
+		return hooks.RunCaptured(commands, ctx, cfg.HookTimeout)
+	}
+	runPostHooks(commands, ctx, cfg.HookTimeout)
+	return nil
+}
+
+// runRemovalHooks runs post_delete or post_prune hooks for a worktree that
+// was just removed. Its directory is gone, so they run in the project root.
+// Failures are only warnings; with --json the results are returned instead
+// of printed.
+func runRemovalHooks(commands []string, projectRoot, worktreePath, branchName string, timeoutSec int) []hooks.HookResult {
+	if HooksDisabled() || len(commands) == 0 {
+		return nil
+	}
+	hookCtx := newHookContext(projectRoot, worktreePath, branchName)
+	hookCtx.WorkDir = projectRoot
+	if IsJSONOutput() {
+		return hooks.RunCaptured(commands, hookCtx, timeoutSec)
+	}
+	runPostHooks(commands, hookCtx, timeoutSec)
+	return nil
+}
+
+// newHookContext builds the hook context for a worktree, resolving the
+// project's default branch
+func newHookContext(projectRoot, worktreePath, branchName string) hooks.Context {
+	defaultBranch, err := git.GetDefaultBranch(projectRoot)
+	if err != nil {
+		defaultBranch = git.DefaultBranch
+	}
+	return hooks.Context{
+		Path:          worktreePath,
+		Branch:        branchName,
+		ProjectRoot:   projectRoot,
+		DefaultBranch: defaultBranch,
+	}
+}
+
+// isSubpath reports whether path lies beneath dir
+func isSubpath(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsSubpath(t *testing.T) {
+	tests := []struct {
+		dir      string
+		path     string
+		expected bool
+	}{
+		{"/proj/main", "/proj/main/src", true},
+		{"/proj/main", "/proj/main/src/pkg", true},
+		{"/proj/main", "/proj/main", false},
+		{"/proj/main", "/proj/main-2", false},
+		{"/proj/main", "/proj", false},
+		{"/proj/main", "/other/main/src", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSubpath(tt.dir, tt.path); got != tt.expected {
+			t.Errorf("isSubpath(%q, %q) = %v, want %v", tt.dir, tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestRunRemovalHooks(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	removed := filepath.Join(dir, "feature-db")
+	commands := []string{`echo "$GIT_WT_BRANCH $GIT_WT_PATH"`, "exit 3"}
+
+	jsonOutputFlag = true
+	t.Cleanup(func() { jsonOutputFlag = false })
+
+	// The worktree directory no longer exists, so hooks run in the project root
+	results := runRemovalHooks(commands, dir, removed, "feature/db", 30)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 hook results, got %+v", results)
+	}
+	if results[0].ExitCode != 0 || strings.TrimSpace(results[0].Output) != "feature/db "+removed {
+		t.Errorf("expected the removed branch and path, got %+v", results[0])
+	}
+	if results[1].ExitCode != 3 || results[1].Error == "" {
+		t.Errorf("expected the failing hook to be reported, got %+v", results[1])
+	}
+
+	noHooksFlag = true
+	t.Cleanup(func() { noHooksFlag = false })
+	if results := runRemovalHooks(commands, dir, removed, "feature/db", 30); results != nil {
+		t.Errorf("expected --no-hooks to skip the hooks, got %+v", results)
+	}
+}
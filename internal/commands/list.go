@@ -4,8 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
+	"time"
 
 	"github.com/raisedadead/git-wt/internal/git"
 	"github.com/raisedadead/git-wt/internal/ui"
@@ -15,12 +20,21 @@ import (
 var (
 	listJSONOutput bool
 	pathOutput     bool
+	noStatusList   bool
+	authorFilter   string
+	listSort       string
+	dirtyOnly      bool
 )
 
+// worktreeStatus reports a worktree's change counts for list; a variable so
+// tests can observe calls
+var worktreeStatus = git.GetWorktreeStatus
+
 // ListData represents the JSON output for the list command
 type ListData struct {
 	Worktrees []worktreeInfo `json:"worktrees"`
 	Count     int            `json:"count"`
+	Total     int            `json:"total"`
 }
 
 var listCmd = &cobra.Command{
@@ -33,16 +47,140 @@ var listCmd = &cobra.Command{
 func init() {
 	listCmd.Flags().BoolVar(&listJSONOutput, "json", false, "Output as JSON (legacy, use global --json)")
 	listCmd.Flags().BoolVar(&pathOutput, "path", false, "Output paths only")
+	listCmd.Flags().BoolVar(&noStatusList, "no-status", false, "Skip computing worktree status (faster on large repos)")
+	listCmd.Flags().StringVar(&authorFilter, "author", "", "Only list worktrees whose last commit author email matches this pattern")
+	listCmd.Flags().StringVar(&listSort, "sort", sortByBranch, "Sort by branch, path, mtime (most recently modified first) or status (dirty first)")
+	listCmd.Flags().BoolVar(&dirtyOnly, "dirty", false, "Only list worktrees with uncommitted changes")
 	rootCmd.AddCommand(listCmd)
 }
 
 type worktreeInfo struct {
-	Branch string `json:"branch"`
-	Path   string `json:"path"`
-	Status string `json:"status"`
+	Branch        string              `json:"branch"`
+	Ref           string              `json:"ref,omitempty"`
+	Path          string              `json:"path"`
+	Status        string              `json:"status,omitempty"`
+	Changes       *git.WorktreeStatus `json:"changes,omitempty"`
+	Upstream      string              `json:"upstream,omitempty"`
+	Ahead         int                 `json:"ahead,omitempty"`
+	Behind        int                 `json:"behind,omitempty"`
+	Commit        string              `json:"commit,omitempty"`
+	CommitSubject string              `json:"commit_subject,omitempty"`
+	Issue         int                 `json:"issue,omitempty"`
+	PR            int                 `json:"pr,omitempty"`
+	MR            int                 `json:"mr,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	CreatedBy     string              `json:"created_by,omitempty"`
+	CreatedAt     string              `json:"created_at,omitempty"`
+	LastCommitAt  string              `json:"last_commit_at,omitempty"`
+	Age           string              `json:"age,omitempty"`
+	StashCount    int                 `json:"stash_count"`
+
+	lastCommit time.Time
+}
+
+// Age buckets for the last commit of a worktree (JSON only)
+const (
+	AgeToday    = "today"
+	AgeThisWeek = "this_week"
+	AgeOlder    = "older"
+)
+
+// ageBucket classifies a commit time relative to now: today (since local
+// midnight), this_week (within the last 7 days), or older. The zero time
+// (no commits) has no bucket.
+func ageBucket(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	year, month, day := now.Date()
+	switch {
+	case !t.Before(time.Date(year, month, day, 0, 0, 0, 0, now.Location())):
+		return AgeToday
+	case !t.Before(now.Add(-7 * 24 * time.Hour)):
+		return AgeThisWeek
+	default:
+		return AgeOlder
+	}
+}
+
+// syncSymbols are the indicators for each upstream sync state
+type syncSymbols struct {
+	synced, ahead, behind, diverged string
+}
+
+var (
+	unicodeSyncSymbols = syncSymbols{synced: "✓", ahead: "↑", behind: "↓", diverged: "↕"}
+	asciiSyncSymbols   = syncSymbols{synced: "=", ahead: ">", behind: "<", diverged: "<>"}
+)
+
+// syncIndicator combines upstream sync and status into one compact symbol, as
+// shell prompts do: ✓ up to date, ↑N ahead, ↓N behind, ↕ diverged, ? no
+// upstream, with * appended when the worktree is dirty. In plain mode it uses
+// git-prompt's ASCII equivalents (=, >N, <N, <>).
+func (info worktreeInfo) syncIndicator(ascii bool) string {
+	symbols := unicodeSyncSymbols
+	if ascii {
+		symbols = asciiSyncSymbols
+	}
+
+	var indicator string
+	switch {
+	case info.Upstream == "":
+		indicator = "?"
+	case info.Ahead > 0 && info.Behind > 0:
+		indicator = symbols.diverged
+	case info.Ahead > 0:
+		indicator = fmt.Sprintf("%s%d", symbols.ahead, info.Ahead)
+	case info.Behind > 0:
+		indicator = fmt.Sprintf("%s%d", symbols.behind, info.Behind)
+	default:
+		indicator = symbols.synced
+	}
+	if info.Status != "" && info.Status != "clean" {
+		indicator += "*"
+	}
+	return indicator
+}
+
+// branchLabel returns the branch for display, flagging forgotten stashes.
+// Detached worktrees show the tag or short SHA they are at.
+func (info worktreeInfo) branchLabel() string {
+	if info.Branch == "" {
+		return ui.SubtleStyle.Render(fmt.Sprintf("(detached at %s)", info.Ref))
+	}
+	if info.StashCount == 0 {
+		return info.Branch
+	}
+	return info.Branch + ui.WarningStyle.Render(fmt.Sprintf(" (%d stashed)", info.StashCount))
+}
+
+// link returns the issue/PR/MR reference for display (e.g. "#42" or "!7"),
+// or "-"
+func (info worktreeInfo) link() string {
+	switch {
+	case info.Issue > 0:
+		return fmt.Sprintf("#%d", info.Issue)
+	case info.PR > 0:
+		return fmt.Sprintf("#%d", info.PR)
+	case info.MR > 0:
+		return fmt.Sprintf("!%d", info.MR)
+	default:
+		return "-"
+	}
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	filter, err := newListFilter(authorFilter)
+	if err != nil {
+		return err
+	}
+	if !validListSort(listSort) {
+		return fmt.Errorf("invalid --sort %q (want %s)", listSort, strings.Join(listSortKeys, ", "))
+	}
+	if dirtyOnly && noStatusList {
+		return fmt.Errorf("--dirty cannot be used with --no-status")
+	}
+
 	// Find project root
 	projectRoot, err := git.GetProjectRoot(".")
 	if err != nil {
@@ -54,26 +192,58 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Build info with status (skip .bare directory)
-	var infos []worktreeInfo
+	// Skip the bare repository itself
+	var listed []git.Worktree
 	for _, wt := range worktrees {
-		// Skip the bare repository itself
-		if strings.HasSuffix(wt.Path, "/.bare") || wt.Branch == "" {
+		if wt.Bare {
 			continue
 		}
-		status, _ := git.GetWorktreeStatus(wt.Path)
-		infos = append(infos, worktreeInfo{
-			Branch: wt.Branch,
-			Path:   wt.Path,
-			Status: status,
-		})
+		listed = append(listed, wt)
+	}
+
+	// Build info with status in parallel (each entry runs several git commands)
+	built := make([]worktreeInfo, len(listed))
+	keep := make([]bool, len(listed))
+	var wg sync.WaitGroup
+	for i, wt := range listed {
+		wg.Add(1)
+		go func(i int, wt git.Worktree) {
+			defer wg.Done()
+			if keep[i] = filter.matches(wt); keep[i] {
+				built[i] = buildWorktreeInfo(wt, !noStatusList)
+			}
+		}(i, wt)
+	}
+	wg.Wait()
+
+	infos := make([]worktreeInfo, 0, len(built))
+	for i, info := range built {
+		if keep[i] {
+			infos = append(infos, info)
+		}
+	}
+
+	// One repo-wide lookup, attributed to worktrees by branch
+	if stashes, err := git.CountStashesByBranch(projectRoot); err == nil {
+		for i := range infos {
+			if infos[i].Branch != "" {
+				infos[i].StashCount = stashes[infos[i].Branch]
+			}
+		}
+	}
+
+	total := len(infos)
+	if dirtyOnly {
+		infos = dirtyWorktrees(infos)
 	}
+	sortWorktreeInfos(infos, listSort)
 
 	// Output based on flags - check global --json first, then legacy list --json
 	if IsJSONOutput() {
 		data := ListData{
 			Worktrees: infos,
 			Count:     len(infos),
+			Total:     total,
 		}
 		return ui.OutputJSON(os.Stdout, "list", data, nil)
 	}
@@ -94,24 +264,246 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	// Table output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(w, ui.BoldStyle.Render("BRANCH\tSTATUS\tPATH"))
+	if noStatusList {
+		_, _ = fmt.Fprintln(w, ui.BoldStyle.Render("BRANCH\tPATH\tLINK\tCOMMIT\tUPDATED"))
+	} else {
+		_, _ = fmt.Fprintln(w, ui.BoldStyle.Render("BRANCH\tSTATUS\tPATH\tLINK\tCOMMIT\tSYNC\tUPDATED"))
+	}
 
+	now := time.Now()
 	for _, info := range infos {
+		if noStatusList {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				info.branchLabel(),
+				ui.SubtleStyle.Render(shortenPath(info.Path)),
+				info.link(),
+				info.commitSummary(),
+				info.lastCommitAge(now),
+			)
+			continue
+		}
+
 		statusStyle := ui.SuccessStyle
 		if info.Status != "clean" {
 			statusStyle = ui.SubtleStyle
 		}
 
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n",
-			info.Branch,
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			info.branchLabel(),
 			statusStyle.Render(info.Status),
 			ui.SubtleStyle.Render(shortenPath(info.Path)),
+			info.link(),
+			info.commitSummary(),
+			info.syncIndicator(!ui.ColorEnabled()),
+			info.lastCommitAge(now),
 		)
 	}
 
 	return w.Flush()
 }
 
+// listFilter selects which worktrees list shows; the zero value matches all
+type listFilter struct {
+	author *regexp.Regexp
+}
+
+// newListFilter compiles the list filter flags. The author pattern is a
+// case-insensitive regular expression, like git log --author.
+func newListFilter(author string) (listFilter, error) {
+	var filter listFilter
+	if author != "" {
+		re, err := regexp.Compile("(?i)" + author)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --author pattern: %w", err)
+		}
+		filter.author = re
+	}
+	return filter, nil
+}
+
+// matches reports whether a worktree passes every configured filter
+func (f listFilter) matches(wt git.Worktree) bool {
+	if f.author != nil {
+		email, err := git.GetLastCommitAuthor(wt.Path)
+		if err != nil || !f.author.MatchString(email) {
+			return false
+		}
+	}
+	return true
+}
+
+// dirtyWorktrees returns the worktrees with uncommitted changes
+func dirtyWorktrees(infos []worktreeInfo) []worktreeInfo {
+	dirty := make([]worktreeInfo, 0, len(infos))
+	for _, info := range infos {
+		if info.Status != "clean" {
+			dirty = append(dirty, info)
+		}
+	}
+	return dirty
+}
+
+// Sort keys for list --sort
+const (
+	sortByBranch = "branch"
+	sortByPath   = "path"
+	sortByMtime  = "mtime"
+	sortByStatus = "status"
+)
+
+var listSortKeys = []string{sortByBranch, sortByPath, sortByMtime, sortByStatus}
+
+// validListSort reports whether key is a supported --sort value
+func validListSort(key string) bool {
+	for _, k := range listSortKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// sortWorktreeInfos orders infos by key, with ties (and worktrees whose
+// directory cannot be stat'ed, for mtime) falling back to branch order.
+// Sorting by status puts dirty worktrees first; without status (--no-status)
+// it is the same as sorting by branch.
+func sortWorktreeInfos(infos []worktreeInfo, key string) {
+	// Detached worktrees go after the branches, ordered by ref
+	sort.SliceStable(infos, func(i, j int) bool {
+		a, b := infos[i], infos[j]
+		if (a.Branch == "") != (b.Branch == "") {
+			return a.Branch != ""
+		}
+		if a.Branch != b.Branch {
+			return a.Branch < b.Branch
+		}
+		return a.Ref < b.Ref
+	})
+
+	switch key {
+	case sortByPath:
+		sort.SliceStable(infos, func(i, j int) bool {
+			return infos[i].Path < infos[j].Path
+		})
+	case sortByMtime:
+		modTimes := make(map[string]time.Time, len(infos))
+		for _, info := range infos {
+			if fi, err := os.Stat(info.Path); err == nil {
+				modTimes[info.Path] = fi.ModTime()
+			}
+		}
+		sort.SliceStable(infos, func(i, j int) bool {
+			return modTimes[infos[i].Path].After(modTimes[infos[j].Path])
+		})
+	case sortByStatus:
+		dirty := func(info worktreeInfo) bool {
+			return info.Status != "" && info.Status != "clean"
+		}
+		sort.SliceStable(infos, func(i, j int) bool {
+			return dirty(infos[i]) && !dirty(infos[j])
+		})
+	}
+}
+
+// buildWorktreeInfo collects the commit, metadata, and (when withStatus is
+// set) the status of a worktree
+func buildWorktreeInfo(wt git.Worktree, withStatus bool) worktreeInfo {
+	info := worktreeInfo{
+		Branch: wt.Branch,
+		Path:   wt.Path,
+		Commit: shortSHA(wt.Commit),
+	}
+	if withStatus {
+		info.Status = "unknown"
+		if status, err := worktreeStatus(wt.Path); err == nil {
+			info.Status = status.String()
+			info.Changes = &status
+		}
+		if upstreamSync, err := git.GetUpstreamSync(wt.Path); err == nil {
+			info.Upstream = upstreamSync.Upstream
+			info.Ahead = upstreamSync.Ahead
+			info.Behind = upstreamSync.Behind
+		}
+	}
+	if wt.Branch == "" {
+		info.Ref = git.GetExactTag(wt.Path)
+		if info.Ref == "" {
+			info.Ref = info.Commit
+		}
+	}
+	info.CommitSubject, _ = git.GetCommitSubject(wt.Path)
+	if committed, err := git.GetLastCommitTime(wt.Path); err == nil && !committed.IsZero() {
+		info.lastCommit = committed
+		info.LastCommitAt = committed.UTC().Format(time.RFC3339)
+		info.Age = ageBucket(committed, time.Now())
+	}
+	if meta, _ := git.ReadMetadata(wt.Path); meta != nil {
+		info.Issue = meta.Issue
+		info.PR = meta.PR
+		info.MR = meta.MR
+		info.CreatedBy = meta.CreatedBy
+		info.CreatedAt = meta.CreatedAt
+	}
+	// A branch description from add --track-issue keeps the issue link when
+	// the metadata file is gone
+	if wt.Branch != "" {
+		info.Description = git.BranchDescription(wt.Path, wt.Branch)
+	}
+	if info.Issue == 0 && info.PR == 0 && info.MR == 0 {
+		info.Issue = issueFromDescription(info.Description)
+	}
+	return info
+}
+
+// closesIssuePattern matches the "Closes #42" description add --track-issue sets
+var closesIssuePattern = regexp.MustCompile(`(?i)\bcloses #(\d+)\b`)
+
+// issueFromDescription returns the issue a branch description closes, or 0
+func issueFromDescription(description string) int {
+	match := closesIssuePattern.FindStringSubmatch(description)
+	if match == nil {
+		return 0
+	}
+	number, _ := strconv.Atoi(match[1])
+	return number
+}
+
+// commitSummary returns the short SHA and truncated subject for display
+func (info worktreeInfo) commitSummary() string {
+	if info.Commit == "" {
+		return "-"
+	}
+	return info.Commit + " " + truncate(info.CommitSubject, maxSubjectWidth)
+}
+
+// lastCommitAge returns how long ago the last commit was made, or "-"
+func (info worktreeInfo) lastCommitAge(now time.Time) string {
+	if info.lastCommit.IsZero() {
+		return "-"
+	}
+	return formatAge(now.Sub(info.lastCommit))
+}
+
+// maxSubjectWidth limits commit subjects in the list table
+const maxSubjectWidth = 40
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// shortSHA abbreviates a commit hash to 7 characters
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
 func shortenPath(path string) string {
 	home, _ := os.UserHomeDir()
 	if strings.HasPrefix(path, home) {
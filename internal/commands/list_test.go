@@ -0,0 +1,321 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		n        int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"a much longer commit subject", 10, "a much lo…"},
+		{"ünïcödé subject", 5, "ünïc…"},
+	}
+
+	for _, tt := range tests {
+		if got := truncate(tt.input, tt.n); got != tt.expected {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.input, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestWorktreeInfo_CommitSummary(t *testing.T) {
+	info := worktreeInfo{Commit: "abc1234", CommitSubject: "Fix login redirect"}
+	if got := info.commitSummary(); got != "abc1234 Fix login redirect" {
+		t.Errorf("unexpected summary %q", got)
+	}
+
+	// Worktrees without commits show a placeholder
+	if got := (worktreeInfo{}).commitSummary(); got != "-" {
+		t.Errorf("expected '-', got %q", got)
+	}
+
+	if got := shortSHA("0123456789abcdef"); got != "0123456" {
+		t.Errorf("expected 7-char SHA, got %s", got)
+	}
+}
+
+func TestWorktreeInfo_LastCommitAge(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	info := worktreeInfo{lastCommit: now.Add(-3 * time.Hour)}
+	if got := info.lastCommitAge(now); got != "3h ago" {
+		t.Errorf("expected '3h ago', got %q", got)
+	}
+
+	// Worktrees without commits show a placeholder
+	if got := (worktreeInfo{}).lastCommitAge(now); got != "-" {
+		t.Errorf("expected '-', got %q", got)
+	}
+}
+
+func TestBuildWorktreeInfo_NoStatus(t *testing.T) {
+	calls := 0
+	orig := worktreeStatus
+	worktreeStatus = func(string) (git.WorktreeStatus, error) {
+		calls++
+		return git.WorktreeStatus{}, nil
+	}
+	t.Cleanup(func() { worktreeStatus = orig })
+
+	wt := git.Worktree{Path: t.TempDir(), Branch: "feature", Commit: "0123456789abcdef"}
+
+	info := buildWorktreeInfo(wt, false)
+	if calls != 0 {
+		t.Errorf("expected no status calls, got %d", calls)
+	}
+	if info.Status != "" {
+		t.Errorf("expected empty status, got %q", info.Status)
+	}
+
+	info = buildWorktreeInfo(wt, true)
+	if calls != 1 || info.Status != "clean" {
+		t.Errorf("expected one status call returning clean, got %d calls and %q", calls, info.Status)
+	}
+}
+
+func TestWorktreeInfo_SyncIndicator(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    worktreeInfo
+		unicode string
+		ascii   string
+	}{
+		{"up to date", worktreeInfo{Upstream: "origin/f", Status: "clean"}, "✓", "="},
+		{"ahead", worktreeInfo{Upstream: "origin/f", Ahead: 2, Status: "clean"}, "↑2", ">2"},
+		{"behind", worktreeInfo{Upstream: "origin/f", Behind: 3, Status: "clean"}, "↓3", "<3"},
+		{"diverged", worktreeInfo{Upstream: "origin/f", Ahead: 1, Behind: 1, Status: "clean"}, "↕", "<>"},
+		{"no upstream", worktreeInfo{Status: "clean"}, "?", "?"},
+		{"dirty and ahead", worktreeInfo{Upstream: "origin/f", Ahead: 1, Status: "1 modified"}, "↑1*", ">1*"},
+		{"dirty without upstream", worktreeInfo{Status: "unknown"}, "?*", "?*"},
+		{"status not computed", worktreeInfo{Upstream: "origin/f"}, "✓", "="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.info.syncIndicator(false); got != tt.unicode {
+				t.Errorf("expected %q, got %q", tt.unicode, got)
+			}
+			if got := tt.info.syncIndicator(true); got != tt.ascii {
+				t.Errorf("expected ASCII %q, got %q", tt.ascii, got)
+			}
+		})
+	}
+}
+
+func TestWorktreeInfo_BranchLabel(t *testing.T) {
+	if got := (worktreeInfo{Branch: "feature"}).branchLabel(); got != "feature" {
+		t.Errorf("expected plain branch without stashes, got %q", got)
+	}
+	if got := (worktreeInfo{Branch: "feature", StashCount: 2}).branchLabel(); !strings.Contains(got, "feature") || !strings.Contains(got, "2 stashed") {
+		t.Errorf("expected stash marker, got %q", got)
+	}
+}
+
+func TestWorktreeInfo_BranchLabelDetached(t *testing.T) {
+	if got := (worktreeInfo{Ref: "v1.2.3"}).branchLabel(); !strings.Contains(got, "(detached at v1.2.3)") {
+		t.Errorf("expected detached label, got %q", got)
+	}
+}
+
+func TestBuildWorktreeInfo_Detached(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	for _, args := range [][]string{
+		{"tag", "v1.2.3"},
+		{"worktree", "add", "-q", "--detach", filepath.Join(dir, "tagged"), "v1.2.3"},
+		{"commit", "-q", "--allow-empty", "-m", "untagged"},
+		{"worktree", "add", "-q", "--detach", filepath.Join(dir, "untagged"), "HEAD"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	worktrees, err := git.ListWorktrees(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs := map[string]string{}
+	for _, wt := range worktrees {
+		if wt.Branch == "" {
+			info := buildWorktreeInfo(wt, false)
+			refs[filepath.Base(wt.Path)] = info.Ref
+		}
+	}
+	if refs["tagged"] != "v1.2.3" {
+		t.Errorf("expected the tag as ref, got %q", refs["tagged"])
+	}
+	if got := refs["untagged"]; len(got) != 7 {
+		t.Errorf("expected a short SHA as ref, got %q", got)
+	}
+}
+
+func TestListFilter_Author(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	other := filepath.Join(t.TempDir(), "other")
+	runGit := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	runGit(dir, "worktree", "add", "-b", "other", other)
+	runGit(other, "commit", "--allow-empty", "-m", "theirs", "--author", "Jane Doe <jane@example.com>")
+
+	mine := git.Worktree{Path: dir, Branch: "main"}
+	theirs := git.Worktree{Path: other, Branch: "other"}
+
+	tests := []struct {
+		pattern     string
+		mine, other bool
+	}{
+		{"", true, true},
+		{"test@example.com", true, false},
+		{"JANE@", false, true},
+		{"^nobody", false, false},
+	}
+	for _, tt := range tests {
+		filter, err := newListFilter(tt.pattern)
+		if err != nil {
+			t.Fatalf("newListFilter(%q) returned error: %v", tt.pattern, err)
+		}
+		if got := filter.matches(mine); got != tt.mine {
+			t.Errorf("pattern %q: mine matched = %v, want %v", tt.pattern, got, tt.mine)
+		}
+		if got := filter.matches(theirs); got != tt.other {
+			t.Errorf("pattern %q: other matched = %v, want %v", tt.pattern, got, tt.other)
+		}
+	}
+
+	if _, err := newListFilter("[unclosed"); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestSortWorktreeInfos(t *testing.T) {
+	dir := t.TempDir()
+	older := filepath.Join(dir, "z-older")
+	newer := filepath.Join(dir, "a-newer")
+	for _, p := range []string{older, newer} {
+		if err := os.Mkdir(p, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	if err := os.Chtimes(older, now, now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	infos := func() []worktreeInfo {
+		return []worktreeInfo{
+			{Branch: "main", Path: older, Status: "clean"},
+			{Branch: "feature", Path: newer, Status: "1 modified"},
+			{Branch: "bugfix", Path: filepath.Join(dir, "missing"), Status: "clean"},
+			{Ref: "v1.0.0", Path: filepath.Join(dir, "v1.0.0"), Status: "clean"},
+		}
+	}
+	branches := func(infos []worktreeInfo) string {
+		var names []string
+		for _, info := range infos {
+			name := info.Branch
+			if name == "" {
+				name = info.Ref
+			}
+			names = append(names, name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{sortByBranch, "bugfix,feature,main,v1.0.0"},
+		{sortByPath, "feature,bugfix,v1.0.0,main"},
+		{sortByMtime, "feature,main,bugfix,v1.0.0"},
+		{sortByStatus, "feature,bugfix,main,v1.0.0"},
+	}
+	for _, tt := range tests {
+		got := infos()
+		sortWorktreeInfos(got, tt.key)
+		if branches(got) != tt.want {
+			t.Errorf("sort by %s: got %s, want %s", tt.key, branches(got), tt.want)
+		}
+	}
+
+	if validListSort("size") {
+		t.Error("expected unknown sort key to be rejected")
+	}
+}
+
+func TestDirtyWorktrees(t *testing.T) {
+	infos := []worktreeInfo{
+		{Branch: "main", Status: "clean"},
+		{Branch: "feature", Status: "1 modified"},
+		{Branch: "bugfix", Status: "2 untracked"},
+	}
+	got := dirtyWorktrees(infos)
+	if len(got) != 2 || got[0].Branch != "feature" || got[1].Branch != "bugfix" {
+		t.Errorf("expected feature and bugfix, got %+v", got)
+	}
+	if len(infos) != 3 {
+		t.Error("input slice should not be modified")
+	}
+}
+
+func TestAgeBucket(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	now := time.Date(2024, 3, 10, 9, 30, 0, 0, loc)
+	midnight := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected string
+	}{
+		{"no commits", time.Time{}, ""},
+		{"just now", now, AgeToday},
+		{"in the future", now.Add(time.Hour), AgeToday},
+		{"midnight", midnight, AgeToday},
+		{"just before midnight", midnight.Add(-time.Second), AgeThisWeek},
+		{"exactly a week", weekAgo, AgeThisWeek},
+		{"just over a week", weekAgo.Add(-time.Second), AgeOlder},
+		{"midnight in UTC", midnight.UTC(), AgeToday},
+	}
+	for _, tt := range tests {
+		if got := ageBucket(tt.t, now); got != tt.expected {
+			t.Errorf("%s: ageBucket() = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestIssueFromDescription(t *testing.T) {
+	tests := []struct {
+		description string
+		expected    int
+	}{
+		{"", 0},
+		{"Closes #42", 42},
+		{"closes #7", 7},
+		{"Refactor auth\n\nCloses #108", 108},
+		{"See #42", 0},
+		{"Closes #", 0},
+	}
+	for _, tt := range tests {
+		if got := issueFromDescription(tt.description); got != tt.expected {
+			t.Errorf("issueFromDescription(%q) = %d, want %d", tt.description, got, tt.expected)
+		}
+	}
+}
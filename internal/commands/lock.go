@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// LockData represents the JSON output for the lock and unlock commands
+type LockData struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path"`
+	Locked bool   `json:"locked"`
+	Reason string `json:"reason,omitempty"`
+}
+
+var lockReason string
+
+var lockCmd = &cobra.Command{
+	Use:   "lock <branch>",
+	Short: "Lock a worktree so it is never pruned or deleted by accident",
+	Long: `Lock a branch's worktree with git worktree lock, e.g. while it lives on a
+removable drive. prune skips locked worktrees and delete refuses them
+without --force.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLock,
+}
+
+var unlockCmd = &cobra.Command{
+	Use:   "unlock <branch>",
+	Short: "Unlock a locked worktree",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUnlock,
+}
+
+func init() {
+	lockCmd.Flags().StringVar(&lockReason, "reason", "", "Why the worktree is locked")
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+}
+
+func runLock(cmd *cobra.Command, args []string) error {
+	return setWorktreeLock("lock", args[0], true)
+}
+
+func runUnlock(cmd *cobra.Command, args []string) error {
+	return setWorktreeLock("unlock", args[0], false)
+}
+
+// setWorktreeLock locks or unlocks the worktree of branchName, reporting the
+// result as command
+func setWorktreeLock(command, branchName string, lock bool) error {
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, command, nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	wt, err := git.FindWorktreeForBranch(projectRoot, branchName)
+	if err == nil && wt == nil {
+		msg := fmt.Sprintf("no worktree found for branch: %s", branchName)
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, command, nil, ui.NewCLIError(ui.ErrCodeNotFound, msg))
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	if err == nil {
+		if lock {
+			err = git.LockWorktree(projectRoot, wt.Path, lockReason)
+		} else {
+			err = git.UnlockWorktree(projectRoot, wt.Path)
+		}
+	}
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, command, nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	if IsJSONOutput() {
+		data := LockData{Branch: branchName, Path: wt.Path, Locked: lock}
+		if lock {
+			data.Reason = lockReason
+		}
+		return ui.OutputJSON(os.Stdout, command, data, nil)
+	}
+
+	if lock {
+		fmt.Println(ui.SuccessMsg(fmt.Sprintf("Locked %s", branchName)))
+	} else {
+		fmt.Println(ui.SuccessMsg(fmt.Sprintf("Unlocked %s", branchName)))
+	}
+	return nil
+}
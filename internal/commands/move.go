@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// MoveData represents the JSON output for the move command
+type MoveData struct {
+	OldBranch string `json:"old_branch"`
+	NewBranch string `json:"new_branch"`
+	OldPath   string `json:"old_path"`
+	NewPath   string `json:"new_path"`
+}
+
+var moveCmd = &cobra.Command{
+	Use:     "move <branch> <new-branch>",
+	Aliases: []string{"mv"},
+	Short:   "Rename a worktree's branch and directory",
+	Long: `Rename a branch and move its worktree to the matching directory, e.g.
+"git wt move feature/atuh feature/auth" renames the branch and moves
+feature-atuh/ to feature-auth/ next to it.
+
+The branch keeps its upstream and other config. The command refuses to
+overwrite an existing branch or directory.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMove,
+}
+
+func init() {
+	rootCmd.AddCommand(moveCmd)
+}
+
+func runMove(cmd *cobra.Command, args []string) error {
+	oldBranch, newBranch := args[0], args[1]
+
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "move", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	data, err := moveWorktree(projectRoot, oldBranch, newBranch)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "move", nil, err)
+		}
+		return err
+	}
+
+	if IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "move", data, nil)
+	}
+
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Renamed %s to %s", oldBranch, newBranch)))
+	if data.NewPath != data.OldPath {
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Moved %s to %s", shortenPath(data.OldPath), shortenPath(data.NewPath))))
+	}
+	return nil
+}
+
+// moveWorktree renames oldBranch to newBranch and moves its worktree to the
+// flattened new name in the same parent directory (so subdirectories such as
+// worktree_subdir are kept). Errors are *ui.CLIError values.
+func moveWorktree(projectRoot, oldBranch, newBranch string) (*MoveData, error) {
+	if err := git.ValidateBranchName(newBranch); err != nil {
+		return nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error())
+	}
+	if err := git.ValidateDirNameLength(newBranch); err != nil {
+		return nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error())
+	}
+
+	wt, err := git.FindWorktreeForBranch(projectRoot, oldBranch)
+	if err != nil {
+		return nil, ui.NewCLIError(ui.ErrCodeGit, err.Error())
+	}
+	if wt == nil {
+		return nil, ui.NewCLIError(ui.ErrCodeNotFound, fmt.Sprintf("no worktree found for branch: %s", oldBranch))
+	}
+	if git.BranchExists(projectRoot, newBranch) {
+		return nil, ui.NewCLIError(ui.ErrCodeAlreadyExists, fmt.Sprintf("branch already exists: %s", newBranch))
+	}
+
+	newPath := filepath.Join(filepath.Dir(wt.Path), git.FlattenBranchName(newBranch))
+	if newPath != wt.Path {
+		if _, err := os.Lstat(newPath); err == nil {
+			return nil, ui.NewCLIError(ui.ErrCodeAlreadyExists, fmt.Sprintf("directory already exists: %s", newPath))
+		}
+	}
+
+	if err := git.RenameBranch(projectRoot, oldBranch, newBranch); err != nil {
+		return nil, ui.NewCLIError(ui.ErrCodeGit, err.Error())
+	}
+	if newPath != wt.Path {
+		if err := git.MoveWorktree(projectRoot, wt.Path, newPath); err != nil {
+			// Put the branch name back so the worktree and branch still match
+			_ = git.RenameBranch(projectRoot, newBranch, oldBranch)
+			return nil, ui.NewCLIError(ui.ErrCodeGit, err.Error())
+		}
+	}
+
+	return &MoveData{
+		OldBranch: oldBranch,
+		NewBranch: newBranch,
+		OldPath:   wt.Path,
+		NewPath:   newPath,
+	}, nil
+}
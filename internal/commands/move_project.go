@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// MoveProjectData represents the JSON output for the move-project command
+type MoveProjectData struct {
+	OldRoot string `json:"old_root"`
+	NewRoot string `json:"new_root"`
+	Output  string `json:"output,omitempty"`
+}
+
+var moveProjectCmd = &cobra.Command{
+	Use:   "move-project <new-path>",
+	Short: "Move the whole project to a new location",
+	Long: `Move the entire git-wt project (the bare repository and all worktrees
+inside it) to a new location, then repair the worktree links.
+
+This automates moving a project by hand and running git wt repair.
+The destination must not exist. Worktrees outside the project root stay
+where they are and are re-linked to the moved repository.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoveProject,
+}
+
+func init() {
+	rootCmd.AddCommand(moveProjectCmd)
+}
+
+func runMoveProject(cmd *cobra.Command, args []string) error {
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "move-project", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	dest, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	if _, err := os.Lstat(dest); err == nil {
+		msg := fmt.Sprintf("destination already exists: %s", dest)
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "move-project", nil, ui.NewCLIError(ui.ErrCodeAlreadyExists, msg))
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	if !IsJSONOutput() {
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Moving %s to %s...", shortenPath(projectRoot), shortenPath(dest))))
+	}
+
+	output, err := git.MoveProject(projectRoot, dest)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "move-project", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	if IsJSONOutput() {
+		data := MoveProjectData{
+			OldRoot: projectRoot,
+			NewRoot: dest,
+			Output:  strings.TrimSpace(output),
+		}
+		return ui.OutputJSON(os.Stdout, "move-project", data, nil)
+	}
+
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Moved project to %s", shortenPath(dest))))
+	if output = strings.TrimSpace(output); output != "" {
+		fmt.Println(ui.SubtleStyle.Render(output))
+	}
+	fmt.Println()
+	fmt.Println(ui.BoldStyle.Render(fmt.Sprintf("cd %s", dest)))
+	return nil
+}
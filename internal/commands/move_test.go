@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+)
+
+func TestMoveWorktree(t *testing.T) {
+	dir := initCommandTestRepo(t, "feature/atuh", "taken")
+
+	oldPath := filepath.Join(dir, "wt", "feature-atuh")
+	cmd := exec.Command("git", "worktree", "add", oldPath, "feature/atuh")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %v\n%s", err, out)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "wt", "occupied"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	errorCases := []struct {
+		name      string
+		oldBranch string
+		newBranch string
+		code      string
+	}{
+		{"invalid name", "feature/atuh", "bad..name", ui.ErrCodeValidation},
+		{"missing worktree", "nope", "feature/auth", ui.ErrCodeNotFound},
+		{"existing branch", "feature/atuh", "taken", ui.ErrCodeAlreadyExists},
+		{"existing directory", "feature/atuh", "occupied", ui.ErrCodeAlreadyExists},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := moveWorktree(dir, tt.oldBranch, tt.newBranch)
+			cliErr, ok := err.(*ui.CLIError)
+			if !ok || cliErr.Code != tt.code {
+				t.Errorf("expected %s error, got %v", tt.code, err)
+			}
+		})
+	}
+	if !git.BranchExists(dir, "feature/atuh") {
+		t.Fatal("failed moves must leave the branch alone")
+	}
+
+	data, err := moveWorktree(dir, "feature/atuh", "feature/auth")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	newPath := filepath.Join(dir, "wt", "feature-auth")
+	if data.NewPath != newPath || data.OldBranch != "feature/atuh" || data.NewBranch != "feature/auth" {
+		t.Errorf("unexpected result %+v", data)
+	}
+	wt, err := git.FindWorktreeForBranch(dir, "feature/auth")
+	if err != nil || wt == nil {
+		t.Fatalf("expected worktree for feature/auth, got %+v (%v)", wt, err)
+	}
+	resolved, _ := filepath.EvalSymlinks(newPath)
+	if got, _ := filepath.EvalSymlinks(wt.Path); got != resolved {
+		t.Errorf("expected worktree at %s, got %s", resolved, wt.Path)
+	}
+}
@@ -3,13 +3,19 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
 	"github.com/charmbracelet/huh"
 	"github.com/raisedadead/git-wt/internal/config"
 	"github.com/raisedadead/git-wt/internal/git"
 	"github.com/raisedadead/git-wt/internal/github"
+	"github.com/raisedadead/git-wt/internal/gitlab"
 	"github.com/raisedadead/git-wt/internal/hooks"
 	"github.com/raisedadead/git-wt/internal/ui"
 	"github.com/spf13/cobra"
@@ -17,13 +23,38 @@ import (
 
 // NewData represents the JSON output for the new command
 type NewData struct {
-	Branch     string     `json:"branch"`
-	Path       string     `json:"path"`
-	BaseBranch string     `json:"base_branch,omitempty"`
-	Issue      *IssueData `json:"issue,omitempty"`
-	PR         *PRData    `json:"pr,omitempty"`
+	Branch        string             `json:"branch"`
+	Ref           string             `json:"ref,omitempty"`
+	Path          string             `json:"path"`
+	Dir           string             `json:"dir"`
+	BaseBranch    string             `json:"base_branch,omitempty"`
+	BaseCommit    string             `json:"base_commit,omitempty"`
+	Tracking      string             `json:"tracking,omitempty"`
+	AlreadyExists bool               `json:"already_exists,omitempty"`
+	CheckedOut    bool               `json:"checked_out"`
+	Issue         *IssueData         `json:"issue,omitempty"`
+	PR            *PRData            `json:"pr,omitempty"`
+	MR            *MRData            `json:"mr,omitempty"`
+	GitConfig     map[string]string  `json:"git_config,omitempty"`
+	PushConfig    map[string]string  `json:"push_config,omitempty"`
+	PRBodyPath    string             `json:"pr_body_path,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	CopiedIgnored int                `json:"copied_ignored,omitempty"`
+	HooksPath     string             `json:"hooks_path,omitempty"`
+	Pushed        bool               `json:"pushed,omitempty"`
+	PRURL         string             `json:"pr_url,omitempty"`
+	BranchSource  string             `json:"branch_source,omitempty"`
+	Hooks         []hooks.HookResult `json:"hooks,omitempty"`
+	HooksSkipped  bool               `json:"hooks_skipped,omitempty"`
+	DurationMs    int64              `json:"duration_ms"`
 }
 
+// Where an issue worktree's branch name came from
+const (
+	BranchSourceLinked    = "linked"
+	BranchSourceGenerated = "generated"
+)
+
 // IssueData represents GitHub issue data for JSON output
 type IssueData struct {
 	Number int      `json:"number"`
@@ -33,6 +64,15 @@ type IssueData struct {
 
 // PRData represents GitHub PR data for JSON output
 type PRData struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	HeadRef   string `json:"head_ref,omitempty"`
+	HeadOwner string `json:"head_owner,omitempty"`
+}
+
+// MRData represents GitLab merge request data for JSON output
+type MRData struct {
 	Number int    `json:"number"`
 	Title  string `json:"title"`
 	Author string `json:"author"`
@@ -41,11 +81,32 @@ type PRData struct {
 var (
 	issueNum           int
 	prNum              int
+	mrNum              int
 	baseFlag           string
 	remoteFlag         string
 	branchTemplateFlag string
 	newTimeoutFlag     int
 	newHookTimeoutFlag int
+	dirPrefixFlag      string
+	noRelativePaths    bool
+	guessRemoteFlag    bool
+	setUpstreamOnPush  bool
+	forceNew           bool
+	recreateFlag       bool
+	noCheckoutFlag     bool
+	trackFlag          bool
+	prBodyFlag         bool
+	trackIssueFlag     bool
+	truncateLongNames  bool
+	copyIgnoredFrom    string
+	pushFlag           bool
+	createPRFlag       bool
+	draftFlag          bool
+	checkoutFlag       bool
+	checkoutPRFlag     bool
+	tagFlag            string
+	commitFlag         string
+	pathFlag           string
 )
 
 var newCmd = &cobra.Command{
@@ -57,23 +118,55 @@ var newCmd = &cobra.Command{
 Examples:
   git wt add feature/auth
   git wt add --issue 42
-  git wt add --pr 123`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runNew,
+  git wt add --pr 123
+  git wt add --pr 123 --checkout-pr
+  git wt add --mr 45
+  git wt add --checkout teammate/feature
+  git wt add --tag v1.2.3
+  git wt add feature/auth --path work/auth`,
+	Args:    cobra.MaximumNArgs(1),
+	PreRunE: commandDefaults("new"),
+	RunE:    runNew,
 }
 
 func init() {
 	newCmd.Flags().IntVar(&issueNum, "issue", 0, "Create worktree from GitHub issue number")
-	newCmd.Flags().IntVar(&prNum, "pr", 0, "Create worktree from GitHub PR number")
+	newCmd.Flags().IntVar(&prNum, "pr", 0, "Create worktree from GitHub PR number (a merge request on GitLab projects)")
+	newCmd.Flags().IntVar(&mrNum, "mr", 0, "Create worktree from GitLab merge request number (uses glab)")
 	newCmd.Flags().StringVar(&baseFlag, "base", "", "Base branch to create worktree from (default: HEAD)")
 	newCmd.Flags().StringVar(&remoteFlag, "remote", "", "Override default remote")
 	newCmd.Flags().StringVar(&branchTemplateFlag, "branch-template", "", "Override branch name template")
 	newCmd.Flags().IntVar(&newTimeoutFlag, "timeout", 0, "Override git operation timeout (seconds)")
 	newCmd.Flags().IntVar(&newHookTimeoutFlag, "hook-timeout", 0, "Override hook timeout (seconds)")
+	newCmd.Flags().BoolVar(&noRelativePaths, "no-relative-paths", false, "Record absolute worktree paths instead of using --relative-paths")
+	newCmd.Flags().BoolVar(&guessRemoteFlag, "guess-remote", false, "Track the matching remote branch if one exists (ignored with --base)")
+	newCmd.Flags().BoolVar(&trackFlag, "track", false, "Set --base as the upstream of the new branch")
+	newCmd.Flags().BoolVar(&setUpstreamOnPush, "set-upstream-on-push", false, "Configure the branch so the first git push publishes and tracks it")
+	newCmd.Flags().BoolVarP(&forceNew, "force", "f", false, "Allow a second worktree for the default branch")
+	newCmd.Flags().BoolVar(&recreateFlag, "recreate", false, "Remove and recreate the worktree if one already exists for the issue or PR (refused if it has uncommitted changes or unmerged commits)")
+	newCmd.Flags().BoolVar(&noCheckoutFlag, "no-checkout", false, "Create the worktree without checking out files (e.g. to set up sparse-checkout first)")
+	newCmd.Flags().BoolVar(&prBodyFlag, "pr-body", false, "Scaffold a PR body file that closes the issue (with --issue)")
+	newCmd.Flags().BoolVar(&trackIssueFlag, "track-issue", false, "Record \"Closes #<n>\" as the branch description (with --issue)")
+	newCmd.Flags().BoolVar(&truncateLongNames, "truncate-long-names", false, "Shorten directory names over the filesystem limit with a hash suffix")
+	newCmd.Flags().StringVar(&dirPrefixFlag, "dir-prefix", "", "Create the worktree under this subdirectory of the project root")
+	newCmd.Flags().StringVar(&copyIgnoredFrom, "copy-ignored-from", "", "Copy gitignored files (local env, build artifacts) from this branch's worktree")
+	newCmd.Flags().BoolVar(&pushFlag, "push", false, "Push the new branch to the remote and set it as upstream")
+	newCmd.Flags().BoolVar(&createPRFlag, "create-pr", false, "Push the branch and open a pull request for it with gh (implies --push)")
+	newCmd.Flags().BoolVar(&draftFlag, "draft", false, "Open the pull request as a draft (with --create-pr)")
+	newCmd.Flags().BoolVar(&checkoutFlag, "checkout", false, "Check out an existing local or remote branch instead of creating a new one")
+	newCmd.Flags().BoolVar(&checkoutPRFlag, "checkout-pr", false, "With --pr, check out the PR's head branch (fetched from refs/pull/<n>/head for forks) instead of creating a new branch")
+	newCmd.Flags().StringVar(&tagFlag, "tag", "", "Create a detached worktree at this tag")
+	newCmd.Flags().StringVar(&commitFlag, "commit", "", "Create a detached worktree at this commit")
+	newCmd.Flags().StringVar(&pathFlag, "path", "", "Create the worktree in this directory (relative to the project root) instead of one named after the branch")
+	_ = newCmd.RegisterFlagCompletionFunc("base", completeBranches)
+	_ = newCmd.RegisterFlagCompletionFunc("issue", completeGitHub("issue"))
+	_ = newCmd.RegisterFlagCompletionFunc("pr", completeGitHub("pr"))
 	rootCmd.AddCommand(newCmd)
 }
 
 func runNew(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+
 	// Find project root
 	projectRoot, err := git.GetProjectRoot(".")
 	if err != nil {
@@ -105,15 +198,87 @@ func runNew(cmd *cobra.Command, args []string) error {
 	if newHookTimeoutFlag > 0 {
 		cfg.HookTimeout = newHookTimeoutFlag
 	}
-
+	if dirPrefixFlag != "" {
+		cfg.WorktreeSubdir = dirPrefixFlag
+	}
+	if guessRemoteFlag {
+		cfg.GuessRemote = config.Bool(true)
+	}
+	if truncateLongNames {
+		cfg.TruncateLongNames = config.Bool(true)
+	}
+	if tagFlag != "" || commitFlag != "" {
+		if err := validateDetachedFlags(args); err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, err)
+			}
+			return err
+		}
+		return runNewDetached(projectRoot, cfg, start)
+	}
+	if err := validateCheckoutFlag(); err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, err)
+		}
+		return err
+	}
+	if err := validatePublishFlags(); err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, err)
+		}
+		return err
+	}
+	if prBodyFlag {
+		if issueNum == 0 {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, "--pr-body requires --issue"))
+			}
+			return ui.NewCLIError(ui.ErrCodeValidation, "--pr-body requires --issue")
+		}
+		cfg.PRBody = config.Bool(true)
+	}
+	if trackIssueFlag && issueNum == 0 {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, "--track-issue requires --issue"))
+		}
+		return ui.NewCLIError(ui.ErrCodeValidation, "--track-issue requires --issue")
+	}
+	if prNum > 0 && mrNum > 0 {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, "--pr cannot be used with --mr"))
+		}
+		return ui.NewCLIError(ui.ErrCodeValidation, "--pr cannot be used with --mr")
+	}
+	// On GitLab projects --pr means a merge request
+	if prNum > 0 {
+		forge, err := detectForge(projectRoot, cfg)
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+			}
+			return err
+		}
+		if forge == ForgeGitLab {
+			mrNum, prNum = prNum, 0
+		}
+	}
+	if err := validateCheckoutPRFlag(); err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, err)
+		}
+		return err
+	}
 	var branchName string
 	var issue *github.Issue
 	var pr *github.PullRequest
+	var mr *gitlab.MergeRequest
+	var existing *git.Worktree
+	var branchSource, linkedTracking string
 
 	// Determine what we're creating
 	if issueNum > 0 {
 		// From issue
-		issue, err = github.GetIssue(issueNum)
+		issue, err = github.GetIssue(issueNum, githubOptions(cfg))
 		if err != nil {
 			if IsJSONOutput() {
 				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeGitHub, err.Error()))
@@ -121,7 +286,26 @@ func runNew(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		branchName = github.GenerateBranchName("issue", issue.Number, issue.Title)
+		branchName, existing, err = resolveIssueBranch(projectRoot, git.Metadata{Issue: issue.Number}, issueBranchName(cfg, issue))
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+			}
+			return err
+		}
+
+		// Prefer a branch linked to the issue on GitHub over a generated name
+		branchSource = BranchSourceGenerated
+		if existing == nil && baseFlag == "" {
+			if linked := linkedIssueBranch(projectRoot, cfg.DefaultRemote, issue.Number, cfg.GitTimeout, githubOptions(cfg)); linked != "" {
+				branchName = linked
+				branchSource = BranchSourceLinked
+				linkedTracking = cfg.DefaultRemote + "/" + linked
+				if wt, err := git.FindWorktreeForBranch(projectRoot, linked); err == nil && wt != nil {
+					existing = wt
+				}
+			}
+		}
 		if !IsJSONOutput() {
 			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("#%d - %s", issue.Number, issue.Title)))
 			if len(issue.Labels) > 0 {
@@ -132,7 +316,7 @@ func runNew(cmd *cobra.Command, args []string) error {
 
 	} else if prNum > 0 {
 		// From PR
-		pr, err = github.GetPullRequest(prNum)
+		pr, err = github.GetPullRequest(prNum, githubOptions(cfg))
 		if err != nil {
 			if IsJSONOutput() {
 				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeGitHub, err.Error()))
@@ -140,13 +324,57 @@ func runNew(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		branchName = github.GenerateBranchName("pr", pr.Number, pr.Title)
+		if checkoutPRFlag {
+			branchName = prHeadBranch(pr)
+			existing, err = findSourceWorktree(projectRoot, git.Metadata{PR: pr.Number}, branchName)
+			if existing != nil {
+				branchName = existing.Branch
+			}
+		} else {
+			branchName, existing, err = resolveIssueBranch(projectRoot, git.Metadata{PR: pr.Number}, prBranchName(cfg, pr))
+		}
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+			}
+			return err
+		}
 		if !IsJSONOutput() {
 			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("#%d - %s", pr.Number, pr.Title)))
 			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Author: @%s", pr.Author.Login)))
 			fmt.Println()
 		}
 
+	} else if mrNum > 0 {
+		// From GitLab merge request
+		if !gitlab.GlabAvailable() {
+			msg := "glab CLI not found or not authenticated (install glab and run glab auth login)"
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeGitLab, msg))
+			}
+			return fmt.Errorf("%s", msg)
+		}
+		mr, err = gitlab.GetMergeRequest(mrNum)
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeGitLab, err.Error()))
+			}
+			return err
+		}
+
+		branchName, existing, err = resolveIssueBranch(projectRoot, git.Metadata{MR: mr.IID}, mrBranchName(cfg, mr))
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+			}
+			return err
+		}
+		if !IsJSONOutput() {
+			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("!%d - %s", mr.IID, mr.Title)))
+			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Author: @%s", mr.Author.Username)))
+			fmt.Println()
+		}
+
 	} else if len(args) > 0 {
 		// Direct branch name
 		branchName = args[0]
@@ -154,28 +382,53 @@ func runNew(cmd *cobra.Command, args []string) error {
 	} else {
 		// Interactive mode - skip if JSON output
 		if IsJSONOutput() {
-			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, "branch name is required (use positional arg, --issue, or --pr)"))
-		}
-		var workType string
-
-		form := huh.NewForm(
-			huh.NewGroup(
-				huh.NewSelect[string]().
-					Title("What are you working on?").
-					Options(
-						huh.NewOption("New feature branch", "feature"),
-						huh.NewOption("GitHub issue", "issue"),
-						huh.NewOption("GitHub pull request", "pr"),
-					).
-					Value(&workType),
-			),
-		)
-
-		if err := form.Run(); err != nil {
-			return err
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, "branch name is required (use positional arg, --issue, --pr, or --mr)"))
+		}
+		// The select starts on its current value, so keep "New feature
+		// branch" as the default choice
+		workType := "feature"
+		if checkoutFlag {
+			workType = "checkout"
+		} else {
+			form := huh.NewForm(
+				huh.NewGroup(
+					huh.NewSelect[string]().
+						Title("What are you working on?").
+						Options(
+							huh.NewOption("New feature branch", "feature"),
+							huh.NewOption("Existing branch", "checkout"),
+							huh.NewOption("GitHub issue", "issue"),
+							huh.NewOption("GitHub pull request", "pr"),
+						).
+						Value(&workType),
+				),
+			)
+
+			if err := form.Run(); err != nil {
+				return err
+			}
 		}
 
 		switch workType {
+		case "checkout":
+			candidates := checkoutCandidates(projectRoot, cfg.DefaultRemote)
+			if len(candidates) == 0 {
+				return fmt.Errorf("no branches without a worktree to check out")
+			}
+			form := huh.NewForm(
+				huh.NewGroup(
+					huh.NewSelect[string]().
+						Title("Branch to check out").
+						Options(huh.NewOptions(candidates...)...).
+						Value(&branchName),
+				),
+			)
+
+			if err := form.Run(); err != nil {
+				return err
+			}
+			checkoutFlag = true
+
 		case "issue":
 			var issueInput string
 			form := huh.NewForm(
@@ -195,12 +448,12 @@ func runNew(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("invalid issue number: %s", issueInput)
 			}
 
-			issue, err = github.GetIssue(issueNum)
+			issue, err = github.GetIssue(issueNum, githubOptions(cfg))
 			if err != nil {
 				return err
 			}
 
-			defaultBranch := github.GenerateBranchName("issue", issue.Number, issue.Title)
+			defaultBranch := git.UniqueBranchName(projectRoot, issueBranchName(cfg, issue))
 			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("#%d - %s", issue.Number, issue.Title)))
 
 			form = huh.NewForm(
@@ -239,12 +492,12 @@ func runNew(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("invalid PR number: %s", prInput)
 			}
 
-			pr, err = github.GetPullRequest(prNum)
+			pr, err = github.GetPullRequest(prNum, githubOptions(cfg))
 			if err != nil {
 				return err
 			}
 
-			defaultBranch := github.GenerateBranchName("pr", pr.Number, pr.Title)
+			defaultBranch := git.UniqueBranchName(projectRoot, prBranchName(cfg, pr))
 			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("#%d - %s", pr.Number, pr.Title)))
 
 			form = huh.NewForm(
@@ -279,6 +532,11 @@ func runNew(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Re-running add for the same issue/PR/MR reuses its worktree
+	if existing != nil {
+		return reportExistingWorktree(projectRoot, existing, issue, pr, mr, start)
+	}
+
 	if branchName == "" {
 		if IsJSONOutput() {
 			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, "branch name is required"))
@@ -293,17 +551,155 @@ func runNew(cmd *cobra.Command, args []string) error {
 		}
 		return fmt.Errorf("invalid branch name: %w", err)
 	}
+	if !forceNew {
+		if err := checkDefaultBranch(projectRoot, branchName); err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+			}
+			return err
+		}
+	}
+	if !cfg.TruncateNames() && pathFlag == "" {
+		if err := git.ValidateDirNameLength(branchName); err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+			}
+			return err
+		}
+	}
+
+	// Issue worktrees can be grouped into subdirectories by label
+	subdir := cfg.WorktreeSubdir
+	if issue != nil {
+		if labelDir := labelSubdir(cfg.LabelSubdirs, issue.GetLabelNames()); labelDir != "" {
+			subdir = filepath.Join(subdir, labelDir)
+		}
+	}
+	if err := git.ValidateSubdir(subdir); err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+		}
+		return err
+	}
+	explicitPath, err := resolvePathFlag(projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+		}
+		return err
+	}
+
+	// default_base_branch stands in for --base when creating a new branch
+	baseRef := baseFlag
+	if baseRef == "" && !checkoutFlag && !checkoutPRFlag {
+		baseRef = cfg.DefaultBaseBranch
+	}
+
+	// Don't silently base the branch on an odd state when run from a
+	// detached worktree; use the remote default branch (or insist on --base)
+	var detachedFallback string
+	if baseRef == "" && !checkoutFlag && !checkoutPRFlag {
+		detachedFallback, err = detachedHeadBase(projectRoot, ".", cfg)
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+			}
+			return err
+		}
+	}
+
+	// Catch base typos before git fails halfway through worktree creation
+	if baseRef != "" {
+		if err := validateBaseRef(projectRoot, baseRef, cfg.DefaultRemote); err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeNotFound, err.Error()))
+			}
+			return err
+		}
+	}
+
+	// Resolve the source of ignored files up front, before anything is created
+	var copySource string
+	if copyIgnoredFrom != "" {
+		wt, err := git.FindWorktreeForBranch(projectRoot, copyIgnoredFrom)
+		if err != nil || wt == nil {
+			msg := fmt.Sprintf("no worktree for --copy-ignored-from branch: %s", copyIgnoredFrom)
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeNotFound, msg))
+			}
+			return fmt.Errorf("%s", msg)
+		}
+		copySource = wt.Path
+	}
 
 	if !IsJSONOutput() {
 		fmt.Println(ui.SubtleStyle.Render("Creating worktree..."))
 	}
 
-	// Create the worktree (with optional base branch)
-	var worktreePath string
-	if baseFlag != "" {
-		worktreePath, err = git.CreateWorktreeWithBase(projectRoot, branchName, baseFlag)
-	} else {
-		worktreePath, err = git.CreateWorktree(projectRoot, branchName)
+	// Tracking setup follows the remote of a remote --base, e.g. upstream/main
+	remote := trackingRemote(projectRoot, baseRef, cfg.DefaultRemote)
+
+	// An explicit --base always wins over a guessed remote branch, which in
+	// turn wins over default_base_branch
+	base := baseFlag
+	var tracking string
+	if base == "" && linkedTracking != "" {
+		tracking = linkedTracking
+		base = tracking
+	} else if base == "" && cfg.GuessRemoteBranch() {
+		tracking = git.GuessRemoteBranch(projectRoot, cfg.DefaultRemote, branchName)
+		base = tracking
+	}
+	if base == "" {
+		base = baseRef
+	}
+	if trackFlag && baseRef != "" && base == baseRef {
+		tracking = baseRef
+	}
+	if base == "" && detachedFallback != "" {
+		base = detachedFallback
+		if !IsJSONOutput() {
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("Current worktree is in detached HEAD; branching off %s (pass --base to choose)", detachedFallback)))
+		}
+	}
+
+	// pre_add hooks can veto the worktree. It does not exist yet, so they
+	// always run in the project root.
+	if !HooksDisabled() {
+		plannedPath := git.WorktreePath(projectRoot, subdir, branchName)
+		if explicitPath != "" {
+			plannedPath = explicitPath
+		}
+		hookCtx := newHookContext(projectRoot, plannedPath, branchName)
+		hookCtx.WorkDir = projectRoot
+		if err := hooks.RunBlocking(cfg.Hooks.PreAdd, hookCtx, cfg.HookTimeout); err != nil {
+			msg := "pre_add hook failed: " + err.Error()
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, msg))
+			}
+			return fmt.Errorf("%s", msg)
+		}
+	}
+
+	// Create the worktree (with optional base branch), or check out an
+	// existing branch with --checkout
+	opts := git.WorktreeOptions{
+		Base:            base,
+		Subdir:          subdir,
+		NoRelativePaths: noRelativePaths || !cfg.RelativePaths(),
+		Track:           tracking != "",
+		NoCheckout:      noCheckoutFlag,
+		TruncateName:    cfg.TruncateNames(),
+		Path:            explicitPath,
+	}
+	var worktreePath, checkedOutRef string
+	switch {
+	case checkoutPRFlag:
+		worktreePath, checkedOutRef, tracking, err = checkoutPullRequest(projectRoot, cfg.DefaultRemote, pr, branchName, opts, cfg.GitLongTimeout)
+	case checkoutFlag:
+		worktreePath, tracking, err = git.WorktreeAddSmart(projectRoot, cfg.DefaultRemote, branchName, opts)
+	default:
+		worktreePath, err = git.CreateWorktreeWithOptions(projectRoot, branchName, opts)
 	}
 	if err != nil {
 		if IsJSONOutput() {
@@ -311,63 +707,930 @@ func runNew(cmd *cobra.Command, args []string) error {
 		}
 		return err
 	}
-	// Get flattened directory name for display
-	worktreeDir := git.FlattenBranchName(branchName)
+	// Record the exact starting point, since the base may be a moving ref
+	baseCommit := startCommit(worktreePath)
+
+	// Directory relative to the project root, for display
+	worktreeDir := relativeWorktreeDir(projectRoot, worktreePath)
 	if !IsJSONOutput() {
-		if baseFlag != "" {
-			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Created %s/ worktree (from %s)", worktreeDir, baseFlag)))
+		if tracking != "" {
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Created %s/ worktree (tracking %s)", worktreeDir, tracking)))
+		} else if base != "" {
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Created %s/ worktree (from %s)", worktreeDir, base)))
 		} else {
 			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Created %s/ worktree", worktreeDir)))
 		}
+		if noCheckoutFlag {
+			fmt.Println(ui.InfoMsg("Files not checked out; run git checkout (or set up sparse-checkout) when ready"))
+		}
 	}
 
-	// Get default branch name for hooks context
-	defaultBranchName, err := git.GetDefaultBranch(projectRoot)
-	if err != nil {
-		defaultBranchName = git.DefaultBranch
+	// Record who created the worktree, and from which issue/PR
+	meta := git.NewMetadata(worktreePath)
+	if issue != nil {
+		meta.Issue = issue.Number
+	}
+	if pr != nil {
+		meta.PR = pr.Number
+	}
+	if mr != nil {
+		meta.MR = mr.IID
+	}
+	if err := git.WriteMetadata(worktreePath, meta); err != nil && !IsJSONOutput() {
+		fmt.Println(ui.WarningMsg(fmt.Sprintf("Could not record worktree metadata: %v", err)))
+	}
+
+	// Link the branch to its issue in git's own config, which outlives the
+	// metadata file
+	var description string
+	if issue != nil && trackIssueFlag {
+		description = fmt.Sprintf("Closes #%d", issue.Number)
+		if err := git.SetBranchDescription(worktreePath, branchName, description); err != nil {
+			description = ""
+			if !IsJSONOutput() {
+				fmt.Println(ui.WarningMsg(fmt.Sprintf("Could not set branch description: %v", err)))
+			}
+		}
+	}
+
+	// Scaffold a PR body that closes the issue
+	var prBodyPath string
+	if issue != nil && cfg.ScaffoldPRBody() {
+		prBodyPath, err = writePRBody(worktreePath, cfg.PRBodyPath, cfg.PRBodyTemplate, issue)
+		if err != nil {
+			if !IsJSONOutput() {
+				fmt.Println(ui.WarningMsg(fmt.Sprintf("Could not write PR body: %v", err)))
+			}
+		} else if !IsJSONOutput() {
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Wrote PR body to %s", shortenPath(prBodyPath))))
+		}
+	}
+
+	// Bring the local, gitignored setup along before hooks run
+	var copiedIgnored int
+	if copySource != "" {
+		copiedIgnored, err = git.CopyIgnoredFiles(copySource, worktreePath)
+		if err != nil {
+			if !IsJSONOutput() {
+				fmt.Println(ui.WarningMsg(fmt.Sprintf("Could not copy ignored files: %v", err)))
+			}
+		} else if !IsJSONOutput() {
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Copied %d ignored file(s) from %s", copiedIgnored, copyIgnoredFrom)))
+		}
 	}
 
-	// Run post_add hooks
-	hookCtx := hooks.Context{
-		Path:          worktreePath,
-		Branch:        branchName,
-		ProjectRoot:   projectRoot,
-		DefaultBranch: defaultBranchName,
+	// Apply per-worktree git config before hooks run
+	appliedConfig := applyWorktreeGitConfig(worktreePath, cfg.WorktreeGitConfig)
+
+	// Make sure git hooks (pre-commit etc.) resolve in the new worktree
+	hooksPath, err := setupHooksPath(worktreePath, cfg.WorktreeHooksPath)
+	if err != nil && !IsJSONOutput() {
+		fmt.Println(ui.WarningMsg(fmt.Sprintf("Git hooks: %v", err)))
 	}
-	if warnings := hooks.RunWithTimeout(cfg.Hooks.PostAdd, hookCtx, cfg.HookTimeout); len(warnings) > 0 {
-		for _, w := range warnings {
+
+	// Defer publishing the branch until the first plain git push
+	var pushConfig map[string]string
+	if setUpstreamOnPush {
+		pushConfig, err = git.SetUpstreamOnPush(worktreePath, branchName, remote)
+		if err != nil {
 			if !IsJSONOutput() {
-				fmt.Println(ui.WarningMsg("Hook: " + w))
+				fmt.Println(ui.WarningMsg(fmt.Sprintf("Could not configure push upstream: %v", err)))
 			}
+		} else if !IsJSONOutput() {
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("First git push will publish to %s and set upstream", remote)))
 		}
 	}
 
+	// Publish the branch and open a PR; failures leave the worktree in place
+	var published publishResult
+	if pushFlag || createPRFlag {
+		published = publishNewBranch(worktreePath, remote, branchName, issue, prBodyPath, cfg.GitLongTimeout, githubOptions(cfg))
+		if !IsJSONOutput() {
+			for _, w := range published.Warnings {
+				fmt.Println(ui.WarningMsg(w))
+			}
+			if published.Pushed {
+				fmt.Println(ui.SuccessMsg(fmt.Sprintf("Pushed %s to %s", branchName, remote)))
+			}
+			if published.PRURL != "" {
+				fmt.Println(ui.SuccessMsg("Opened " + published.PRURL))
+			}
+		}
+	}
+
+	// Run post_add hooks; with --json their output is captured into the
+	// result instead of corrupting it
+	var hookResults []hooks.HookResult
+	if !HooksDisabled() {
+		hookCtx := newHookContext(projectRoot, worktreePath, branchName)
+		hookResults = runWorktreeHooks(cfg.Hooks.PostAdd, hookCtx, cfg)
+	}
+
 	// JSON output
 	if IsJSONOutput() {
 		data := NewData{
-			Branch:     branchName,
-			Path:       worktreePath,
-			BaseBranch: baseFlag,
+			Branch:        branchName,
+			Ref:           checkedOutRef,
+			Path:          worktreePath,
+			Dir:           worktreeDir,
+			BaseBranch:    base,
+			BaseCommit:    baseCommit,
+			Tracking:      tracking,
+			CheckedOut:    !noCheckoutFlag,
+			GitConfig:     appliedConfig,
+			PushConfig:    pushConfig,
+			PRBodyPath:    prBodyPath,
+			Description:   description,
+			CopiedIgnored: copiedIgnored,
+			HooksPath:     hooksPath,
+			Pushed:        published.Pushed,
+			PRURL:         published.PRURL,
+			Hooks:         hookResults,
+			HooksSkipped:  HooksDisabled(),
+			BranchSource:  branchSource,
+			DurationMs:    time.Since(start).Milliseconds(),
 		}
-		if issue != nil {
-			data.Issue = &IssueData{
-				Number: issue.Number,
-				Title:  issue.Title,
-				Labels: issue.GetLabelNames(),
-			}
+		data.setSource(issue, pr, mr)
+		return ui.OutputJSON(os.Stdout, "new", data, nil)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldStyle.Render(fmt.Sprintf("cd %s", worktreePath)))
+
+	return nil
+}
+
+// validatePublishFlags checks the --push/--create-pr/--draft combination
+func validatePublishFlags() error {
+	if draftFlag && !createPRFlag {
+		return ui.NewCLIError(ui.ErrCodeValidation, "--draft requires --create-pr")
+	}
+	if createPRFlag && prNum > 0 {
+		return ui.NewCLIError(ui.ErrCodeValidation, "--create-pr cannot be used with --pr (the pull request already exists)")
+	}
+	return nil
+}
+
+// validateCheckoutPRFlag checks --checkout-pr is used with a GitHub --pr and
+// without flags that describe a new branch
+func validateCheckoutPRFlag() error {
+	if !checkoutPRFlag {
+		return nil
+	}
+	switch {
+	case mrNum > 0:
+		return ui.NewCLIError(ui.ErrCodeValidation, "--checkout-pr is not supported for GitLab merge requests")
+	case prNum == 0:
+		return ui.NewCLIError(ui.ErrCodeValidation, "--checkout-pr requires --pr")
+	case baseFlag != "":
+		return ui.NewCLIError(ui.ErrCodeValidation, "--checkout-pr cannot be used with --base (the PR's head is checked out)")
+	}
+	return nil
+}
+
+// validateCheckoutFlag rejects flags that describe a new branch, which
+// --checkout does not create
+func validateCheckoutFlag() error {
+	if !checkoutFlag {
+		return nil
+	}
+	switch {
+	case issueNum > 0 || prNum > 0:
+		return ui.NewCLIError(ui.ErrCodeValidation, "--checkout cannot be used with --issue or --pr")
+	case baseFlag != "":
+		return ui.NewCLIError(ui.ErrCodeValidation, "--checkout cannot be used with --base (the branch already exists)")
+	}
+	return nil
+}
+
+// resolvePathFlag resolves and validates --path, returning "" without it
+func resolvePathFlag(projectRoot string) (string, error) {
+	if pathFlag == "" {
+		return "", nil
+	}
+	return git.ResolveWorktreePath(projectRoot, pathFlag)
+}
+
+// relativeWorktreeDir returns a worktree's directory relative to the project
+// root for display, e.g. "feature-auth" or "../project-auth"
+func relativeWorktreeDir(projectRoot, worktreePath string) string {
+	if rel, err := filepath.Rel(projectRoot, worktreePath); err == nil {
+		return rel
+	}
+	return worktreePath
+}
+
+// validateDetachedFlags rejects flags that need a branch, which --tag and
+// --commit worktrees do not have
+func validateDetachedFlags(args []string) error {
+	switch {
+	case tagFlag != "" && commitFlag != "":
+		return ui.NewCLIError(ui.ErrCodeValidation, "--tag cannot be used with --commit")
+	case len(args) > 0 || issueNum > 0 || prNum > 0 || checkoutFlag || baseFlag != "":
+		return ui.NewCLIError(ui.ErrCodeValidation, "--tag and --commit create a detached worktree and cannot be used with a branch, --issue, --pr, --checkout or --base")
+	case pushFlag || createPRFlag || setUpstreamOnPush:
+		return ui.NewCLIError(ui.ErrCodeValidation, "--tag and --commit worktrees have no branch to push")
+	}
+	return nil
+}
+
+// runNewDetached creates a detached-HEAD worktree at --tag or --commit, named
+// after the ref, e.g. for inspecting a release or bisecting without a
+// throwaway branch
+func runNewDetached(projectRoot string, cfg *config.Config, start time.Time) error {
+	ref, label := commitFlag, commitFlag
+	if tagFlag != "" {
+		ref, label = "refs/tags/"+tagFlag, tagFlag
+	}
+	if _, err := git.ResolveRef(projectRoot, ref); err != nil {
+		msg := fmt.Sprintf("ref not found: %s", label)
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeNotFound, msg))
 		}
-		if pr != nil {
-			data.PR = &PRData{
-				Number: pr.Number,
-				Title:  pr.Title,
-				Author: pr.Author.Login,
+		return fmt.Errorf("%s", msg)
+	}
+
+	subdir := cfg.WorktreeSubdir
+	if err := git.ValidateSubdir(subdir); err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+		}
+		return err
+	}
+	explicitPath, err := resolvePathFlag(projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+		}
+		return err
+	}
+	dirName := git.DetachedDirName(ref)
+	worktreePath := filepath.Join(projectRoot, subdir, dirName)
+	if explicitPath != "" {
+		worktreePath = explicitPath
+	} else if _, err := os.Stat(worktreePath); err == nil {
+		msg := fmt.Sprintf("directory already exists: %s", worktreePath)
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeAlreadyExists, msg))
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	if !HooksDisabled() {
+		hookCtx := newHookContext(projectRoot, worktreePath, "")
+		hookCtx.WorkDir = projectRoot
+		if err := hooks.RunBlocking(cfg.Hooks.PreAdd, hookCtx, cfg.HookTimeout); err != nil {
+			msg := "pre_add hook failed: " + err.Error()
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeValidation, msg))
 			}
+			return fmt.Errorf("%s", msg)
 		}
-		return ui.OutputJSON(os.Stdout, "new", data, nil)
+	}
+
+	worktreePath, err = git.CreateDetachedWorktree(projectRoot, ref, dirName, git.WorktreeOptions{
+		Subdir:          subdir,
+		NoRelativePaths: noRelativePaths || !cfg.RelativePaths(),
+		NoCheckout:      noCheckoutFlag,
+		Path:            explicitPath,
+	})
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "new", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+	worktreeDir := relativeWorktreeDir(projectRoot, worktreePath)
+	if !IsJSONOutput() {
+		fmt.Println(ui.SuccessMsg(fmt.Sprintf("Created %s/ worktree (detached at %s)", worktreeDir, label)))
+	}
+
+	hooksPath, err := setupHooksPath(worktreePath, cfg.WorktreeHooksPath)
+	if err != nil && !IsJSONOutput() {
+		fmt.Println(ui.WarningMsg(fmt.Sprintf("Git hooks: %v", err)))
+	}
+
+	var hookResults []hooks.HookResult
+	if !HooksDisabled() {
+		hookCtx := newHookContext(projectRoot, worktreePath, "")
+		hookResults = runWorktreeHooks(cfg.Hooks.PostAdd, hookCtx, cfg)
+	}
+
+	if IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "new", NewData{
+			Ref:          label,
+			Path:         worktreePath,
+			Dir:          worktreeDir,
+			BaseCommit:   startCommit(worktreePath),
+			CheckedOut:   !noCheckoutFlag,
+			HooksPath:    hooksPath,
+			Hooks:        hookResults,
+			HooksSkipped: HooksDisabled(),
+			DurationMs:   time.Since(start).Milliseconds(),
+		}, nil)
 	}
 
 	fmt.Println()
 	fmt.Println(ui.BoldStyle.Render(fmt.Sprintf("cd %s", worktreePath)))
+	return nil
+}
+
+// checkoutCandidates lists the existing branches --checkout can offer: local
+// branches without a worktree, then branches only on remote
+func checkoutCandidates(projectRoot, remote string) []string {
+	branches, err := git.ListBranches(projectRoot)
+	if err != nil {
+		return nil
+	}
+	taken := map[string]bool{}
+	if worktrees, err := git.ListWorktrees(projectRoot); err == nil {
+		for _, wt := range worktrees {
+			taken[wt.Branch] = true
+		}
+	}
+
+	var candidates []string
+	for _, branch := range branches {
+		if name, ok := strings.CutPrefix(branch, remote+"/"); ok {
+			branch = name
+		} else if git.RemoteFromRef(projectRoot, branch) != "" {
+			continue
+		}
+		if !taken[branch] {
+			candidates = append(candidates, branch)
+			taken[branch] = true
+		}
+	}
+	return candidates
+}
+
+// pushNewBranch and createPullRequest publish a new worktree's branch;
+// variables so tests can stub out git push and gh
+var (
+	pushNewBranch     = git.PushBranch
+	createPullRequest = github.CreatePullRequest
+)
+
+// publishResult reports what publishNewBranch managed to do
+type publishResult struct {
+	Pushed   bool
+	PRURL    string
+	Warnings []string
+}
+
+// publishNewBranch pushes a new branch and, with --create-pr, opens a pull
+// request for it: titled after the issue (closing it) when created from one,
+// otherwise filled from the commits. Failures become warnings so the
+// worktree is kept either way.
+func publishNewBranch(worktreePath, remote, branchName string, issue *github.Issue, prBodyPath string, timeout int, gh github.Options) publishResult {
+	var result publishResult
+	if err := pushNewBranch(worktreePath, remote, branchName, timeout); err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+		return result
+	}
+	result.Pushed = true
+
+	if !createPRFlag {
+		return result
+	}
+	opts := github.PRCreateOptions{Options: gh, Draft: draftFlag}
+	if issue != nil {
+		opts.Title = issue.Title
+		opts.Body = fmt.Sprintf("Closes #%d", issue.Number)
+		opts.BodyFile = prBodyPath
+	}
+	url, err := createPullRequest(worktreePath, opts)
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+		return result
+	}
+	result.PRURL = url
+	return result
+}
+
+// trackingRemote returns the remote a --base ref lives on, falling back to
+// defaultRemote for local refs or when no base is given
+func trackingRemote(projectRoot, base, defaultRemote string) string {
+	if base == "" {
+		return defaultRemote
+	}
+	if remote := git.RemoteFromRef(projectRoot, base); remote != "" {
+		return remote
+	}
+	return defaultRemote
+}
+
+// startCommit returns the full SHA checked out in a new worktree, or "" if it
+// cannot be resolved
+func startCommit(worktreePath string) string {
+	sha, err := git.ResolveRef(worktreePath, "HEAD")
+	if err != nil {
+		return ""
+	}
+	return sha
+}
+
+// setSource records the issue, PR or MR a worktree was created from
+func (d *NewData) setSource(issue *github.Issue, pr *github.PullRequest, mr *gitlab.MergeRequest) {
+	if issue != nil {
+		d.Issue = &IssueData{
+			Number: issue.Number,
+			Title:  issue.Title,
+			Labels: issue.GetLabelNames(),
+		}
+	}
+	if pr != nil {
+		d.PR = &PRData{
+			Number: pr.Number,
+			Title:  pr.Title,
+			Author: pr.Author.Login,
+		}
+		if checkoutPRFlag {
+			d.PR.HeadRef = pr.HeadRefName
+			d.PR.HeadOwner = pr.HeadRepositoryOwner.Login
+		}
+	}
+	if mr != nil {
+		d.MR = &MRData{
+			Number: mr.IID,
+			Title:  mr.Title,
+			Author: mr.Author.Username,
+		}
+	}
+}
+
+// githubOptions returns the gh options configured for the project, e.g. a
+// GitHub Enterprise github_host
+func githubOptions(cfg *config.Config) github.Options {
+	return github.Options{Host: cfg.GitHubHost}
+}
+
+// issueLinkedBranches lists the branches linked to an issue on GitHub;
+// a variable so tests can stub out gh
+var issueLinkedBranches = github.LinkedBranches
+
+// linkedIssueBranch returns the first branch linked to an issue that exists
+// on the remote (fetching it if needed), or "" to fall back to a generated
+// name. A linked branch that only exists locally without a worktree is
+// skipped, since checking it out would not track the remote.
+func linkedIssueBranch(projectRoot, remote string, number, timeout int, gh github.Options) string {
+	branches, err := issueLinkedBranches(number, gh)
+	if err != nil {
+		return ""
+	}
+
+	for _, branch := range branches {
+		if wt, err := git.FindWorktreeForBranch(projectRoot, branch); err == nil && wt != nil {
+			return branch
+		}
+		if git.GuessRemoteBranch(projectRoot, remote, branch) != "" {
+			return branch
+		}
+		if _, err := git.ResolveRef(projectRoot, "refs/heads/"+branch); err == nil {
+			continue
+		}
+		ref := "refs/remotes/" + remote + "/" + branch
+		if _, err := git.RunInDirWithTimeout(projectRoot, timeout, "fetch", remote, branch+":"+ref); err == nil {
+			return branch
+		}
+	}
+	return ""
+}
+
+// resolveIssueBranch returns a free branch name for an issue/PR worktree, or
+// the worktree that already exists for it. The existing worktree is matched
+// by its stored metadata only: the generated name can change between runs
+// (e.g. with {{date}} or a retitled issue), so it is never used to match.
+// With --recreate the existing worktree and its branch are removed so the
+// worktree can be recreated.
+func resolveIssueBranch(projectRoot string, meta git.Metadata, generated string) (string, *git.Worktree, error) {
+	existing, err := findSourceWorktree(projectRoot, meta, "")
+	if err != nil {
+		return "", nil, err
+	}
+	if existing != nil {
+		return existing.Branch, existing, nil
+	}
+	return git.UniqueBranchName(projectRoot, generated), nil, nil
+}
+
+// issueBranchName, prBranchName and mrBranchName render branch_template
+// for a new issue, PR or MR worktree
+func issueBranchName(cfg *config.Config, issue *github.Issue) string {
+	return github.RenderBranchName(cfg.BranchTemplate, github.BranchData{
+		Type: "issue", Number: issue.Number, Title: issue.Title,
+		Author: issue.Author.Login, Labels: issue.GetLabelNames(), Date: time.Now(),
+	})
+}
+
+func prBranchName(cfg *config.Config, pr *github.PullRequest) string {
+	return github.RenderBranchName(cfg.BranchTemplate, github.BranchData{
+		Type: "pr", Number: pr.Number, Title: pr.Title,
+		Author: pr.Author.Login, Labels: pr.GetLabelNames(), Date: time.Now(),
+	})
+}
+
+func mrBranchName(cfg *config.Config, mr *gitlab.MergeRequest) string {
+	return github.RenderBranchName(cfg.BranchTemplate, github.BranchData{
+		Type: "mr", Number: mr.IID, Title: mr.Title,
+		Author: mr.Author.Username, Labels: mr.Labels, Date: time.Now(),
+	})
+}
+
+// findSourceWorktree returns the worktree already created for an issue/PR/MR
+// (by its metadata, the "Closes #N" description add --track-issue sets, or,
+// when branch is not empty, on branch), or nil if there is none. With
+// --recreate the existing worktree and its branch are removed instead and nil
+// is returned, unless that would lose uncommitted changes or unmerged commits.
+func findSourceWorktree(projectRoot string, meta git.Metadata, branch string) (*git.Worktree, error) {
+	existing, err := git.FindWorktreeByMetadata(projectRoot, meta)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil && meta.Issue > 0 {
+		if existing, err = findIssueWorktreeByDescription(projectRoot, meta.Issue); err != nil {
+			return nil, err
+		}
+	}
+	if existing == nil && branch != "" {
+		if existing, err = git.FindWorktreeForBranch(projectRoot, branch); err != nil {
+			return nil, err
+		}
+	}
+
+	if existing == nil || !recreateFlag {
+		return existing, nil
+	}
+	if err := checkRecreatable(projectRoot, existing); err != nil {
+		return nil, err
+	}
+	if err := git.RemoveWorktreeForce(projectRoot, existing.Path); err != nil {
+		return nil, err
+	}
+	if err := git.DeleteBranch(projectRoot, existing.Branch); err != nil {
+		return nil, err
+	}
+	if !IsJSONOutput() {
+		fmt.Println(ui.WarningMsg(fmt.Sprintf("Removed existing worktree %s", shortenPath(existing.Path))))
+	}
+	return nil, nil
+}
+
+// findIssueWorktreeByDescription returns the worktree whose branch
+// description closes issue, for worktrees whose metadata file is gone
+func findIssueWorktreeByDescription(projectRoot string, issue int) (*git.Worktree, error) {
+	worktrees, err := git.ListWorktrees(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	for i, wt := range worktrees {
+		if wt.Branch != "" && issueFromDescription(git.BranchDescription(wt.Path, wt.Branch)) == issue {
+			return &worktrees[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// checkRecreatable refuses to recreate a worktree whose removal would lose
+// work: uncommitted changes (or an unreadable status) or commits that are
+// neither merged into the default branch nor pushed
+func checkRecreatable(projectRoot string, wt *git.Worktree) error {
+	if status, err := git.GetWorktreeStatus(wt.Path); err != nil || !status.IsClean() {
+		return fmt.Errorf("worktree %s has uncommitted changes; commit them or use 'git wt delete --force %s' first", shortenPath(wt.Path), wt.Branch)
+	}
+	defaultBranch, _ := git.GetDefaultBranch(projectRoot)
+	unmerged, err := git.CountUnmergedCommits(projectRoot, wt.Branch, defaultBranch)
+	if err != nil {
+		return err
+	}
+	if unmerged > 0 {
+		return fmt.Errorf("branch %s has %d unmerged commit(s); merge them or use 'git wt delete --force %s' first", wt.Branch, unmerged, wt.Branch)
+	}
+	return nil
+}
+
+// prHeadBranch names the local branch for --checkout-pr: the PR's head
+// branch, prefixed with the fork owner for PRs from forks (as their head
+// branch is often main or a name already used locally)
+func prHeadBranch(pr *github.PullRequest) string {
+	if pr.IsCrossRepository && pr.HeadRepositoryOwner.Login != "" {
+		return pr.HeadRepositoryOwner.Login + "/" + pr.HeadRefName
+	}
+	return pr.HeadRefName
+}
+
+// checkoutPullRequest creates a worktree on a pull request's head, like gh pr
+// checkout: a head branch on remote is checked out tracking it, while a
+// fork's head is fetched from refs/pull/<n>/head. Returns the worktree path,
+// the ref that was checked out and the upstream set up for the branch ("" if
+// an existing local branch was used as is).
+func checkoutPullRequest(projectRoot, remote string, pr *github.PullRequest, branchName string, opts git.WorktreeOptions, timeout int) (string, string, string, error) {
+	if !pr.IsCrossRepository {
+		ref := remote + "/" + pr.HeadRefName
+		if _, err := git.RunInDirWithTimeout(projectRoot, timeout, "fetch", remote, "+refs/heads/"+pr.HeadRefName+":refs/remotes/"+ref); err != nil {
+			return "", "", "", fmt.Errorf("failed to fetch %s from %s: %w", pr.HeadRefName, remote, err)
+		}
+		path, tracking, err := git.WorktreeAddSmart(projectRoot, remote, branchName, opts)
+		return path, ref, tracking, err
+	}
+
+	ref := git.PullRequestRef(pr.Number)
+	tracking := ""
+	if !git.BranchExists(projectRoot, branchName) {
+		if err := git.FetchPullRequestHead(projectRoot, remote, pr.Number, branchName, timeout); err != nil {
+			return "", "", "", err
+		}
+		tracking = remote + " " + ref
+	}
+	opts.Base, opts.Track, opts.Existing = "", false, true
+	path, err := git.CreateWorktreeWithOptions(projectRoot, branchName, opts)
+	return path, ref, tracking, err
+}
+
+// reportExistingWorktree reports the worktree already created for an
+// issue/PR/MR instead of creating a duplicate
+func reportExistingWorktree(projectRoot string, wt *git.Worktree, issue *github.Issue, pr *github.PullRequest, mr *gitlab.MergeRequest, start time.Time) error {
+	dir, err := filepath.Rel(projectRoot, wt.Path)
+	if err != nil {
+		dir = wt.Path
+	}
+
+	if IsJSONOutput() {
+		data := NewData{
+			Branch:        wt.Branch,
+			Path:          wt.Path,
+			Dir:           dir,
+			AlreadyExists: true,
+			CheckedOut:    true,
+			DurationMs:    time.Since(start).Milliseconds(),
+		}
+		data.setSource(issue, pr, mr)
+		return ui.OutputJSON(os.Stdout, "new", data, nil)
+	}
 
+	var ref string
+	switch {
+	case issue != nil:
+		ref = fmt.Sprintf("issue #%d", issue.Number)
+	case pr != nil:
+		ref = fmt.Sprintf("PR #%d", pr.Number)
+	case mr != nil:
+		ref = fmt.Sprintf("MR !%d", mr.IID)
+	}
+	fmt.Println(ui.InfoMsg(fmt.Sprintf("Worktree for %s already exists: %s/ (use --recreate to recreate)", ref, dir)))
+	fmt.Println()
+	fmt.Println(ui.BoldStyle.Render(fmt.Sprintf("cd %s", wt.Path)))
 	return nil
 }
+
+// applyWorktreeGitConfig sets worktree_git_config entries in the new worktree
+// Returns the settings that were applied; failures are reported as warnings
+func applyWorktreeGitConfig(worktreePath string, settings map[string]string) map[string]string {
+	if len(settings) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	applied := make(map[string]string)
+	for _, key := range keys {
+		if err := git.SetLocalConfig(worktreePath, key, settings[key]); err != nil {
+			if !IsJSONOutput() {
+				fmt.Println(ui.WarningMsg(fmt.Sprintf("Git config: %v", err)))
+			}
+			continue
+		}
+		applied[key] = settings[key]
+		if !IsJSONOutput() {
+			fmt.Println(ui.SuccessMsg(fmt.Sprintf("Set %s = %s", key, settings[key])))
+		}
+	}
+	return applied
+}
+
+// setupHooksPath sets core.hooksPath for a new worktree when configured, then
+// verifies that an explicit core.hooksPath (ours or inherited) points at an
+// existing directory. Returns the hooks directory the worktree resolves to.
+func setupHooksPath(worktreePath, hooksPath string) (string, error) {
+	if hooksPath != "" {
+		if err := git.SetLocalConfig(worktreePath, "core.hooksPath", hooksPath); err != nil {
+			return "", err
+		}
+	}
+
+	dir, err := git.HooksDir(worktreePath)
+	if err != nil {
+		return "", err
+	}
+	if configured, _ := git.GetLocalConfig(worktreePath, "core.hooksPath"); configured != "" {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return dir, fmt.Errorf("core.hooksPath %s resolves to %s, which does not exist", configured, dir)
+		}
+	}
+	return dir, nil
+}
+
+// labelSubdir returns the subdirectory mapped to the first issue label found
+// in label_subdirs (labels match case-insensitively), or "" when none match
+func labelSubdir(labelSubdirs map[string]string, labels []string) string {
+	for _, label := range labels {
+		if dir, ok := labelSubdirs[label]; ok {
+			return dir
+		}
+		for key, dir := range labelSubdirs {
+			if strings.EqualFold(key, label) {
+				return dir
+			}
+		}
+	}
+	return ""
+}
+
+// validateBaseRef checks that a --base ref resolves to a commit, suggesting
+// the remote branch when only <remote>/<base> exists
+func validateBaseRef(projectRoot, base, remote string) error {
+	if _, err := git.ResolveRef(projectRoot, base); err == nil {
+		return nil
+	}
+	msg := fmt.Sprintf("base ref not found: %s (checked local branches, remote branches, tags and commit SHAs)", base)
+	if remote != "" {
+		remoteRef := remote + "/" + base
+		if _, err := git.ResolveRef(projectRoot, remoteRef); err == nil {
+			msg += fmt.Sprintf("; did you mean %s?", remoteRef)
+		}
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// Values for the forge config option
+const (
+	ForgeGitHub = "github"
+	ForgeGitLab = "gitlab"
+)
+
+// detectForge returns the code host --pr refers to: the forge config option
+// if set, otherwise GitLab when the default remote points at a GitLab host
+func detectForge(projectRoot string, cfg *config.Config) (string, error) {
+	switch cfg.Forge {
+	case ForgeGitHub, ForgeGitLab:
+		return cfg.Forge, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid forge %q (expected %q or %q)", cfg.Forge, ForgeGitHub, ForgeGitLab)
+	}
+
+	if url, err := git.RemoteURL(projectRoot, cfg.DefaultRemote); err == nil && gitlab.IsGitLabURL(url) {
+		return ForgeGitLab, nil
+	}
+	return ForgeGitHub, nil
+}
+
+// Values for the detached_head config option
+const (
+	DetachedHeadDefault = "default"
+	DetachedHeadRequire = "require"
+)
+
+// detachedHeadBase returns the base for a new branch when add runs without
+// --base from a worktree (dir) in detached HEAD: the remote default branch,
+// falling back to the local one. Returns "" when dir is on a branch.
+func detachedHeadBase(projectRoot, dir string, cfg *config.Config) (string, error) {
+	if !git.IsDetachedHead(dir) {
+		return "", nil
+	}
+
+	switch cfg.DetachedHead {
+	case "", DetachedHeadDefault:
+	case DetachedHeadRequire:
+		return "", fmt.Errorf("current worktree is in detached HEAD; pass --base to choose the base branch")
+	default:
+		return "", fmt.Errorf("invalid detached_head %q (expected %q or %q)", cfg.DetachedHead, DetachedHeadDefault, DetachedHeadRequire)
+	}
+
+	defaultBranch, err := git.GetDefaultBranch(projectRoot)
+	if err != nil {
+		defaultBranch = git.DefaultBranch
+	}
+	remoteRef := cfg.DefaultRemote + "/" + defaultBranch
+	if _, err := git.ResolveRef(projectRoot, remoteRef); err == nil {
+		return remoteRef, nil
+	}
+	return defaultBranch, nil
+}
+
+// checkDefaultBranch rejects a new worktree for the default branch, which
+// already has one from clone, pointing at the existing worktree instead of
+// letting git fail with a raw error
+func checkDefaultBranch(projectRoot, branchName string) error {
+	defaultBranch, err := git.GetDefaultBranch(projectRoot)
+	if err != nil || branchName != defaultBranch {
+		return nil
+	}
+	if wt, err := git.FindWorktreeForBranch(projectRoot, branchName); err == nil && wt != nil {
+		return fmt.Errorf("%s is the default branch and already has a worktree at %s (use --force to create one anyway)", branchName, shortenPath(wt.Path))
+	}
+	return fmt.Errorf("%s is the default branch (use --force to create a worktree for it anyway)", branchName)
+}
+
+// completeBranches suggests local and remote branch names for flag values
+func completeBranches(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	branches, err := git.ListBranches(projectRoot)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return branches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// ghCompletions caches gh list results per kind for the life of the process
+var (
+	ghCompletionsMu sync.Mutex
+	ghCompletions   = map[string][]string{}
+)
+
+// completeGitHub suggests open issue or PR numbers, with titles as
+// descriptions. Returns no completions when gh is unavailable.
+func completeGitHub(kind string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ghCompletionsMu.Lock()
+		defer ghCompletionsMu.Unlock()
+
+		if cached, ok := ghCompletions[kind]; ok {
+			return cached, cobra.ShellCompDirectiveNoFileComp
+		}
+		var completions []string
+		if github.GHAvailable() {
+			if items, err := github.ListOpen(kind, completionGitHubOptions()); err == nil {
+				completions = formatGitHubCompletions(items)
+			}
+		}
+		ghCompletions[kind] = completions
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionGitHubOptions returns the gh options for the current project's
+// config, or the defaults outside a project
+func completionGitHubOptions() github.Options {
+	projectRoot, _ := git.GetProjectRoot(".")
+	cfg, err := config.LoadWithRepo(config.GetConfigPath(), projectRoot)
+	if err != nil {
+		return github.Options{}
+	}
+	return githubOptions(cfg)
+}
+
+// formatGitHubCompletions renders items as "<number>\t<title>" completions
+func formatGitHubCompletions(items []github.ListItem) []string {
+	completions := make([]string, 0, len(items))
+	for _, item := range items {
+		completions = append(completions, fmt.Sprintf("%d\t%s", item.Number, item.Title))
+	}
+	return completions
+}
+
+// defaultPRBodyTemplate is used when pr_body_template is not set
+const defaultPRBodyTemplate = "{{.Title}}\n\nCloses #{{.Number}}\n"
+
+// writePRBody renders the PR body template for an issue and writes it to
+// path, relative to the worktree. An empty path writes PR_BODY.md into the
+// worktree's git dir so the file is never committed. Returns the file path.
+func writePRBody(worktreePath, path, tmpl string, issue *github.Issue) (string, error) {
+	if tmpl == "" {
+		tmpl = defaultPRBodyTemplate
+	}
+	t, err := template.New("pr_body").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid pr_body_template: %w", err)
+	}
+	var body strings.Builder
+	if err := t.Execute(&body, issue); err != nil {
+		return "", fmt.Errorf("invalid pr_body_template: %w", err)
+	}
+
+	switch {
+	case path == "":
+		gitDir, err := git.WorktreeGitDir(worktreePath)
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(gitDir, "PR_BODY.md")
+	case !filepath.IsAbs(path):
+		path = filepath.Join(worktreePath, path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(body.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write PR body: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,735 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/github"
+	"github.com/spf13/cobra"
+)
+
+func TestLabelSubdir(t *testing.T) {
+	mapping := map[string]string{
+		"bug":     "bugs",
+		"feature": "features",
+	}
+
+	tests := []struct {
+		name     string
+		labels   []string
+		expected string
+	}{
+		{"single match", []string{"bug"}, "bugs"},
+		{"case insensitive", []string{"Feature"}, "features"},
+		{"first mapped label wins", []string{"docs", "feature", "bug"}, "features"},
+		{"no match", []string{"docs", "question"}, ""},
+		{"no labels", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labelSubdir(mapping, tt.labels); got != tt.expected {
+				t.Errorf("labelSubdir(%v) = %q, want %q", tt.labels, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLabelSubdir_NoMapping(t *testing.T) {
+	if got := labelSubdir(nil, []string{"bug"}); got != "" {
+		t.Errorf("expected no subdir without mapping, got %q", got)
+	}
+}
+
+func TestValidateBaseRef(t *testing.T) {
+	dir := initCommandTestRepo(t, "develop")
+	for _, args := range [][]string{
+		{"tag", "v1.0"},
+		{"update-ref", "refs/remotes/origin/staging", "main"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	for _, base := range []string{"main", "develop", "origin/staging", "v1.0", "HEAD"} {
+		if err := validateBaseRef(dir, base, "origin"); err != nil {
+			t.Errorf("validateBaseRef(%q) = %v, want nil", base, err)
+		}
+	}
+
+	err := validateBaseRef(dir, "devlop", "origin")
+	if err == nil || !strings.Contains(err.Error(), "base ref not found: devlop") {
+		t.Errorf("expected not found error for typo, got %v", err)
+	}
+
+	err = validateBaseRef(dir, "staging", "origin")
+	if err == nil || !strings.Contains(err.Error(), "did you mean origin/staging?") {
+		t.Errorf("expected remote branch suggestion, got %v", err)
+	}
+}
+
+func TestResolveIssueBranch(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	addWorktree := func(branch string) string {
+		path := filepath.Join(dir, branch)
+		if out, err := exec.Command("git", "-C", dir, "worktree", "add", path, "-b", branch).CombinedOutput(); err != nil {
+			t.Fatalf("git worktree add failed: %v\n%s", err, out)
+		}
+		return path
+	}
+
+	// Matched by metadata even though the title (and so the name) changed
+	metaPath := addWorktree("issue-42-old-title")
+	if err := git.WriteMetadata(metaPath, git.Metadata{Issue: 42}); err != nil {
+		t.Fatal(err)
+	}
+	branch, existing, err := resolveIssueBranch(dir, git.Metadata{Issue: 42}, "issue-42-new-title")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if existing == nil || existing.Branch != "issue-42-old-title" || branch != "issue-42-old-title" {
+		t.Errorf("expected existing worktree issue-42-old-title, got %q (%+v)", branch, existing)
+	}
+
+	// Matched by the --track-issue description when the metadata is gone
+	addWorktree("issue-9-2026-01-01")
+	if out, err := exec.Command("git", "-C", dir, "config", "branch.issue-9-2026-01-01.description", "Closes #9").CombinedOutput(); err != nil {
+		t.Fatalf("git config failed: %v\n%s", err, out)
+	}
+	branch, existing, err = resolveIssueBranch(dir, git.Metadata{Issue: 9}, "issue-9-2026-02-01")
+	if err != nil || existing == nil || branch != "issue-9-2026-01-01" {
+		t.Errorf("expected existing worktree issue-9-2026-01-01, got %q (%+v, %v)", branch, existing, err)
+	}
+
+	// A worktree on the generated name without metadata is not the issue's:
+	// generated names (e.g. with {{date}}) are not stable enough to match on
+	addWorktree("pr-7-docs")
+	branch, existing, err = resolveIssueBranch(dir, git.Metadata{PR: 7}, "pr-7-docs")
+	if err != nil || existing != nil || branch != "pr-7-docs-2" {
+		t.Errorf("expected new branch pr-7-docs-2, got %q (%+v, %v)", branch, existing, err)
+	}
+
+	// No existing worktree: a fresh name is returned
+	branch, existing, err = resolveIssueBranch(dir, git.Metadata{Issue: 5}, "issue-5-new")
+	if err != nil || existing != nil || branch != "issue-5-new" {
+		t.Errorf("expected new branch issue-5-new, got %q (%+v, %v)", branch, existing, err)
+	}
+}
+
+func TestResolveIssueBranch_Recreate(t *testing.T) {
+	recreateFlag = true
+	t.Cleanup(func() { recreateFlag = false })
+
+	dir := initCommandTestRepo(t)
+	// main is pushed, so the new branch has no commits of its own
+	if out, err := exec.Command("git", "-C", dir, "update-ref", "refs/remotes/origin/main", "main").CombinedOutput(); err != nil {
+		t.Fatalf("git update-ref failed: %v\n%s", err, out)
+	}
+	path := filepath.Join(dir, "issue-42-fix")
+	if out, err := exec.Command("git", "-C", dir, "worktree", "add", path, "-b", "issue-42-fix").CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %v\n%s", err, out)
+	}
+	if err := git.WriteMetadata(path, git.Metadata{Issue: 42}); err != nil {
+		t.Fatal(err)
+	}
+
+	branch, existing, err := resolveIssueBranch(dir, git.Metadata{Issue: 42}, "issue-42-fix")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if existing != nil {
+		t.Errorf("expected existing worktree to be removed, got %+v", existing)
+	}
+	if branch != "issue-42-fix" {
+		t.Errorf("expected branch name to be reused, got %q", branch)
+	}
+	if git.BranchExists(dir, "issue-42-fix") {
+		t.Error("expected old branch to be deleted")
+	}
+}
+
+func TestResolveIssueBranch_RecreateRefusesLostWork(t *testing.T) {
+	recreateFlag = true
+	t.Cleanup(func() { recreateFlag = false })
+
+	dir := initCommandTestRepo(t)
+	run := func(args ...string) {
+		t.Helper()
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	run("update-ref", "refs/remotes/origin/main", "main")
+
+	// Uncommitted changes
+	dirty := filepath.Join(dir, "issue-1-dirty")
+	run("worktree", "add", dirty, "-b", "issue-1-dirty")
+	if err := git.WriteMetadata(dirty, git.Metadata{Issue: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirty, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := resolveIssueBranch(dir, git.Metadata{Issue: 1}, "issue-1-dirty"); err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Errorf("expected uncommitted changes to be refused, got %v", err)
+	}
+
+	// A commit that exists nowhere else
+	unmerged := filepath.Join(dir, "issue-2-unmerged")
+	run("worktree", "add", unmerged, "-b", "issue-2-unmerged")
+	if err := git.WriteMetadata(unmerged, git.Metadata{Issue: 2}); err != nil {
+		t.Fatal(err)
+	}
+	run("-C", unmerged, "commit", "--allow-empty", "-m", "work")
+	if _, _, err := resolveIssueBranch(dir, git.Metadata{Issue: 2}, "issue-2-unmerged"); err == nil || !strings.Contains(err.Error(), "unmerged commit") {
+		t.Errorf("expected unmerged commits to be refused, got %v", err)
+	}
+
+	for _, path := range []string{dirty, unmerged} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to be kept: %v", path, err)
+		}
+	}
+}
+
+func TestLinkedIssueBranch(t *testing.T) {
+	orig := issueLinkedBranches
+	t.Cleanup(func() { issueLinkedBranches = orig })
+
+	dir := initCommandTestRepo(t, "local-only")
+	for _, ref := range []string{"refs/remotes/origin/42-fix-login", "refs/remotes/origin/local-only"} {
+		if out, err := exec.Command("git", "-C", dir, "update-ref", ref, "main").CombinedOutput(); err != nil {
+			t.Fatalf("git update-ref failed: %v\n%s", err, out)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		branches []string
+		err      error
+		want     string
+	}{
+		{"linked branch on remote", []string{"42-fix-login"}, nil, "42-fix-login"},
+		{"skips missing and local-only branches", []string{"gone", "local-only", "42-fix-login"}, nil, "42-fix-login"},
+		{"no linked branch", nil, nil, ""},
+		{"gh failure falls back", nil, errors.New("gh: not found"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issueLinkedBranches = func(int, github.Options) ([]string, error) { return tt.branches, tt.err }
+			if got := linkedIssueBranch(dir, "origin", 42, 5, github.Options{}); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStartCommit(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	path := filepath.Join(dir, "feature")
+	if out, err := exec.Command("git", "-C", dir, "worktree", "add", path, "-b", "feature").CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %v\n%s", err, out)
+	}
+
+	sha := startCommit(path)
+	if !regexp.MustCompile(`^[0-9a-f]{40}$`).MatchString(sha) {
+		t.Errorf("expected 40-char SHA, got %q", sha)
+	}
+
+	if got := startCommit(filepath.Join(dir, "missing")); got != "" {
+		t.Errorf("expected empty SHA for missing worktree, got %q", got)
+	}
+}
+
+func TestCheckDefaultBranch(t *testing.T) {
+	dir := initCommandTestRepo(t, "develop")
+	if out, err := exec.Command("git", "-C", dir, "update-ref", "refs/remotes/origin/main", "main").CombinedOutput(); err != nil {
+		t.Fatalf("git update-ref failed: %v\n%s", err, out)
+	}
+
+	err := checkDefaultBranch(dir, "main")
+	if err == nil || !strings.Contains(err.Error(), "main is the default branch") || !strings.Contains(err.Error(), shortenPath(dir)) {
+		t.Errorf("expected default branch error pointing at %s, got %v", dir, err)
+	}
+	if err := checkDefaultBranch(dir, "develop"); err != nil {
+		t.Errorf("expected no error for other branches, got %v", err)
+	}
+}
+
+func TestTrackingRemote(t *testing.T) {
+	dir := initCommandTestRepo(t, "develop")
+	for _, args := range [][]string{
+		{"remote", "add", "upstream", "https://example.com/upstream.git"},
+		{"update-ref", "refs/remotes/upstream/feature", "main"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	tests := []struct {
+		base     string
+		expected string
+	}{
+		{"upstream/feature", "upstream"},
+		{"refs/remotes/upstream/feature", "upstream"},
+		{"upstream/missing", "origin"},
+		{"develop", "origin"},
+		{"", "origin"},
+	}
+	for _, tt := range tests {
+		if got := trackingRemote(dir, tt.base, "origin"); got != tt.expected {
+			t.Errorf("trackingRemote(%q) = %q, want %q", tt.base, got, tt.expected)
+		}
+	}
+
+	// Tracking for a branch off upstream/feature is set against upstream
+	path := filepath.Join(dir, "topic")
+	if out, err := exec.Command("git", "-C", dir, "worktree", "add", path, "-b", "topic", "upstream/feature").CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %v\n%s", err, out)
+	}
+	if _, err := git.SetUpstreamOnPush(path, "topic", trackingRemote(dir, "upstream/feature", "origin")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if remote, err := git.GetLocalConfig(path, "branch.topic.pushRemote"); err != nil || remote != "upstream" {
+		t.Errorf("expected pushRemote upstream, got %q (%v)", remote, err)
+	}
+}
+
+func TestFormatGitHubCompletions(t *testing.T) {
+	items := []github.ListItem{{Number: 42, Title: "Fix login"}, {Number: 7, Title: "Add docs"}}
+	got := formatGitHubCompletions(items)
+	expected := []string{"42\tFix login", "7\tAdd docs"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("completion %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+
+	if got := formatGitHubCompletions(nil); len(got) != 0 {
+		t.Errorf("expected no completions, got %v", got)
+	}
+}
+
+func TestCompleteGitHub_Cached(t *testing.T) {
+	ghCompletions["issue"] = []string{"1\tcached"}
+	t.Cleanup(func() { delete(ghCompletions, "issue") })
+
+	got, directive := completeGitHub("issue")(newCmd, nil, "")
+	if len(got) != 1 || got[0] != "1\tcached" {
+		t.Errorf("expected cached completions, got %v", got)
+	}
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("expected NoFileComp directive, got %v", directive)
+	}
+}
+
+func TestWritePRBody(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	issue := &github.Issue{Number: 42, Title: "Fix login redirect", URL: "https://github.com/o/r/issues/42"}
+
+	path, err := writePRBody(dir, "", "", issue)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := filepath.Join(dir, ".git", "PR_BODY.md"); path != want {
+		t.Errorf("expected default path %s, got %s", want, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); !strings.Contains(got, "Closes #42") || !strings.Contains(got, "Fix login redirect") {
+		t.Errorf("expected issue reference and title, got %q", got)
+	}
+
+	path, err = writePRBody(dir, "docs/pr.md", "Fixes {{.URL}}", issue)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, err = os.ReadFile(filepath.Join(dir, "docs", "pr.md"))
+	if err != nil {
+		t.Fatalf("expected file at configured path %s: %v", path, err)
+	}
+	if string(data) != "Fixes https://github.com/o/r/issues/42" {
+		t.Errorf("unexpected body %q", data)
+	}
+
+	if _, err := writePRBody(dir, "", "{{.Nope", issue); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
+
+func TestDetachedHeadBase(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	detached := filepath.Join(t.TempDir(), "detached")
+	for _, args := range [][]string{
+		{"worktree", "add", "--detach", detached},
+		{"update-ref", "refs/remotes/origin/main", "main"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	cfg := config.DefaultConfig()
+
+	// Invoked from a worktree on a branch, nothing changes
+	if base, err := detachedHeadBase(dir, dir, cfg); err != nil || base != "" {
+		t.Errorf("expected no fallback on a branch, got %q (%v)", base, err)
+	}
+
+	base, err := detachedHeadBase(dir, detached, cfg)
+	if err != nil || base != "origin/main" {
+		t.Errorf("expected origin/main for detached HEAD, got %q (%v)", base, err)
+	}
+
+	cfg.DetachedHead = DetachedHeadRequire
+	if _, err := detachedHeadBase(dir, detached, cfg); err == nil || !strings.Contains(err.Error(), "--base") {
+		t.Errorf("expected --base to be required, got %v", err)
+	}
+
+	cfg.DetachedHead = "sometimes"
+	if _, err := detachedHeadBase(dir, detached, cfg); err == nil {
+		t.Error("expected error for invalid detached_head")
+	}
+}
+
+func TestDetectForge(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	cfg := config.DefaultConfig()
+
+	// No remote configured: GitHub, as before
+	if forge, err := detectForge(dir, cfg); err != nil || forge != ForgeGitHub {
+		t.Errorf("expected github without a remote, got %q (%v)", forge, err)
+	}
+
+	if out, err := exec.Command("git", "-C", dir, "remote", "add", "origin", "git@gitlab.com:acme/app.git").CombinedOutput(); err != nil {
+		t.Fatalf("git remote add failed: %v\n%s", err, out)
+	}
+	if forge, err := detectForge(dir, cfg); err != nil || forge != ForgeGitLab {
+		t.Errorf("expected gitlab from the remote URL, got %q (%v)", forge, err)
+	}
+
+	// The config option wins over the remote URL
+	cfg.Forge = ForgeGitHub
+	if forge, err := detectForge(dir, cfg); err != nil || forge != ForgeGitHub {
+		t.Errorf("expected forge config to win, got %q (%v)", forge, err)
+	}
+
+	cfg.Forge = "bitbucket"
+	if _, err := detectForge(dir, cfg); err == nil {
+		t.Error("expected error for invalid forge")
+	}
+}
+
+func TestSetupHooksPath(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	worktree := filepath.Join(t.TempDir(), "feature")
+	if out, err := exec.Command("git", "-C", dir, "worktree", "add", worktree, "-b", "feature").CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %v\n%s", err, out)
+	}
+
+	// Missing hooks directories are reported, but the config is applied
+	hooksDir, err := setupHooksPath(worktree, ".githooks")
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected missing directory error, got %v", err)
+	}
+	if want := filepath.Join(worktree, ".githooks"); hooksDir != want {
+		t.Errorf("expected hooks dir %s, got %s", want, hooksDir)
+	}
+	if value, err := git.GetLocalConfig(worktree, "core.hooksPath"); err != nil || value != ".githooks" {
+		t.Errorf("expected core.hooksPath in the new worktree, got %q (%v)", value, err)
+	}
+	// Scoped to the worktree, not the shared repository config
+	if value, _ := git.GetLocalConfig(dir, "core.hooksPath"); value != "" {
+		t.Errorf("expected main worktree to be unaffected, got %q", value)
+	}
+
+	if err := os.Mkdir(filepath.Join(worktree, ".githooks"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := setupHooksPath(worktree, ""); err != nil {
+		t.Errorf("expected existing hooks directory to verify, got %v", err)
+	}
+}
+
+func TestValidatePublishFlags(t *testing.T) {
+	t.Cleanup(func() { draftFlag, createPRFlag, prNum = false, false, 0 })
+
+	draftFlag = true
+	if err := validatePublishFlags(); err == nil {
+		t.Error("expected --draft without --create-pr to be rejected")
+	}
+
+	createPRFlag = true
+	if err := validatePublishFlags(); err != nil {
+		t.Errorf("expected --create-pr --draft to be accepted, got %v", err)
+	}
+
+	prNum = 7
+	if err := validatePublishFlags(); err == nil {
+		t.Error("expected --create-pr with --pr to be rejected")
+	}
+}
+
+func TestValidateCheckoutFlag(t *testing.T) {
+	t.Cleanup(func() { checkoutFlag, baseFlag, issueNum = false, "", 0 })
+
+	baseFlag = "develop"
+	if err := validateCheckoutFlag(); err != nil {
+		t.Errorf("expected --base without --checkout to be accepted, got %v", err)
+	}
+
+	checkoutFlag = true
+	if err := validateCheckoutFlag(); err == nil {
+		t.Error("expected --checkout with --base to be rejected")
+	}
+
+	baseFlag, issueNum = "", 42
+	if err := validateCheckoutFlag(); err == nil {
+		t.Error("expected --checkout with --issue to be rejected")
+	}
+}
+
+func TestValidateCheckoutPRFlag(t *testing.T) {
+	t.Cleanup(func() { checkoutPRFlag, prNum, mrNum, baseFlag = false, 0, 0, "" })
+
+	checkoutPRFlag = true
+	if err := validateCheckoutPRFlag(); err == nil {
+		t.Error("expected --checkout-pr without --pr to be rejected")
+	}
+
+	prNum = 12
+	if err := validateCheckoutPRFlag(); err != nil {
+		t.Errorf("expected --pr --checkout-pr to be accepted, got %v", err)
+	}
+
+	baseFlag = "develop"
+	if err := validateCheckoutPRFlag(); err == nil {
+		t.Error("expected --checkout-pr with --base to be rejected")
+	}
+
+	prNum, mrNum, baseFlag = 0, 12, ""
+	if err := validateCheckoutPRFlag(); err == nil {
+		t.Error("expected --checkout-pr with a merge request to be rejected")
+	}
+}
+
+func TestCheckoutPullRequest(t *testing.T) {
+	src := initCommandTestRepo(t, "feature")
+	for _, args := range [][]string{
+		{"commit", "--allow-empty", "-m", "fix from fork"},
+		{"update-ref", "refs/pull/9/head", "HEAD"},
+		{"reset", "--hard", "HEAD~1"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", src}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	projectRoot := filepath.Join(t.TempDir(), ".bare")
+	if out, err := exec.Command("git", "clone", "--bare", src, projectRoot).CombinedOutput(); err != nil {
+		t.Fatalf("git clone failed: %v\n%s", err, out)
+	}
+	worktreeOpts := func() git.WorktreeOptions {
+		return git.WorktreeOptions{NoRelativePaths: true, Path: filepath.Join(t.TempDir(), "wt")}
+	}
+
+	// A PR from a branch on the remote checks out that branch
+	samePR := &github.PullRequest{Number: 8, HeadRefName: "feature"}
+	path, ref, _, err := checkoutPullRequest(projectRoot, "origin", samePR, prHeadBranch(samePR), worktreeOpts(), 30)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ref != "origin/feature" {
+		t.Errorf("expected origin/feature to be checked out, got %q", ref)
+	}
+	if wt, _ := git.FindWorktreeForBranch(projectRoot, "feature"); wt == nil || wt.Path != path {
+		t.Errorf("expected a worktree for feature at %s, got %+v", path, wt)
+	}
+
+	// A PR from a fork is fetched from refs/pull/<n>/head
+	forkPR := &github.PullRequest{Number: 9, HeadRefName: "main", IsCrossRepository: true, HeadRepositoryOwner: github.Author{Login: "alice"}}
+	branch := prHeadBranch(forkPR)
+	if branch != "alice/main" {
+		t.Fatalf("expected fork branch alice/main, got %q", branch)
+	}
+	path, ref, tracking, err := checkoutPullRequest(projectRoot, "origin", forkPR, branch, worktreeOpts(), 30)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ref != "refs/pull/9/head" || tracking != "origin refs/pull/9/head" {
+		t.Errorf("expected the PR ref to be checked out and tracked, got %q (tracking %q)", ref, tracking)
+	}
+	want, _ := git.ResolveRef(src, "refs/pull/9/head")
+	if got, _ := git.ResolveRef(path, "HEAD"); got != want {
+		t.Errorf("expected worktree at the PR head %s, got %s", want, got)
+	}
+}
+
+func TestValidateDetachedFlags(t *testing.T) {
+	t.Cleanup(func() { tagFlag, commitFlag, pushFlag = "", "", false })
+
+	tagFlag = "v1.2.3"
+	if err := validateDetachedFlags(nil); err != nil {
+		t.Errorf("expected --tag alone to be accepted, got %v", err)
+	}
+	if err := validateDetachedFlags([]string{"feature"}); err == nil {
+		t.Error("expected --tag with a branch to be rejected")
+	}
+
+	pushFlag = true
+	if err := validateDetachedFlags(nil); err == nil {
+		t.Error("expected --tag with --push to be rejected")
+	}
+
+	pushFlag, commitFlag = false, "abc1234"
+	if err := validateDetachedFlags(nil); err == nil {
+		t.Error("expected --tag with --commit to be rejected")
+	}
+}
+
+func TestCheckoutCandidates(t *testing.T) {
+	dir := initCommandTestRepo(t, "local", "busy")
+	for _, args := range [][]string{
+		{"remote", "add", "origin", dir},
+		{"update-ref", "refs/remotes/origin/local", "HEAD"},
+		{"update-ref", "refs/remotes/origin/shared", "HEAD"},
+		{"worktree", "add", filepath.Join(dir, "busy"), "busy"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	got := checkoutCandidates(dir, "origin")
+	if want := []string{"local", "shared"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestPublishNewBranch(t *testing.T) {
+	origPush, origCreate := pushNewBranch, createPullRequest
+	t.Cleanup(func() {
+		pushNewBranch, createPullRequest = origPush, origCreate
+		createPRFlag, draftFlag = false, false
+	})
+
+	var pushed []string
+	var prOpts []github.PRCreateOptions
+	pushNewBranch = func(dir, remote, branch string, timeout int) error {
+		pushed = append(pushed, remote+"/"+branch)
+		return nil
+	}
+	createPullRequest = func(dir string, opts github.PRCreateOptions) (string, error) {
+		prOpts = append(prOpts, opts)
+		return "https://github.com/o/r/pull/9", nil
+	}
+	issue := &github.Issue{Number: 42, Title: "Fix login"}
+
+	// --push alone never calls gh
+	result := publishNewBranch("/wt", "origin", "issue-42-fix-login", issue, "", 60, github.Options{})
+	if !result.Pushed || result.PRURL != "" || len(prOpts) != 0 {
+		t.Errorf("expected push only, got %+v (gh calls: %d)", result, len(prOpts))
+	}
+
+	// --create-pr --draft pushes, then opens a draft PR titled after the issue
+	createPRFlag, draftFlag = true, true
+	result = publishNewBranch("/wt", "origin", "issue-42-fix-login", issue, "", 60, github.Options{})
+	if !result.Pushed || result.PRURL != "https://github.com/o/r/pull/9" {
+		t.Errorf("expected pushed branch and PR URL, got %+v", result)
+	}
+	want := github.PRCreateOptions{Title: "Fix login", Body: "Closes #42", Draft: true}
+	if len(prOpts) != 1 || prOpts[0] != want {
+		t.Errorf("expected %+v, got %+v", want, prOpts)
+	}
+	if len(pushed) != 2 || pushed[1] != "origin/issue-42-fix-login" {
+		t.Errorf("unexpected pushes: %v", pushed)
+	}
+
+	// Without an issue the PR is filled from the commits
+	draftFlag = false
+	publishNewBranch("/wt", "origin", "feature", nil, "", 60, github.Options{})
+	if last := prOpts[len(prOpts)-1]; last != (github.PRCreateOptions{}) {
+		t.Errorf("expected --fill options, got %+v", last)
+	}
+
+	// A gh failure is a warning; the push still counts
+	createPullRequest = func(string, github.PRCreateOptions) (string, error) {
+		return "", errors.New("failed to create PR: no commits between main and feature")
+	}
+	result = publishNewBranch("/wt", "origin", "feature", nil, "", 60, github.Options{})
+	if !result.Pushed || result.PRURL != "" || len(result.Warnings) != 1 {
+		t.Errorf("expected a warning and no URL, got %+v", result)
+	}
+
+	// A failed push skips the PR
+	pushNewBranch = func(string, string, string, int) error { return errors.New("failed to push feature") }
+	result = publishNewBranch("/wt", "origin", "feature", nil, "", 60, github.Options{})
+	if result.Pushed || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "push") {
+		t.Errorf("expected push failure only, got %+v", result)
+	}
+}
+
+func TestRunNew_DefaultBaseBranch(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	projectRoot := initDoctorTestProject(t)
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", projectRoot}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("-C", filepath.Join(projectRoot, "main"), "commit", "-q", "--allow-empty", "-m", "develop only")
+	run("branch", "develop", "main")
+	run("-C", filepath.Join(projectRoot, "main"), "reset", "-q", "--hard", "HEAD~1")
+	if err := os.WriteFile(config.GetRepoConfigPath(projectRoot), []byte("default_base_branch = \"develop\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(filepath.Join(projectRoot, "main")); err != nil {
+		t.Fatal(err)
+	}
+	jsonOutputFlag, noRelativePaths = true, true
+	stdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		_ = devNull.Close()
+		jsonOutputFlag, noRelativePaths, baseFlag = false, false, ""
+	})
+
+	// Without --base, the new branch starts from default_base_branch
+	if err := runNew(newCmd, []string{"from-default"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got, want := run("rev-parse", "from-default"), run("rev-parse", "develop"); got != want {
+		t.Errorf("expected from-default at develop (%s), got %s", want, got)
+	}
+
+	// --base wins over default_base_branch
+	baseFlag = "main"
+	if err := runNew(newCmd, []string{"from-base"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got, want := run("rev-parse", "from-base"), run("rev-parse", "main"); got != want {
+		t.Errorf("expected from-base at main (%s), got %s", want, got)
+	}
+}
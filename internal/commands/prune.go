@@ -3,10 +3,12 @@ package commands
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/charmbracelet/huh"
 	"github.com/raisedadead/git-wt/internal/config"
 	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/hooks"
 	"github.com/raisedadead/git-wt/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -14,42 +16,151 @@ import (
 // PruneData represents the JSON output for the prune command
 type PruneData struct {
 	StaleWorktrees []StaleWorktreeInfo `json:"stale_worktrees"`
+	Skipped        []StaleWorktreeInfo `json:"skipped,omitempty"`
 	Removed        int                 `json:"removed"`
+	ReclaimedBytes int64               `json:"reclaimed_bytes"`
 	DryRun         bool                `json:"dry_run,omitempty"`
+	FetchSkipped   bool                `json:"fetch_skipped,omitempty"`
+	DurationMs     int64               `json:"duration_ms"`
 }
 
 // StaleWorktreeInfo represents info about a stale worktree
 type StaleWorktreeInfo struct {
-	Branch  string `json:"branch"`
-	Path    string `json:"path"`
-	Reason  string `json:"reason"`
-	Removed bool   `json:"removed,omitempty"`
+	Branch     string             `json:"branch"`
+	Path       string             `json:"path"`
+	Reason     string             `json:"reason"`
+	ReasonCode string             `json:"reason_code"`
+	Removed    bool               `json:"removed,omitempty"`
+	SizeBytes  int64              `json:"size_bytes,omitempty"`
+	RenamedTo  string             `json:"renamed_to,omitempty"`
+	Hooks      []hooks.HookResult `json:"hooks,omitempty"`
+}
+
+// Stable reason codes for StaleWorktreeInfo.ReasonCode
+const (
+	ReasonRemoteDeleted = "remote_deleted"
+	ReasonMerged        = "merged"
+	ReasonUnreachable   = "unreachable"
+	ReasonInactive      = "inactive"
+	ReasonUnpushed      = "unpushed"
+	ReasonRenamed       = "possibly_renamed"
+	ReasonDirty         = "uncommitted_changes"
+	ReasonLocked        = "locked"
+	ReasonUnknown       = "unknown"
+)
+
+// reasonText holds the human-readable reason for each reason code
+var reasonText = map[string]string{
+	ReasonRemoteDeleted: "branch deleted on remote",
+	ReasonMerged:        "branch merged",
+	ReasonUnreachable:   "worktree directory missing",
+	ReasonInactive:      "no recent commits",
+	ReasonUnpushed:      "has unpushed commits",
+	ReasonRenamed:       "possibly renamed on remote",
+	ReasonDirty:         "has uncommitted changes",
+	ReasonLocked:        "locked",
+	ReasonUnknown:       "could not check for unpushed commits",
+}
+
+// pruneFetch refreshes remote-tracking refs before staleness detection
+var pruneFetch = func(projectRoot string, timeout int) error {
+	_, err := git.RunInDirWithTimeout(projectRoot, timeout, "fetch", "--prune")
+	return err
+}
+
+// fetchBeforePrune fetches unless skip is set. A failed fetch only warns,
+// since staleness can still be judged from the local remote-tracking refs.
+func fetchBeforePrune(projectRoot string, timeout int, skip bool) {
+	if skip {
+		if !IsJSONOutput() {
+			fmt.Println(ui.SubtleStyle.Render("Skipping fetch (using local remote-tracking refs)"))
+		}
+		return
+	}
+	if !IsJSONOutput() {
+		fmt.Println(ui.SubtleStyle.Render("Fetching remote..."))
+	}
+	if err := pruneFetch(projectRoot, timeout); err != nil {
+		if !IsJSONOutput() {
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("Failed to fetch remote: %v (continuing with local state)", err)))
+		}
+	}
+}
+
+// newStaleInfo describes a worktree with the given reason code
+func newStaleInfo(wt git.Worktree, code string) StaleWorktreeInfo {
+	return StaleWorktreeInfo{
+		Branch:     wt.Branch,
+		Path:       wt.Path,
+		Reason:     reasonText[code],
+		ReasonCode: code,
+	}
 }
 
 var (
 	dryRunPrune      bool
 	yesPrune         bool
+	forcePrune       bool
+	interactivePrune bool
 	pruneRemoteFlag  string
 	pruneTimeoutFlag int
+	noFetchPrune     bool
+	pruneMergedBase  string
+	pruneDays        int
 )
 
+// mergedIntoDefault is the --merged value when no base is given, standing for
+// the project's default branch
+const mergedIntoDefault = "<default>"
+
 var pruneCmd = &cobra.Command{
 	Use:   "prune",
 	Short: "Remove stale worktrees",
 	Long: `Remove worktrees whose branches have been deleted on remote or whose
-directories no longer exist.`,
-	RunE: runPrune,
+directories no longer exist.
+
+With --merged, also remove worktrees whose branches are fully merged into the
+default branch (or --merged=<base>), even if the remote branch still exists.
+The base must be joined with "=": "--merged main" is read as --merged plus an
+argument.
+With --days N, also remove worktrees with no commits or changes to their
+directory in N days, e.g. abandoned experiments (add --force for ones never
+pushed).`,
+	Args:    pruneArgs,
+	PreRunE: commandDefaults("prune"),
+	RunE:    runPrune,
+}
+
+// pruneArgs rejects arguments, pointing "--merged <base>" at the
+// --merged=<base> form it was meant as
+func pruneArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && pruneMergedBase == mergedIntoDefault {
+		return fmt.Errorf("unexpected argument %q (use --merged=%s to set the base branch)", args[0], args[0])
+	}
+	return cobra.NoArgs(cmd, args)
 }
 
 func init() {
 	pruneCmd.Flags().BoolVar(&dryRunPrune, "dry-run", false, "Show what would be pruned without pruning")
 	pruneCmd.Flags().BoolVarP(&yesPrune, "yes", "y", false, "Skip confirmation prompt")
+	pruneCmd.Flags().BoolVarP(&forcePrune, "force", "f", false, "Also remove worktrees whose branches have unpushed commits")
+	pruneCmd.Flags().BoolVarP(&interactivePrune, "interactive", "i", false, "Choose which stale worktrees to remove")
 	pruneCmd.Flags().StringVar(&pruneRemoteFlag, "remote", "", "Override default remote")
 	pruneCmd.Flags().IntVar(&pruneTimeoutFlag, "timeout", 0, "Override git operation timeout (seconds)")
+	pruneCmd.Flags().BoolVar(&noFetchPrune, "no-fetch", false, "Skip fetching and use the existing remote-tracking refs")
+	pruneCmd.Flags().StringVar(&pruneMergedBase, "merged", "", "Also prune branches merged into the default branch (or --merged=<base>; the = is required)")
+	pruneCmd.Flags().Lookup("merged").NoOptDefVal = mergedIntoDefault
+	pruneCmd.Flags().IntVar(&pruneDays, "days", 0, "Also prune worktrees inactive for this many days")
 	rootCmd.AddCommand(pruneCmd)
 }
 
 func runPrune(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+
+	if interactivePrune && IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "prune", nil, ui.NewCLIError(ui.ErrCodeValidation, "--interactive cannot be used with --json"))
+	}
+
 	// Find project root
 	projectRoot, err := git.GetProjectRoot(".")
 	if err != nil {
@@ -77,14 +188,7 @@ func runPrune(cmd *cobra.Command, args []string) error {
 	}
 
 	// Fetch to get latest remote state
-	if !IsJSONOutput() {
-		fmt.Println(ui.SubtleStyle.Render("Fetching remote..."))
-	}
-	if _, err := git.RunInDirWithTimeout(projectRoot, cfg.GitTimeout, "fetch", "--prune"); err != nil {
-		if !IsJSONOutput() {
-			fmt.Println(ui.WarningMsg(fmt.Sprintf("Failed to fetch remote: %v (continuing with local state)", err)))
-		}
-	}
+	fetchBeforePrune(projectRoot, cfg.GitTimeout, noFetchPrune)
 
 	// List worktrees
 	worktrees, err := git.ListWorktrees(projectRoot)
@@ -95,24 +199,26 @@ func runPrune(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Find stale worktrees (branch deleted on remote)
-	var stale []git.Worktree
-	var staleInfos []StaleWorktreeInfo
-	for _, wt := range worktrees {
-		// Skip main/master
-		if wt.Branch == git.DefaultBranch || wt.Branch == git.FallbackBranch {
-			continue
+	// Merged branches are judged against the default branch unless given
+	mergeBase := pruneMergedBase
+	if mergeBase == mergedIntoDefault {
+		if mergeBase, err = git.GetDefaultBranch(projectRoot); err != nil {
+			mergeBase = git.DefaultBranch
 		}
+	}
 
-		// Check if branch exists on remote
-		_, err := git.RunInDirWithTimeout(projectRoot, cfg.GitTimeout, "rev-parse", "--verify", fmt.Sprintf("refs/remotes/%s/%s", cfg.DefaultRemote, wt.Branch))
-		if err != nil {
-			stale = append(stale, wt)
-			staleInfos = append(staleInfos, StaleWorktreeInfo{
-				Branch: wt.Branch,
-				Path:   wt.Path,
-				Reason: "branch deleted on remote",
-			})
+	// Find stale worktrees
+	criteria := staleCriteria{mergeBase: mergeBase, inactiveDays: pruneDays}
+	stale, staleInfos, skippedInfos, err := detectStaleWorktrees(projectRoot, cfg, worktrees, criteria)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "prune", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+	if !IsJSONOutput() {
+		for _, info := range skippedInfos {
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("Skipping %s: %s (use --force to remove)", info.Branch, info.Reason)))
 		}
 	}
 
@@ -120,7 +226,10 @@ func runPrune(cmd *cobra.Command, args []string) error {
 		if IsJSONOutput() {
 			data := PruneData{
 				StaleWorktrees: []StaleWorktreeInfo{},
+				Skipped:        skippedInfos,
 				Removed:        0,
+				FetchSkipped:   noFetchPrune,
+				DurationMs:     time.Since(start).Milliseconds(),
 			}
 			return ui.OutputJSON(os.Stdout, "prune", data, nil)
 		}
@@ -133,38 +242,36 @@ func runPrune(cmd *cobra.Command, args []string) error {
 		if IsJSONOutput() {
 			data := PruneData{
 				StaleWorktrees: staleInfos,
+				Skipped:        skippedInfos,
 				Removed:        0,
 				DryRun:         true,
+				FetchSkipped:   noFetchPrune,
+				DurationMs:     time.Since(start).Milliseconds(),
 			}
 			return ui.OutputJSON(os.Stdout, "prune", data, nil)
 		}
-		fmt.Printf("Found %d stale worktrees:\n", len(stale))
-		for _, wt := range stale {
-			fmt.Println("  • " + wt.Branch + ui.SubtleStyle.Render(" (branch deleted on remote)"))
-		}
-		fmt.Println()
+		printStaleWorktrees(staleInfos)
 		fmt.Println(ui.InfoMsg("Dry run - no changes made"))
 		return nil
 	}
 
 	// Show stale worktrees (always show in non-JSON mode)
 	if !IsJSONOutput() {
-		fmt.Printf("Found %d stale worktrees:\n", len(stale))
-		for _, wt := range stale {
-			fmt.Println("  • " + wt.Branch + ui.SubtleStyle.Render(" (branch deleted on remote)"))
-		}
-		fmt.Println()
+		printStaleWorktrees(staleInfos)
 	}
 
 	// Confirmation prompt (skip with --yes or --json)
-	if !yesPrune && !IsJSONOutput() {
-		var action string
+	action := "all"
+	if interactivePrune {
+		action = "select"
+	} else if !yesPrune && !IsJSONOutput() {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewSelect[string]().
 					Title("Remove these?").
 					Options(
 						huh.NewOption("Yes, remove all", "all"),
+						huh.NewOption("Choose which to remove", "select"),
 						huh.NewOption("Cancel", "cancel"),
 					).
 					Value(&action),
@@ -181,10 +288,40 @@ func runPrune(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if action == "select" {
+		stale, staleInfos, err = selectStaleWorktrees(stale, staleInfos, promptStaleSelection)
+		if err != nil {
+			return err
+		}
+		if len(stale) == 0 {
+			fmt.Println("Nothing selected.")
+			return nil
+		}
+	}
+
 	// Remove stale worktrees
 	removed := 0
 	for i, wt := range stale {
-		if err := git.RemoveWorktreeForce(projectRoot, wt.Path); err != nil {
+		// Measure before removal; a failed measurement only affects the total
+		if staleInfos[i].ReasonCode != ReasonUnreachable {
+			if size, err := git.WorktreeSize(wt.Path); err != nil {
+				if !IsJSONOutput() {
+					fmt.Println(ui.WarningMsg(fmt.Sprintf("Could not measure %s: %v", wt.Branch, err)))
+				}
+			} else {
+				staleInfos[i].SizeBytes = size
+			}
+		}
+
+		// Missing directories only need their admin entry pruned
+		if staleInfos[i].ReasonCode == ReasonUnreachable {
+			if err := git.PruneWorktrees(projectRoot); err != nil {
+				if !IsJSONOutput() {
+					fmt.Println(ui.WarningMsg(fmt.Sprintf("Failed to remove %s: %v", wt.Branch, err)))
+				}
+				continue
+			}
+		} else if err := git.RemoveWorktreeForce(projectRoot, wt.Path); err != nil {
 			if !IsJSONOutput() {
 				fmt.Println(ui.WarningMsg(fmt.Sprintf("Failed to remove %s: %v", wt.Branch, err)))
 			}
@@ -198,6 +335,7 @@ func runPrune(cmd *cobra.Command, args []string) error {
 		}
 
 		staleInfos[i].Removed = true
+		staleInfos[i].Hooks = runRemovalHooks(cfg.Hooks.PostPrune, projectRoot, wt.Path, wt.Branch, cfg.HookTimeout)
 		removed++
 	}
 
@@ -211,12 +349,231 @@ func runPrune(cmd *cobra.Command, args []string) error {
 	if IsJSONOutput() {
 		data := PruneData{
 			StaleWorktrees: staleInfos,
+			Skipped:        skippedInfos,
 			Removed:        removed,
+			ReclaimedBytes: reclaimedBytes(staleInfos),
+			FetchSkipped:   noFetchPrune,
+			DurationMs:     time.Since(start).Milliseconds(),
 		}
 		return ui.OutputJSON(os.Stdout, "prune", data, nil)
 	}
 
 	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Removed %d stale worktrees", removed)))
+	if reclaimed := reclaimedBytes(staleInfos); reclaimed > 0 {
+		fmt.Println(ui.SubtleStyle.Render("Reclaimed " + formatBytes(reclaimed)))
+	}
 
 	return nil
 }
+
+// staleCriteria are the optional prune checks beyond remote state
+type staleCriteria struct {
+	mergeBase    string // Branches fully merged into this are stale (--merged)
+	inactiveDays int    // Worktrees idle for this many days are stale (--days)
+}
+
+// inactive reports whether a worktree has been idle for at least
+// inactiveDays, and for how many days. Activity is the later of the last
+// commit and the directory's modification time.
+func (c staleCriteria) inactive(worktreePath string, now time.Time) (int, bool) {
+	if c.inactiveDays <= 0 {
+		return 0, false
+	}
+	last, _ := git.GetLastCommitTime(worktreePath)
+	if info, err := os.Stat(worktreePath); err == nil && info.ModTime().After(last) {
+		last = info.ModTime()
+	}
+	if last.IsZero() {
+		return 0, false
+	}
+	days := int(now.Sub(last).Hours() / 24)
+	return days, days >= c.inactiveDays
+}
+
+// detectStaleWorktrees classifies worktrees as stale (with a reason code) or
+// skipped because removing them would lose unpushed or uncommitted work.
+// Besides missing directories and remote-deleted branches, criteria can mark
+// merged and inactive worktrees as stale.
+func detectStaleWorktrees(projectRoot string, cfg *config.Config, worktrees []git.Worktree, criteria staleCriteria) ([]git.Worktree, []StaleWorktreeInfo, []StaleWorktreeInfo, error) {
+	var stale []git.Worktree
+	var staleInfos []StaleWorktreeInfo
+	var skippedInfos []StaleWorktreeInfo
+
+	var merged map[string]bool
+	if criteria.mergeBase != "" {
+		var err error
+		if merged, err = git.MergedBranches(projectRoot, criteria.mergeBase); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	now := time.Now()
+
+	// The project's default branch may be neither main nor master
+	defaultBranch, _ := git.GetDefaultBranch(projectRoot)
+
+	for _, wt := range worktrees {
+		// Skip the default branch (and main/master as a safety net), the
+		// merge base and the bare repo itself
+		switch wt.Branch {
+		case "", defaultBranch, git.DefaultBranch, git.FallbackBranch, criteria.mergeBase:
+			continue
+		}
+
+		// The --merged and --days criteria are checked before the remote
+		// ref, which a never-pushed branch lacks without having been deleted
+		var code, reason string
+		if _, err := os.Stat(wt.Path); os.IsNotExist(err) {
+			code = ReasonUnreachable
+		} else if merged[wt.Branch] {
+			code, reason = ReasonMerged, "merged into "+criteria.mergeBase
+		} else if days, ok := criteria.inactive(wt.Path, now); ok {
+			code, reason = ReasonInactive, fmt.Sprintf("stale for %d days", days)
+		} else if _, err := git.RunInDirWithTimeout(projectRoot, cfg.GitTimeout, "rev-parse", "--verify", fmt.Sprintf("refs/remotes/%s/%s", cfg.DefaultRemote, wt.Branch)); err != nil {
+			code = ReasonRemoteDeleted
+		} else {
+			continue
+		}
+
+		// A lock means "keep this", even with --force (git refuses too)
+		if wt.Locked {
+			info := newStaleInfo(wt, ReasonLocked)
+			info.Reason = lockedText(wt.LockReason)
+			skippedInfos = append(skippedInfos, info)
+			continue
+		}
+
+		// A vanished tracking ref may just mean the remote branch was renamed
+		if code == ReasonRemoteDeleted && !forcePrune {
+			if renamed, err := git.FindRenamedRemoteBranch(projectRoot, cfg.DefaultRemote, wt.Branch, wt.Commit); err == nil && renamed != "" {
+				info := newStaleInfo(wt, ReasonRenamed)
+				info.RenamedTo = cfg.DefaultRemote + "/" + renamed
+				info.Reason = fmt.Sprintf("%s (to %s?)", info.Reason, info.RenamedTo)
+				skippedInfos = append(skippedInfos, info)
+				continue
+			}
+		}
+
+		// Merged and inactive worktrees may still be in use; the remote
+		// branch existing says nothing about local edits
+		if (code == ReasonMerged || code == ReasonInactive) && !forcePrune {
+			if status, err := git.GetWorktreeStatus(wt.Path); err != nil || !status.IsClean() {
+				skippedInfos = append(skippedInfos, newStaleInfo(wt, ReasonDirty))
+				continue
+			}
+		}
+
+		// Never force-delete a branch whose commits exist nowhere else;
+		// merged commits live on in the base branch. If they cannot be
+		// counted, keep the worktree rather than risk losing them.
+		if code != ReasonMerged && !forcePrune {
+			unpushed, err := git.CountUnpushedCommits(projectRoot, wt.Branch)
+			if err != nil {
+				info := newStaleInfo(wt, ReasonUnknown)
+				info.Reason = fmt.Sprintf("%s: %v", info.Reason, err)
+				skippedInfos = append(skippedInfos, info)
+				continue
+			}
+			if unpushed > 0 {
+				skippedInfos = append(skippedInfos, newStaleInfo(wt, ReasonUnpushed))
+				continue
+			}
+		}
+
+		info := newStaleInfo(wt, code)
+		if reason != "" {
+			info.Reason = reason
+		}
+		stale = append(stale, wt)
+		staleInfos = append(staleInfos, info)
+	}
+
+	return stale, staleInfos, skippedInfos, nil
+}
+
+// reclaimedBytes sums the measured sizes of the worktrees that were removed
+func reclaimedBytes(infos []StaleWorktreeInfo) int64 {
+	var total int64
+	for _, info := range infos {
+		if info.Removed {
+			total += info.SizeBytes
+		}
+	}
+	return total
+}
+
+// formatBytes renders a byte count with a decimal unit, e.g. "1.2 GB"
+func formatBytes(n int64) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n)
+	units := []string{"kB", "MB", "GB", "TB"}
+	unit := ""
+	for _, u := range units {
+		value /= 1000
+		unit = u
+		if value < 1000 {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f %s", value, unit)
+}
+
+// printStaleWorktrees lists stale worktrees with their reasons
+func printStaleWorktrees(infos []StaleWorktreeInfo) {
+	fmt.Printf("Found %d stale worktrees:\n", len(infos))
+	for _, info := range infos {
+		fmt.Println("  • " + info.Branch + ui.SubtleStyle.Render(" ("+info.Reason+")"))
+	}
+	fmt.Println()
+}
+
+// staleSelector returns the paths of the stale worktrees chosen for removal
+type staleSelector func(stale []git.Worktree) ([]string, error)
+
+// promptStaleSelection lets the user deselect stale worktrees to keep
+func promptStaleSelection(stale []git.Worktree) ([]string, error) {
+	var options []huh.Option[string]
+	for _, wt := range stale {
+		options = append(options, huh.NewOption(wt.Branch, wt.Path).Selected(true))
+	}
+
+	var selected []string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Select worktrees to remove").
+				Options(options...).
+				Value(&selected),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// selectStaleWorktrees filters stale worktrees (and their matching infos)
+// down to the ones picked by the selector
+func selectStaleWorktrees(stale []git.Worktree, infos []StaleWorktreeInfo, selector staleSelector) ([]git.Worktree, []StaleWorktreeInfo, error) {
+	selected, err := selector(stale)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keep := make(map[string]bool, len(selected))
+	for _, path := range selected {
+		keep[path] = true
+	}
+
+	var filtered []git.Worktree
+	var filteredInfos []StaleWorktreeInfo
+	for i, wt := range stale {
+		if keep[wt.Path] {
+			filtered = append(filtered, wt)
+			filteredInfos = append(filteredInfos, infos[i])
+		}
+	}
+	return filtered, filteredInfos, nil
+}
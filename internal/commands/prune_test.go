@@ -0,0 +1,423 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+func TestSelectStaleWorktrees(t *testing.T) {
+	stale := []git.Worktree{
+		{Branch: "feature/a", Path: "/project/feature-a"},
+		{Branch: "feature/b", Path: "/project/feature-b"},
+		{Branch: "feature/c", Path: "/project/feature-c"},
+	}
+	infos := []StaleWorktreeInfo{
+		{Branch: "feature/a", Path: "/project/feature-a"},
+		{Branch: "feature/b", Path: "/project/feature-b"},
+		{Branch: "feature/c", Path: "/project/feature-c"},
+	}
+
+	// Deselect feature/b
+	selector := func(stale []git.Worktree) ([]string, error) {
+		return []string{"/project/feature-a", "/project/feature-c"}, nil
+	}
+
+	filtered, filteredInfos, err := selectStaleWorktrees(stale, infos, selector)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(filtered) != 2 || len(filteredInfos) != 2 {
+		t.Fatalf("expected 2 selected worktrees, got %d/%d", len(filtered), len(filteredInfos))
+	}
+	if filtered[0].Branch != "feature/a" || filtered[1].Branch != "feature/c" {
+		t.Errorf("unexpected selection: %v", filtered)
+	}
+	if filteredInfos[1].Branch != "feature/c" {
+		t.Errorf("expected infos to stay aligned with worktrees, got %v", filteredInfos)
+	}
+}
+
+func TestSelectStaleWorktrees_NoneSelected(t *testing.T) {
+	stale := []git.Worktree{{Branch: "feature/a", Path: "/project/feature-a"}}
+	infos := []StaleWorktreeInfo{{Branch: "feature/a", Path: "/project/feature-a"}}
+
+	selector := func(stale []git.Worktree) ([]string, error) {
+		return nil, nil
+	}
+
+	filtered, _, err := selectStaleWorktrees(stale, infos, selector)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(filtered) != 0 {
+		t.Errorf("expected nothing selected, got %v", filtered)
+	}
+}
+
+func TestSelectStaleWorktrees_SelectorError(t *testing.T) {
+	stale := []git.Worktree{{Branch: "feature/a", Path: "/project/feature-a"}}
+	infos := []StaleWorktreeInfo{{Branch: "feature/a", Path: "/project/feature-a"}}
+
+	selector := func(stale []git.Worktree) ([]string, error) {
+		return nil, errors.New("user aborted")
+	}
+
+	if _, _, err := selectStaleWorktrees(stale, infos, selector); err == nil {
+		t.Error("expected selector error to be returned")
+	}
+}
+
+func TestDetectStaleWorktrees_ReasonCodes(t *testing.T) {
+	dir := initCommandTestRepo(t, "keep", "gone", "missing", "local")
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	run("update-ref", "refs/remotes/origin/keep", "main")
+	run("checkout", "-q", "local")
+	run("commit", "--allow-empty", "-m", "unpushed")
+	run("checkout", "-q", "main")
+
+	worktrees := []git.Worktree{
+		{Path: dir, Branch: "main"},
+		{Path: dir, Branch: "keep"},
+		{Path: dir, Branch: "gone"},
+		{Path: filepath.Join(dir, "does-not-exist"), Branch: "missing"},
+		{Path: dir, Branch: "local"},
+	}
+
+	stale, infos, skipped, _ := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{})
+
+	if len(stale) != 2 || len(infos) != 2 {
+		t.Fatalf("stale = %+v, want 2 entries", infos)
+	}
+	want := map[string]string{"gone": ReasonRemoteDeleted, "missing": ReasonUnreachable}
+	for _, info := range infos {
+		if info.ReasonCode != want[info.Branch] {
+			t.Errorf("%s reason_code = %q, want %q", info.Branch, info.ReasonCode, want[info.Branch])
+		}
+		if info.Reason != reasonText[info.ReasonCode] {
+			t.Errorf("%s reason = %q, want %q", info.Branch, info.Reason, reasonText[info.ReasonCode])
+		}
+	}
+	if len(skipped) != 1 || skipped[0].Branch != "local" || skipped[0].ReasonCode != ReasonUnpushed {
+		t.Errorf("skipped = %+v, want local with %q", skipped, ReasonUnpushed)
+	}
+}
+
+func TestDetectStaleWorktrees_UnknownUnpushed(t *testing.T) {
+	dir := initCommandTestRepo(t)
+
+	// No local branch "ghost", so its unpushed commits cannot be counted
+	worktrees := []git.Worktree{{Path: dir, Branch: "ghost"}}
+
+	stale, _, skipped, err := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("stale = %+v, want none", stale)
+	}
+	if len(skipped) != 1 || skipped[0].ReasonCode != ReasonUnknown {
+		t.Fatalf("skipped = %+v, want ghost with %q", skipped, ReasonUnknown)
+	}
+	if !strings.HasPrefix(skipped[0].Reason, reasonText[ReasonUnknown]) {
+		t.Errorf("reason = %q, want it to start with %q", skipped[0].Reason, reasonText[ReasonUnknown])
+	}
+}
+
+func TestDetectStaleWorktrees_Locked(t *testing.T) {
+	dir := initCommandTestRepo(t, "gone", "usb")
+
+	worktrees := []git.Worktree{
+		{Path: dir, Branch: "gone", Locked: true},
+		{Path: filepath.Join(dir, "does-not-exist"), Branch: "usb", Locked: true, LockReason: "on a USB drive"},
+	}
+
+	// Locked worktrees are kept even with --force
+	forcePrune = true
+	defer func() { forcePrune = false }()
+
+	stale, _, skipped, err := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("stale = %+v, want none", stale)
+	}
+	want := map[string]string{"gone": "locked", "usb": "locked (on a USB drive)"}
+	if len(skipped) != 2 {
+		t.Fatalf("skipped = %+v, want 2 entries", skipped)
+	}
+	for _, info := range skipped {
+		if info.ReasonCode != ReasonLocked || info.Reason != want[info.Branch] {
+			t.Errorf("%s skipped as %q (%s), want %q", info.Branch, info.Reason, info.ReasonCode, want[info.Branch])
+		}
+	}
+}
+
+func TestDetectStaleWorktrees_Renamed(t *testing.T) {
+	dir := initCommandTestRepo(t, "feature/login")
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("checkout", "-q", "feature/login")
+	run("commit", "--allow-empty", "-m", "login work")
+	run("checkout", "-q", "main")
+	commit := run("rev-parse", "feature/login")
+
+	// feature/login was renamed to feat/signin on the remote: its old
+	// tracking ref is gone and the new one points at the same commit
+	run("update-ref", "refs/remotes/origin/feat/signin", commit)
+	worktrees := []git.Worktree{{Path: dir, Branch: "feature/login", Commit: commit}}
+
+	t.Cleanup(func() { forcePrune = false })
+	stale, _, skipped, _ := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{})
+	if len(stale) != 0 {
+		t.Errorf("expected renamed branch not to be stale, got %+v", stale)
+	}
+	if len(skipped) != 1 || skipped[0].ReasonCode != ReasonRenamed || skipped[0].RenamedTo != "origin/feat/signin" {
+		t.Fatalf("skipped = %+v, want feature/login possibly renamed to origin/feat/signin", skipped)
+	}
+
+	forcePrune = true
+	stale, infos, _, _ := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{})
+	if len(stale) != 1 || infos[0].ReasonCode != ReasonRemoteDeleted {
+		t.Errorf("expected --force to prune it as remote_deleted, got %+v", infos)
+	}
+}
+
+func TestDetectStaleWorktrees_Merged(t *testing.T) {
+	dir := initCommandTestRepo(t, "landed", "wip", "dirty")
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("checkout", "-q", "wip")
+	run("commit", "--allow-empty", "-m", "unfinished")
+	run("checkout", "-q", "main")
+	run("commit", "--allow-empty", "-m", "after landing")
+	for _, branch := range []string{"landed", "wip", "dirty"} {
+		run("update-ref", "refs/remotes/origin/"+branch, branch)
+	}
+	landed, dirty := filepath.Join(dir, "landed"), filepath.Join(dir, "dirty")
+	run("worktree", "add", landed, "landed")
+	run("worktree", "add", dirty, "dirty")
+	if err := os.WriteFile(filepath.Join(dirty, "notes.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	worktrees := []git.Worktree{
+		{Path: dir, Branch: "main"},
+		{Path: landed, Branch: "landed"},
+		{Path: dir, Branch: "wip"},
+		{Path: dirty, Branch: "dirty"},
+	}
+
+	// Without --merged, branches still on the remote are kept
+	stale, _, _, err := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{})
+	if err != nil || len(stale) != 0 {
+		t.Fatalf("expected nothing stale, got %+v (%v)", stale, err)
+	}
+
+	stale, infos, skipped, err := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{mergeBase: "main"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stale) != 1 || infos[0].Branch != "landed" || infos[0].ReasonCode != ReasonMerged || infos[0].Reason != "merged into main" {
+		t.Errorf("expected landed merged into main, got %+v", infos)
+	}
+	if len(skipped) != 1 || skipped[0].Branch != "dirty" || skipped[0].ReasonCode != ReasonDirty {
+		t.Errorf("expected dirty to be skipped for uncommitted changes, got %+v", skipped)
+	}
+
+	if _, _, _, err := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{mergeBase: "no-such-branch"}); err == nil {
+		t.Error("expected error for an unknown merge base")
+	}
+}
+
+func TestDetectStaleWorktrees_CustomDefaultBranch(t *testing.T) {
+	dir := initCommandTestRepo(t, "develop", "feature")
+	for _, args := range [][]string{
+		{"update-ref", "refs/remotes/origin/develop", "develop"},
+		{"update-ref", "refs/remotes/origin/feature", "feature"},
+		{"symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/develop"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	worktrees := []git.Worktree{
+		{Path: dir, Branch: "develop"},
+		{Path: dir, Branch: "feature"},
+	}
+
+	// Both branches are merged into main, but develop is the default branch
+	stale, infos, _, err := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{mergeBase: "main"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stale) != 1 || infos[0].Branch != "feature" {
+		t.Errorf("expected only feature to be stale, got %+v", infos)
+	}
+}
+
+func TestDetectStaleWorktrees_InactiveNeverPushed(t *testing.T) {
+	// A branch whose last commit is long past and that never reached the remote
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-01T00:00:00Z")
+	dir := initCommandTestRepo(t, "old")
+	path := filepath.Join(dir, "old")
+	for _, args := range [][]string{
+		{"update-ref", "refs/remotes/origin/main", "main"},
+		{"worktree", "add", path, "old"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	idle := time.Now().Add(-30 * 24 * time.Hour)
+	if err := os.Chtimes(path, idle, idle); err != nil {
+		t.Fatal(err)
+	}
+	worktrees := []git.Worktree{{Path: path, Branch: "old"}}
+
+	stale, infos, _, err := detectStaleWorktrees(dir, config.DefaultConfig(), worktrees, staleCriteria{inactiveDays: 14})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stale) != 1 || infos[0].ReasonCode != ReasonInactive || !strings.HasPrefix(infos[0].Reason, "stale for ") {
+		t.Errorf("expected old to be stale for inactivity, got %+v", infos)
+	}
+}
+
+func TestStaleCriteria_Inactive(t *testing.T) {
+	dir := initCommandTestRepo(t)
+	committed := time.Now()
+	now := committed.Add(30 * 24 * time.Hour)
+
+	// A directory older than the last commit does not count
+	if err := os.Chtimes(dir, now, committed.Add(-10*24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if days, ok := (staleCriteria{inactiveDays: 14}).inactive(dir, now); !ok || days < 29 {
+		t.Errorf("expected ~30 idle days, got %d (%v)", days, ok)
+	}
+	if _, ok := (staleCriteria{inactiveDays: 45}).inactive(dir, now); ok {
+		t.Error("expected a worktree idle for 30 days not to pass --days 45")
+	}
+
+	// A recently touched directory counts as activity
+	if err := os.Chtimes(dir, now, now.Add(-5*24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if days, ok := (staleCriteria{inactiveDays: 14}).inactive(dir, now); ok || days != 5 {
+		t.Errorf("expected 5 idle days, got %d (%v)", days, ok)
+	}
+
+	if _, ok := (staleCriteria{}).inactive(dir, now); ok {
+		t.Error("expected no inactivity check without --days")
+	}
+}
+
+func TestReasonText_AllCodes(t *testing.T) {
+	for _, code := range []string{ReasonRemoteDeleted, ReasonMerged, ReasonUnreachable, ReasonInactive, ReasonUnpushed, ReasonRenamed, ReasonDirty, ReasonLocked, ReasonUnknown} {
+		if reasonText[code] == "" {
+			t.Errorf("reason code %q has no text", code)
+		}
+	}
+}
+
+func TestReclaimedBytes(t *testing.T) {
+	infos := []StaleWorktreeInfo{
+		{Branch: "a", Removed: true, SizeBytes: 1500},
+		{Branch: "b", Removed: false, SizeBytes: 9999}, // removal failed
+		{Branch: "c", Removed: true, SizeBytes: 0},     // size unknown
+		{Branch: "d", Removed: true, SizeBytes: 500},
+	}
+	if got := reclaimedBytes(infos); got != 2000 {
+		t.Errorf("reclaimedBytes() = %d, want 2000", got)
+	}
+	if got := reclaimedBytes(nil); got != 0 {
+		t.Errorf("reclaimedBytes(nil) = %d, want 0", got)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n        int64
+		expected string
+	}{
+		{0, "0 B"},
+		{999, "999 B"},
+		{1500, "1.5 kB"},
+		{1200000000, "1.2 GB"},
+		{3400000000000, "3.4 TB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.expected {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestFetchBeforePrune(t *testing.T) {
+	original := pruneFetch
+	defer func() { pruneFetch = original }()
+
+	calls := 0
+	pruneFetch = func(projectRoot string, timeout int) error {
+		calls++
+		return errors.New("offline")
+	}
+
+	fetchBeforePrune(t.TempDir(), 5, true)
+	if calls != 0 {
+		t.Errorf("expected no fetch with --no-fetch, got %d", calls)
+	}
+
+	fetchBeforePrune(t.TempDir(), 5, false)
+	if calls != 1 {
+		t.Errorf("expected one fetch, got %d", calls)
+	}
+}
+
+func TestPruneArgs(t *testing.T) {
+	defer func() { pruneMergedBase = "" }()
+
+	pruneMergedBase = mergedIntoDefault
+	err := pruneArgs(pruneCmd, []string{"main"})
+	if err == nil || !strings.Contains(err.Error(), "--merged=main") {
+		t.Errorf("expected a hint to use --merged=main, got %v", err)
+	}
+	if err := pruneArgs(pruneCmd, nil); err != nil {
+		t.Errorf("expected no error without arguments, got %v", err)
+	}
+
+	pruneMergedBase = ""
+	if err := pruneArgs(pruneCmd, []string{"main"}); err == nil || strings.Contains(err.Error(), "--merged") {
+		t.Errorf("expected a plain argument error without --merged, got %v", err)
+	}
+}
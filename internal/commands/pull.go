@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/raisedadead/git-wt/internal/config"
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// PullData represents the JSON output for the pull command
+type PullData struct {
+	Results []PullResult `json:"results"`
+	Updated int          `json:"updated"`
+	Skipped int          `json:"skipped"`
+	Failed  int          `json:"failed"`
+}
+
+// PullResult is the outcome of pulling one worktree
+type PullResult struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Stable values for PullResult.Result
+const (
+	PullUpdated  = "updated"
+	PullUpToDate = "up_to_date"
+	PullSkipped  = "skipped"
+	PullFailed   = "failed"
+)
+
+var (
+	rebasePull      bool
+	pullTimeoutFlag int
+)
+
+var pullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Update every worktree from its upstream",
+	Long: `Run git pull --ff-only in each worktree, one after another. Worktrees
+that are detached, locked, missing or have no upstream are skipped; a failed
+pull (e.g. a diverged branch) is reported and the rest still run.
+
+Examples:
+  git wt fetch && git wt pull
+  git wt pull --rebase`,
+	Args: cobra.NoArgs,
+	RunE: runPull,
+}
+
+func init() {
+	pullCmd.Flags().BoolVar(&rebasePull, "rebase", false, "Rebase local commits onto the upstream instead of fast-forwarding only")
+	pullCmd.Flags().IntVar(&pullTimeoutFlag, "timeout", 0, "Override git pull timeout per worktree (seconds)")
+	rootCmd.AddCommand(pullCmd)
+}
+
+func runPull(cmd *cobra.Command, args []string) error {
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "pull", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	cfg, err := config.LoadWithRepo(config.GetConfigPath(), projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "pull", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+	if pullTimeoutFlag > 0 {
+		cfg.GitLongTimeout = pullTimeoutFlag
+	}
+
+	worktrees, err := git.ListWorktrees(projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "pull", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	var data PullData
+	for _, wt := range worktrees {
+		if wt.Bare {
+			continue
+		}
+		res := pullWorktree(wt, rebasePull, cfg.GitLongTimeout)
+		data.Results = append(data.Results, res)
+
+		switch res.Result {
+		case PullUpdated:
+			data.Updated++
+		case PullSkipped:
+			data.Skipped++
+		case PullFailed:
+			data.Failed++
+		}
+		if !IsJSONOutput() {
+			printPullResult(res)
+		}
+	}
+
+	if IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "pull", data, nil)
+	}
+
+	fmt.Println()
+	if data.Failed > 0 {
+		return fmt.Errorf("pull failed in %d of %d worktrees", data.Failed, len(data.Results))
+	}
+	fmt.Println(ui.SuccessMsg(fmt.Sprintf("Updated %d worktrees (%d skipped)", data.Updated, data.Skipped)))
+	return nil
+}
+
+// pullWorktree pulls one worktree, skipping those that cannot or should not
+// be updated. For skipped worktrees Error holds the reason.
+func pullWorktree(wt git.Worktree, rebase bool, timeout int) PullResult {
+	res := PullResult{Branch: wt.Branch, Path: wt.Path}
+	skip := func(reason string) PullResult {
+		res.Result = PullSkipped
+		res.Error = reason
+		return res
+	}
+
+	switch {
+	case wt.Branch == "":
+		return skip("detached HEAD")
+	case wt.Locked:
+		return skip(lockedText(wt.LockReason))
+	case wt.Prunable != "":
+		return skip("worktree directory missing")
+	}
+
+	upstreamSync, err := git.GetUpstreamSync(wt.Path)
+	if err == nil && upstreamSync.Upstream == "" {
+		return skip("no upstream")
+	}
+
+	before, _ := git.RunInDir(wt.Path, "rev-parse", "HEAD")
+	if err := git.PullBranch(wt.Path, rebase, timeout); err != nil {
+		res.Result = PullFailed
+		res.Error = err.Error()
+		return res
+	}
+	after, _ := git.RunInDir(wt.Path, "rev-parse", "HEAD")
+
+	res.Result = PullUpToDate
+	if before != after {
+		res.Result = PullUpdated
+	}
+	return res
+}
+
+// printPullResult prints one line per worktree
+func printPullResult(res PullResult) {
+	switch res.Result {
+	case PullUpdated:
+		fmt.Println(ui.SuccessMsg(res.Branch + ": updated"))
+	case PullUpToDate:
+		fmt.Println(ui.SubtleStyle.Render("  " + res.Branch + ": up to date"))
+	case PullSkipped:
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("  %s: skipped (%s)", res.Branch, res.Error)))
+	case PullFailed:
+		fmt.Println(ui.WarningMsg(fmt.Sprintf("%s: %s", res.Branch, res.Error)))
+	}
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+func TestPullWorktree(t *testing.T) {
+	dir := initCommandTestRepo(t, "base", "feature")
+	run := func(args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	// main tracks the local base branch, which gains a commit
+	run("branch", "--set-upstream-to=base")
+	next := run("commit-tree", "HEAD^{tree}", "-p", "HEAD", "-m", "next")
+	run("update-ref", "refs/heads/base", next)
+	feature := filepath.Join(dir, "feature")
+	run("worktree", "add", feature, "feature")
+
+	main := git.Worktree{Path: dir, Branch: "main"}
+	if res := pullWorktree(main, false, 30); res.Result != PullUpdated {
+		t.Errorf("expected main to be updated, got %+v", res)
+	}
+	if res := pullWorktree(main, false, 30); res.Result != PullUpToDate {
+		t.Errorf("expected main to be up to date, got %+v", res)
+	}
+
+	res := pullWorktree(git.Worktree{Path: feature, Branch: "feature"}, false, 30)
+	if res.Result != PullSkipped || res.Error != "no upstream" {
+		t.Errorf("expected feature to be skipped for no upstream, got %+v", res)
+	}
+
+	res = pullWorktree(git.Worktree{Path: feature}, false, 30)
+	if res.Result != PullSkipped || res.Error != "detached HEAD" {
+		t.Errorf("expected detached worktree to be skipped, got %+v", res)
+	}
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/raisedadead/git-wt/internal/git"
@@ -12,11 +13,35 @@ import (
 
 // RepairData represents the JSON output for the repair command
 type RepairData struct {
+	ProjectRoot  string           `json:"project_root"`
+	Repaired     bool             `json:"repaired"`
+	Output       string           `json:"output,omitempty"`
+	DryRun       bool             `json:"dry_run,omitempty"`
+	PlannedFixes []git.BrokenLink `json:"planned_fixes,omitempty"`
+}
+
+// RepairProjectResult is the outcome of repairing one project in a
+// recursive repair
+type RepairProjectResult struct {
 	ProjectRoot string `json:"project_root"`
 	Repaired    bool   `json:"repaired"`
 	Output      string `json:"output,omitempty"`
+	Skipped     bool   `json:"skipped,omitempty"`
+	Reason      string `json:"reason,omitempty"`
 }
 
+// RepairRecursiveData represents the JSON output for repair --recursive
+type RepairRecursiveData struct {
+	Projects      []RepairProjectResult `json:"projects"`
+	Total         int                   `json:"total"`
+	RepairedCount int                   `json:"repaired_count"`
+}
+
+var (
+	dryRunRepair    bool
+	recursiveRepair bool
+)
+
 var repairCmd = &cobra.Command{
 	Use:   "repair",
 	Short: "Repair worktree paths after moving a repository",
@@ -24,15 +49,34 @@ var repairCmd = &cobra.Command{
 
 This fixes broken worktree paths by updating the gitdir links between
 the main repository and its worktrees. Run this command from within
-any worktree after moving a git-wt managed repository.`,
+any worktree after moving a git-wt managed repository.
+
+Use --dry-run to check which worktrees need repair without changing anything.
+
+Use --recursive to repair every git-wt project directly under a directory
+(default: the current directory), e.g. after moving a whole projects folder.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runRepair,
 }
 
 func init() {
+	repairCmd.Flags().BoolVar(&dryRunRepair, "dry-run", false, "Show what would be repaired without repairing")
+	repairCmd.Flags().BoolVarP(&recursiveRepair, "recursive", "r", false, "Repair all git-wt projects under a directory")
 	rootCmd.AddCommand(repairCmd)
 }
 
 func runRepair(cmd *cobra.Command, args []string) error {
+	if recursiveRepair {
+		return runRepairRecursive(args)
+	}
+	if len(args) > 0 {
+		msg := "a directory argument requires --recursive"
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "repair", nil, ui.NewCLIError(ui.ErrCodeValidation, msg))
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
 	// Find project root
 	projectRoot, err := git.GetProjectRoot(".")
 	if err != nil {
@@ -42,6 +86,10 @@ func runRepair(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a git-wt project: %w", err)
 	}
 
+	if dryRunRepair {
+		return runRepairDryRun(projectRoot)
+	}
+
 	if !IsJSONOutput() {
 		fmt.Println(ui.SubtleStyle.Render("Repairing worktree paths..."))
 	}
@@ -76,3 +124,128 @@ func runRepair(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runRepairDryRun reports broken worktree links without repairing them
+func runRepairDryRun(projectRoot string) error {
+	broken, err := git.CheckWorktreeLinks(projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "repair", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	if IsJSONOutput() {
+		data := RepairData{
+			ProjectRoot:  projectRoot,
+			DryRun:       true,
+			PlannedFixes: broken,
+		}
+		return ui.OutputJSON(os.Stdout, "repair", data, nil)
+	}
+
+	if len(broken) == 0 {
+		fmt.Println(ui.SuccessMsg("All worktree paths are correct"))
+		return nil
+	}
+
+	fmt.Printf("Found %d worktrees to repair:\n", len(broken))
+	for _, link := range broken {
+		fmt.Println("  • " + shortenPath(link.Path) + ui.SubtleStyle.Render(" ("+link.Reason+")"))
+	}
+	fmt.Println()
+	fmt.Println(ui.InfoMsg("Dry run - no changes made"))
+	return nil
+}
+
+// runRepairRecursive repairs every git-wt project directly under a directory
+func runRepairRecursive(args []string) error {
+	if dryRunRepair {
+		msg := "--dry-run cannot be used with --recursive"
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "repair", nil, ui.NewCLIError(ui.ErrCodeValidation, msg))
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	results, err := repairProjects(dir)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "repair", nil, ui.NewCLIError(ui.ErrCodeNotFound, err.Error()))
+		}
+		return err
+	}
+
+	data := RepairRecursiveData{Projects: results, Total: len(results)}
+	for _, r := range results {
+		if r.Repaired {
+			data.RepairedCount++
+		}
+	}
+
+	if IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "repair", data, nil)
+	}
+
+	for _, r := range results {
+		name := filepath.Base(r.ProjectRoot)
+		switch {
+		case r.Skipped:
+			fmt.Println("  " + ui.SubtleStyle.Render(fmt.Sprintf("- %s skipped (%s)", name, r.Reason)))
+		case r.Reason != "":
+			fmt.Println(ui.WarningMsg(fmt.Sprintf("%s failed: %s", name, r.Reason)))
+		case r.Repaired:
+			fmt.Println(ui.SuccessMsg(name + " repaired"))
+		default:
+			fmt.Println(ui.SuccessMsg(name + " ok"))
+		}
+	}
+	fmt.Println()
+	fmt.Println(ui.InfoMsg(fmt.Sprintf("Repaired %d of %d projects", data.RepairedCount, data.Total)))
+	return nil
+}
+
+// repairProjects runs git worktree repair in each project directly under dir.
+// Subdirectories that are not git-wt projects are reported as skipped; hidden
+// directories are ignored.
+func repairProjects(dir string) ([]RepairProjectResult, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	entries, err := os.ReadDir(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", absDir, err)
+	}
+
+	results := []RepairProjectResult{}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		projectRoot := filepath.Join(absDir, entry.Name())
+		result := RepairProjectResult{ProjectRoot: projectRoot}
+
+		if !git.IsBareRepo(projectRoot) {
+			result.Skipped = true
+			result.Reason = "not a git-wt project"
+			results = append(results, result)
+			continue
+		}
+
+		output, err := git.RepairWorktrees(projectRoot)
+		if err != nil {
+			result.Reason = err.Error()
+		} else {
+			result.Output = strings.TrimSpace(output)
+			result.Repaired = result.Output != ""
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
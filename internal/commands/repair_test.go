@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRepairProjects_MixedDirectories(t *testing.T) {
+	src := initCommandTestRepo(t)
+	dir := t.TempDir()
+
+	project := filepath.Join(dir, "app")
+	if out, err := exec.Command("git", "clone", "--bare", src, filepath.Join(project, ".bare")).CombinedOutput(); err != nil {
+		t.Fatalf("git clone failed: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".git"), []byte("gitdir: ./.bare\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"notes", ".cache"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := repairProjects(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %+v", results)
+	}
+
+	if results[0].ProjectRoot != project || results[0].Skipped || results[0].Reason != "" {
+		t.Errorf("expected app to be repaired cleanly, got %+v", results[0])
+	}
+	if results[1].ProjectRoot != filepath.Join(dir, "notes") || !results[1].Skipped || results[1].Reason == "" {
+		t.Errorf("expected notes to be skipped with a reason, got %+v", results[1])
+	}
+}
+
+func TestRepairProjects_MissingDir(t *testing.T) {
+	if _, err := repairProjects(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
@@ -12,13 +12,25 @@ import (
 var version = "dev"
 
 // Global flags
-var jsonOutputFlag bool
+var (
+	jsonOutputFlag bool
+	configPathFlag string
+	colorFlag      string
+	noColorFlag    bool
+	chdirFlag      string
+	noHooksFlag    bool
+)
 
 // IsJSONOutput returns true if JSON output is enabled
 func IsJSONOutput() bool {
 	return jsonOutputFlag
 }
 
+// HooksDisabled returns true if configured hooks (pre and post) should be skipped
+func HooksDisabled() bool {
+	return noHooksFlag
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "git-wt",
 	Short: "Git worktree manager with bare repo support",
@@ -27,18 +39,61 @@ var rootCmd = &cobra.Command{
 Create isolated worktrees for features, issues, and PRs with
 customizable post-create hooks.`,
 	Version: version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if chdirFlag != "" {
+			if err := applyChdir(chdirFlag); err != nil {
+				if IsJSONOutput() {
+					return ui.OutputJSON(os.Stdout, cmd.Name(), nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+				}
+				return ui.NewCLIError(ui.ErrCodeValidation, err.Error())
+			}
+		}
+		if configPathFlag != "" {
+			config.SetConfigPath(configPathFlag)
+		}
+		// --no-color is an alias for --color=never
+		colorMode := colorFlag
+		if noColorFlag {
+			colorMode = ui.ColorNever
+		}
+		if err := ui.SetColorMode(colorMode); err != nil {
+			return ui.NewCLIError(ui.ErrCodeValidation, err.Error())
+		}
+		return nil
+	},
 }
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonOutputFlag, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().StringVarP(&chdirFlag, "chdir", "C", "", "Run as if git-wt was started in this directory")
+	rootCmd.PersistentFlags().StringVar(&configPathFlag, "config", "", "Use an explicit global config file")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", ui.ColorAuto, "Colorize output: auto, always, or never")
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output (same as --color=never)")
+	rootCmd.PersistentFlags().BoolVar(&noHooksFlag, "no-hooks", false, "Skip configured hooks (pre_add, pre_delete, post_clone, post_add, post_delete, post_prune)")
 	rootCmd.SetVersionTemplate(fmt.Sprintf("%s\n", ui.TitleStyle.Render("git-wt version {{.Version}}")))
 }
 
+// applyChdir changes the working directory so that project-root detection
+// and relative paths resolve from dir
+func applyChdir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("cannot change to %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("cannot change to %s: not a directory", dir)
+	}
+	if err := os.Chdir(dir); err != nil {
+		return fmt.Errorf("cannot change to %s: %w", dir, err)
+	}
+	return nil
+}
+
 func Execute() {
 	err := rootCmd.Execute()
 
-	// Show first-run hint (only once, only on success, only if not JSON)
-	if err == nil && !jsonOutputFlag && !config.IsInitialized() {
+	// Show first-run hint (only once, only on success, only if not JSON or an explicit config)
+	if err == nil && !jsonOutputFlag && configPathFlag == "" && !config.IsInitialized() {
 		fmt.Println()
 		fmt.Println(ui.SubtleStyle.Render("Tip: Customize git-wt at " + config.GetConfigPath()))
 		_ = config.MarkInitialized()
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+func TestApplyChdir_AffectsProjectRoot(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+
+	projectRoot := t.TempDir()
+	if err := os.Mkdir(filepath.Join(projectRoot, git.BareDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectRoot, git.GitPointerFile), []byte("gitdir: ./.bare\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	worktree := filepath.Join(projectRoot, "main")
+	if err := os.Mkdir(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applyChdir(worktree); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got, err := git.GetProjectRoot(".")
+	if err != nil {
+		t.Fatalf("expected project root, got error %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(projectRoot)
+	got, _ = filepath.EvalSymlinks(got)
+	if got != want {
+		t.Errorf("expected project root %s, got %s", want, got)
+	}
+}
+
+func TestApplyChdir_Invalid(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+
+	if err := applyChdir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyChdir(file); err == nil {
+		t.Error("expected error for a file")
+	}
+}
+
+func TestHooksDisabled(t *testing.T) {
+	t.Cleanup(func() { noHooksFlag = false })
+
+	if HooksDisabled() {
+		t.Error("expected hooks to be enabled by default")
+	}
+	if err := rootCmd.PersistentFlags().Parse([]string{"--no-hooks"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !HooksDisabled() {
+		t.Error("expected --no-hooks to disable hooks")
+	}
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var shellInitCmd = &cobra.Command{
+	Use:   "shell-init <bash|zsh|fish>",
+	Short: "Print a shell function that lets switch change directory",
+	Long: `Print a wt shell function for bash, zsh or fish. "wt switch" changes the
+shell's directory to the worktree; any other subcommand runs git-wt as is.
+
+A program cannot change its parent shell's directory, so switch only prints
+the path. Add one of these to your shell startup file:
+
+  eval "$(git wt shell-init bash)"    # ~/.bashrc
+  eval "$(git wt shell-init zsh)"     # ~/.zshrc
+  git wt shell-init fish | source     # ~/.config/fish/config.fish`,
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		script, err := shellInitScript(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Print(script)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shellInitCmd)
+}
+
+// posixShellInit defines wt for bash and zsh
+const posixShellInit = `wt() {
+  if [ "$1" = "switch" ]; then
+    shift
+    local dir
+    dir="$(command git-wt switch "$@")" || return
+    [ -n "$dir" ] && cd "$dir"
+  else
+    command git-wt "$@"
+  fi
+}
+`
+
+const fishShellInit = `function wt
+  if test (count $argv) -gt 0; and test "$argv[1]" = switch
+    set -l dir (command git-wt switch $argv[2..-1]); or return
+    test -n "$dir"; and cd $dir
+  else
+    command git-wt $argv
+  end
+end
+`
+
+// shellInitScript returns the wt function definition for shell
+func shellInitScript(shell string) (string, error) {
+	switch shell {
+	case "bash", "zsh":
+		return posixShellInit, nil
+	case "fish":
+		return fishShellInit, nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s (use bash, zsh, or fish)", shell)
+	}
+}
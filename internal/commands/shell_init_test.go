@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShellInitScript_Bash(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+
+	// A stand-in git-wt that prints a path for switch and echoes anything else
+	binDir := t.TempDir()
+	target := t.TempDir()
+	stub := "#!/bin/sh\nif [ \"$1\" = switch ]; then echo '" + target + "'; else echo \"ran $*\"; fi\n"
+	if err := os.WriteFile(filepath.Join(binDir, "git-wt"), []byte(stub), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	script, err := shellInitScript("bash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(bash, "-c", script+"wt list --dirty\nwt switch feature\npwd")
+	cmd.Env = append(os.Environ(), "PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("script failed: %v\n%s", err, out)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 || lines[0] != "ran list --dirty" {
+		t.Fatalf("unexpected output: %q", lines)
+	}
+	want, _ := filepath.EvalSymlinks(target)
+	if got, _ := filepath.EvalSymlinks(lines[1]); got != want {
+		t.Errorf("wt switch left the shell in %s, want %s", lines[1], target)
+	}
+}
+
+func TestShellInitScript_Unsupported(t *testing.T) {
+	if _, err := shellInitScript("powershell"); err == nil {
+		t.Error("expected error for unsupported shell")
+	}
+	if script, err := shellInitScript("fish"); err != nil || !strings.Contains(script, "function wt") {
+		t.Errorf("expected fish function, got %q (%v)", script, err)
+	}
+}
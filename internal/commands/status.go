@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// StatusData represents the JSON output for the status command
+type StatusData struct {
+	ProjectRoot   string         `json:"project_root"`
+	DefaultBranch string         `json:"default_branch"`
+	Worktrees     int            `json:"worktrees"`
+	LastFetched   *time.Time     `json:"last_fetched"`
+	FetchedAgo    string         `json:"fetched_ago"`
+	Branches      []BranchStatus `json:"branches"`
+}
+
+// BranchStatus summarizes one worktree: its changes and how its branch
+// compares with the upstream (ahead/behind are zero without an upstream)
+type BranchStatus struct {
+	Branch   string `json:"branch"`
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	Clean    bool   `json:"clean"`
+	Upstream string `json:"upstream,omitempty"`
+	Ahead    int    `json:"ahead"`
+	Behind   int    `json:"behind"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show project status",
+	Long: `Show a summary of the current git-wt project, including how long ago
+the remote was last fetched. Stale remote-tracking info can make prune
+miss (or misreport) deleted branches, so fetch first if it has been a while.
+
+Below the summary, each worktree is listed with its status and how many
+commits it is ahead of (needs pushing) and behind (needs rebasing) its
+upstream.`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "status", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	defaultBranch, err := git.GetDefaultBranch(projectRoot)
+	if err != nil {
+		defaultBranch = git.DefaultBranch
+	}
+
+	worktrees, err := git.ListWorktrees(projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "status", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+	var listed []git.Worktree
+	for _, wt := range worktrees {
+		if wt.Bare || wt.Branch == "" {
+			continue
+		}
+		listed = append(listed, wt)
+	}
+	count := len(listed)
+
+	// Each entry runs a few git commands, so gather them in parallel
+	branches := make([]BranchStatus, len(listed))
+	var wg sync.WaitGroup
+	for i, wt := range listed {
+		wg.Add(1)
+		go func(i int, wt git.Worktree) {
+			defer wg.Done()
+			branches[i] = buildBranchStatus(wt)
+		}(i, wt)
+	}
+	wg.Wait()
+
+	fetched, err := git.LastFetchTime(projectRoot)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "status", nil, ui.NewCLIError(ui.ErrCodeGit, err.Error()))
+		}
+		return err
+	}
+
+	data := StatusData{
+		ProjectRoot:   projectRoot,
+		DefaultBranch: defaultBranch,
+		Worktrees:     count,
+		FetchedAgo:    "never",
+		Branches:      branches,
+	}
+	if !fetched.IsZero() {
+		data.LastFetched = &fetched
+		data.FetchedAgo = formatAge(time.Since(fetched))
+	}
+
+	if IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "status", data, nil)
+	}
+
+	fmt.Printf("%s %s\n", ui.BoldStyle.Render("Project:       "), shortenPath(projectRoot))
+	fmt.Printf("%s %s\n", ui.BoldStyle.Render("Default branch:"), defaultBranch)
+	fmt.Printf("%s %d\n", ui.BoldStyle.Render("Worktrees:     "), count)
+	if data.LastFetched == nil {
+		fmt.Printf("%s %s\n", ui.BoldStyle.Render("Last fetched:  "), ui.WarningStyle.Render("never"))
+	} else {
+		fmt.Printf("%s %s\n", ui.BoldStyle.Render("Last fetched:  "), data.FetchedAgo)
+	}
+	if len(branches) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, ui.BoldStyle.Render("BRANCH\tSTATUS\tAHEAD\tBEHIND\tUPSTREAM"))
+	for _, b := range branches {
+		statusStyle := ui.SuccessStyle
+		if !b.Clean {
+			statusStyle = ui.SubtleStyle
+		}
+		ahead, behind, upstream := "-", "-", ui.SubtleStyle.Render("(none)")
+		if b.Upstream != "" {
+			ahead, behind, upstream = fmt.Sprintf("%d", b.Ahead), fmt.Sprintf("%d", b.Behind), b.Upstream
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", b.Branch, statusStyle.Render(b.Status), ahead, behind, upstream)
+	}
+	return w.Flush()
+}
+
+// buildBranchStatus collects the status and upstream sync of a worktree
+func buildBranchStatus(wt git.Worktree) BranchStatus {
+	status := BranchStatus{Branch: wt.Branch, Path: wt.Path}
+	status.Status = "unknown"
+	if changes, err := worktreeStatus(wt.Path); err == nil {
+		status.Status = changes.String()
+		status.Clean = changes.IsClean()
+	}
+	if upstreamSync, err := git.GetUpstreamSync(wt.Path); err == nil {
+		status.Upstream = upstreamSync.Upstream
+		status.Ahead = upstreamSync.Ahead
+		status.Behind = upstreamSync.Behind
+	}
+	return status
+}
+
+// formatAge renders a duration as a short relative age, e.g. "3h ago"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/raisedadead/git-wt/internal/git"
+)
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		age      time.Duration
+		expected string
+	}{
+		{10 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{3*time.Hour + 20*time.Minute, "3h ago"},
+		{49 * time.Hour, "2d ago"},
+	}
+
+	for _, tt := range tests {
+		if got := formatAge(tt.age); got != tt.expected {
+			t.Errorf("formatAge(%v) = %q, want %q", tt.age, got, tt.expected)
+		}
+	}
+}
+
+func TestBuildBranchStatus(t *testing.T) {
+	dir := initCommandTestRepo(t, "base")
+	for _, args := range [][]string{
+		{"branch", "--set-upstream-to=base"},
+		{"commit", "--allow-empty", "-m", "unpushed"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	status := buildBranchStatus(git.Worktree{Path: dir, Branch: "main"})
+	if !status.Clean || status.Status != "clean" {
+		t.Errorf("expected clean worktree, got %+v", status)
+	}
+	if status.Upstream != "base" || status.Ahead != 1 || status.Behind != 0 {
+		t.Errorf("expected 1 ahead of base, got %+v", status)
+	}
+
+	// No upstream is not an error
+	if out, err := exec.Command("git", "-C", dir, "branch", "--unset-upstream").CombinedOutput(); err != nil {
+		t.Fatalf("git branch --unset-upstream failed: %v\n%s", err, out)
+	}
+	status = buildBranchStatus(git.Worktree{Path: dir, Branch: "main"})
+	if status.Upstream != "" || status.Ahead != 0 || status.Behind != 0 {
+		t.Errorf("expected no upstream, got %+v", status)
+	}
+}
@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/raisedadead/git-wt/internal/git"
+	"github.com/raisedadead/git-wt/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// SwitchData represents the JSON output for the switch command
+type SwitchData struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path"`
+	Tmux   string `json:"tmux,omitempty"`
+}
+
+var switchTmux bool
+
+var switchCmd = &cobra.Command{
+	Use:   "switch [branch]",
+	Short: "Print the path of a worktree",
+	Long: `Print the absolute path of a branch's worktree, and nothing else, for
+use with cd:
+
+  cd "$(git wt switch feature/auth)"
+
+Without a branch, choose a worktree interactively.
+
+With --tmux, switch to a tmux window named after the branch instead,
+creating it in the worktree if needed. Must be run inside tmux.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSwitch,
+}
+
+func init() {
+	switchCmd.Flags().BoolVar(&switchTmux, "tmux", false, "Switch to (or open) a tmux window for the worktree")
+	rootCmd.AddCommand(switchCmd)
+}
+
+func runSwitch(cmd *cobra.Command, args []string) error {
+	projectRoot, err := git.GetProjectRoot(".")
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "switch", nil, ui.NewCLIError(ui.ErrCodeNotInProject, "not in a git-wt project"))
+		}
+		return fmt.Errorf("not in a git-wt project: %w", err)
+	}
+
+	var branchName string
+	if len(args) > 0 {
+		branchName = args[0]
+	} else {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "switch", nil,
+				ui.NewCLIError(ui.ErrCodeValidation, "branch name is required"))
+		}
+
+		worktrees, err := git.ListWorktrees(projectRoot)
+		if err != nil {
+			return err
+		}
+
+		var options []huh.Option[string]
+		for _, wt := range worktrees {
+			if wt.Branch == "" || wt.Bare {
+				continue
+			}
+			options = append(options, huh.NewOption(wt.Branch, wt.Branch))
+		}
+		if len(options) == 0 {
+			return fmt.Errorf("no worktrees to switch to")
+		}
+
+		// Draw the picker on stderr so stdout holds only the path
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("Select worktree").
+					Options(options...).
+					Value(&branchName),
+			),
+		).WithOutput(os.Stderr)
+
+		if err := form.Run(); err != nil {
+			return err
+		}
+	}
+
+	worktreePath, err := switchTarget(projectRoot, branchName)
+	if err != nil {
+		if IsJSONOutput() {
+			return ui.OutputJSON(os.Stdout, "switch", nil, ui.NewCLIError(ui.ErrCodeNotFound, err.Error()))
+		}
+		return err
+	}
+
+	data := SwitchData{Branch: branchName, Path: worktreePath}
+	if switchTmux {
+		data.Tmux, err = switchTmuxWindow(git.FlattenBranchName(branchName), worktreePath)
+		if err != nil {
+			if IsJSONOutput() {
+				return ui.OutputJSON(os.Stdout, "switch", nil, ui.NewCLIError(ui.ErrCodeValidation, err.Error()))
+			}
+			return err
+		}
+	}
+
+	if IsJSONOutput() {
+		return ui.OutputJSON(os.Stdout, "switch", data, nil)
+	}
+	if switchTmux {
+		// Nothing on stdout, so the wt shell function has nothing to cd to
+		fmt.Fprintln(os.Stderr, ui.SuccessMsg(fmt.Sprintf("Switched to tmux window %s", data.Tmux)))
+		return nil
+	}
+	fmt.Println(worktreePath)
+	return nil
+}
+
+// switchTmuxWindow selects the tmux window called name in the current
+// session, creating it with path as its working directory if there is none.
+// It returns the window name, and errors when not running inside tmux.
+func switchTmuxWindow(name, path string) (string, error) {
+	if os.Getenv("TMUX") == "" {
+		return "", fmt.Errorf("not inside a tmux session")
+	}
+
+	out, err := exec.Command("tmux", "list-windows", "-F", "#{window_name}").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+	windows := strings.Split(strings.TrimSpace(string(out)), "\n")
+
+	if out, err := exec.Command("tmux", tmuxWindowArgs(windows, name, path)...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("tmux failed: %s", strings.TrimSpace(string(out)))
+	}
+	return name, nil
+}
+
+// tmuxWindowArgs returns the tmux arguments that select the window called
+// name if it is among windows, or create it in path otherwise
+func tmuxWindowArgs(windows []string, name, path string) []string {
+	if slices.Contains(windows, name) {
+		// "=" makes tmux match the name exactly rather than as a prefix
+		return []string{"select-window", "-t", "=" + name}
+	}
+	return []string{"new-window", "-c", path, "-n", name}
+}
+
+// switchTarget returns the absolute path of a branch's worktree. Like delete,
+// it asks git first (handles worktree_subdir and moved worktrees) and falls
+// back to the flattened branch name under the project root.
+func switchTarget(projectRoot, branchName string) (string, error) {
+	worktreePath := filepath.Join(projectRoot, git.FlattenBranchName(branchName))
+	if wt, err := git.FindWorktreeForBranch(projectRoot, branchName); err == nil && wt != nil {
+		worktreePath = wt.Path
+	}
+
+	if info, err := os.Stat(worktreePath); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("worktree not found: %s", branchName)
+	}
+	return filepath.Abs(worktreePath)
+}
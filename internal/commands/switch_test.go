@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSwitchTarget(t *testing.T) {
+	dir := initCommandTestRepo(t, "feature/auth", "moved")
+
+	flattened := filepath.Join(dir, "feature-auth")
+	elsewhere := filepath.Join(t.TempDir(), "elsewhere")
+	for _, args := range [][]string{
+		{"worktree", "add", flattened, "feature/auth"},
+		{"worktree", "add", elsewhere, "moved"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	got, err := switchTarget(dir, "feature/auth")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got != flattened {
+		t.Errorf("expected %s, got %s", flattened, got)
+	}
+
+	// Worktrees outside the flattened location are found through git
+	if got, err := switchTarget(dir, "moved"); err != nil || got != elsewhere {
+		t.Errorf("expected %s, got %s (%v)", elsewhere, got, err)
+	}
+
+	if _, err := switchTarget(dir, "missing"); err == nil {
+		t.Error("expected error for missing worktree")
+	}
+
+	// A stray file at the flattened path is not a worktree
+	if err := os.WriteFile(filepath.Join(dir, "stray"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := switchTarget(dir, "stray"); err == nil {
+		t.Error("expected error for non-directory path")
+	}
+}
+
+func TestTmuxWindowArgs(t *testing.T) {
+	windows := []string{"main", "feature-auth"}
+
+	got := tmuxWindowArgs(windows, "feature-auth", "/proj/feature-auth")
+	if strings.Join(got, " ") != "select-window -t =feature-auth" {
+		t.Errorf("expected to select the existing window, got %v", got)
+	}
+
+	// A prefix of an existing window name is a new window
+	got = tmuxWindowArgs(windows, "feature", "/proj/feature")
+	if strings.Join(got, " ") != "new-window -c /proj/feature -n feature" {
+		t.Errorf("expected a new window, got %v", got)
+	}
+}
+
+func TestSwitchTmuxWindow_OutsideTmux(t *testing.T) {
+	t.Setenv("TMUX", "")
+	if _, err := switchTmuxWindow("main", t.TempDir()); err == nil {
+		t.Error("expected error outside tmux")
+	}
+}
@@ -8,22 +8,113 @@ import (
 	"github.com/BurntSushi/toml"
 )
 
-// Config holds the git-wt configuration
+// Config holds the git-wt configuration. Besides the toml key, each field's
+// tags drive the template written by config init (see GenerateConfigTemplate):
+// section groups fields under a heading (fields of a section are kept
+// together, top-level keys before tables), comment documents the field ("\n"
+// separates lines), applies and flag name the commands and flags it affects,
+// and example replaces the default value shown.
 type Config struct {
-	WorktreeRoot      string `toml:"worktree_root"`
-	DefaultRemote     string `toml:"default_remote"`
-	DefaultBaseBranch string `toml:"default_base_branch"`
-	BranchTemplate    string `toml:"branch_template"`
-	GitTimeout        int    `toml:"git_timeout"`
-	GitLongTimeout    int    `toml:"git_long_timeout"`
-	HookTimeout       int    `toml:"hook_timeout"`
-	Hooks             Hooks  `toml:"hooks"`
+	WorktreeRoot      string   `toml:"worktree_root" section:"Directory Settings" comment:"Where to clone repos (empty = current directory)" applies:"clone" flag:"--root"`
+	WorktreeSubdir    string   `toml:"worktree_subdir" section:"Directory Settings" comment:"Subdirectory of the project root to create worktrees in (empty = project root)" applies:"new" flag:"--dir-prefix"`
+	InitialWorktrees  []string `toml:"initial_worktrees" section:"Directory Settings" comment:"Existing branches to check out as worktrees after clone, besides the default" applies:"clone" flag:"--worktree (repeatable)" example:"[\"develop\", \"staging\"]"`
+	UseRelativePaths  *bool    `toml:"use_relative_paths" section:"Directory Settings" comment:"Record worktree paths relative to the project root (needs Git 2.48+)\nSet to false for older git versions or to record absolute paths" applies:"new" flag:"--no-relative-paths" example:"true"`
+	TruncateLongNames *bool    `toml:"truncate_long_names" section:"Directory Settings" comment:"Shorten worktree directory names over the 255-byte filesystem limit with a\nhash suffix instead of failing (the branch keeps its full name)" applies:"new" flag:"--truncate-long-names"`
+
+	DefaultRemote    string `toml:"default_remote" section:"Remote Settings" comment:"Git remote name for operations" applies:"prune, new" flag:"--remote"`
+	DefaultForgeHost string `toml:"default_forge_host" section:"Remote Settings" comment:"Host that owner/repo shorthand expands to on clone, e.g. a self-hosted\nGitLab or Gitea" applies:"clone"`
+	CloneHTTPS       *bool  `toml:"clone_https" section:"Remote Settings" comment:"Expand owner/repo shorthand to an HTTPS URL instead of SSH" applies:"clone" flag:"--https"`
+	GitHubHost       string `toml:"github_host" section:"Remote Settings" comment:"GitHub Enterprise host for gh commands (issues, PRs), passed as GH_HOST\n(empty = gh's default)" applies:"new --issue, new --pr" example:"\"github.example.com\""`
+	Forge            string `toml:"forge" section:"Remote Settings" comment:"Code host for add --pr: \"github\" (gh) or \"gitlab\" (glab, as with --mr)\n(empty = detect from the default remote's URL)" applies:"new --pr" example:"\"gitlab\""`
+
+	DefaultBaseBranch string `toml:"default_base_branch" section:"Branch Settings" comment:"Base branch for new worktrees (empty = HEAD)" applies:"new" flag:"--base"`
+	BranchTemplate    string `toml:"branch_template" section:"Branch Settings" comment:"Branch name template for issues, PRs and MRs\nVariables: {{type}}, {{number}}, {{slug}}, {{author}}, {{label}} (first label),\n{{date}} (YYYY-MM-DD); empty ones are dropped with their separator" applies:"new --issue, new --pr" flag:"--branch-template"`
+	GuessRemote       *bool  `toml:"guess_remote" section:"Branch Settings" comment:"Track the matching remote branch when the new branch exists on the remote\nonly (like git's --guess-remote). Ignored when --base is given." applies:"new" flag:"--guess-remote"`
+	DetachedHead      string `toml:"detached_head" section:"Branch Settings" comment:"What add does without --base when run from a worktree in detached HEAD:\n\"default\" warns and branches off the remote default branch, \"require\"\nfails until --base is given" applies:"new" example:"\"default\""`
+
+	GitTimeout     int `toml:"git_timeout" section:"Timeout Settings (seconds)" comment:"Standard git operations (status, branch, etc.)" flag:"--timeout"`
+	GitLongTimeout int `toml:"git_long_timeout" section:"Timeout Settings (seconds)" comment:"Long git operations (clone, fetch)"`
+	HookTimeout    int `toml:"hook_timeout" section:"Timeout Settings (seconds)" comment:"Hook execution timeout" flag:"--hook-timeout"`
+
+	PRBody         *bool  `toml:"pr_body" section:"PR Body" comment:"Scaffold a PR body file (e.g. for gh pr create --body-file) when creating\na worktree from an issue" applies:"new --issue" flag:"--pr-body"`
+	PRBodyPath     string `toml:"pr_body_path" section:"PR Body" comment:"Where to write it, relative to the worktree\n(empty = PR_BODY.md in the worktree's git dir, so it is never committed)"`
+	PRBodyTemplate string `toml:"pr_body_template" section:"PR Body" comment:"Go template for the file; fields: .Number, .Title, .URL, .Body" example:"\"{{.Title}}\\n\\nCloses #{{.Number}}\\n\""`
+
+	IgnoreUntrackedOnDelete *bool `toml:"ignore_untracked_on_delete" section:"Delete Settings" comment:"Allow deleting worktrees that only have untracked files without --force" applies:"delete"`
+
+	HookWorkdir       string `toml:"hook_workdir" section:"Hooks" comment:"Directory hooks run in: \"worktree\" (the new worktree) or \"project\" (the\nproject root)" example:"\"worktree\""`
+	WorktreeHooksPath string `toml:"worktree_hooks_path" section:"Hooks" comment:"Set core.hooksPath in each new worktree (git config --worktree), e.g. for\nhook managers that keep hooks in the repository; relative paths resolve\nfrom the worktree" applies:"new" example:"\".githooks\""`
+	HooksMerge        string `toml:"hooks_merge" section:"Hooks" comment:"How repo hooks combine with global ones: \"replace\" (a repo list replaces\nthe global list) or \"append\" (repo commands run after the global ones)" example:"\"append\""`
+	Hooks             Hooks  `toml:"hooks" section:"Hooks" comment:"Shell commands to run around operations. pre_add and pre_delete abort\nthe operation when a command fails; post_clone, post_add, post_delete and\npost_prune only warn\nEnvironment variables: GIT_WT_PATH, GIT_WT_BRANCH, GIT_WT_PROJECT_ROOT, GIT_WT_DEFAULT_BRANCH\nTemplate variables: {{.Path}}, {{.Branch}}, {{.ProjectRoot}}, {{.DefaultBranch}}"`
+
+	WorktreeGitConfig map[string]string `toml:"worktree_git_config" section:"Worktree Git Config" comment:"Git config applied to each new worktree only (git config --worktree)" applies:"new" example:"[worktree_git_config]\n\"user.email\" = \"me@work.com\""`
+	LabelSubdirs      map[string]string `toml:"label_subdirs" section:"Label Subdirectories" comment:"Group issue worktrees into subdirectories by issue label\n(nested under worktree_subdir when both are set)" applies:"new --issue" example:"[label_subdirs]\nbug = \"bugs\"\nfeature = \"features\""`
+	CommandDefaults   CommandDefaults   `toml:"command_defaults" section:"Command Defaults" comment:"Default flag values per command, used unless the flag is passed explicitly" applies:"delete, prune, new" example:"[command_defaults.delete]\nforce = true\n[command_defaults.prune]\nyes = true\n[command_defaults.new]\ntrack = true"`
 }
 
+// CommandDefaults maps a command name to default values for its flags,
+// e.g. [command_defaults.delete] force = true
+type CommandDefaults map[string]map[string]interface{}
+
+// RelativePaths reports whether new worktrees should use --relative-paths
+// (use_relative_paths defaults to true when unset)
+func (c *Config) RelativePaths() bool {
+	return c.UseRelativePaths == nil || *c.UseRelativePaths
+}
+
+// The optional bool options below default to false when unset. They are
+// pointers so that a repo config can turn off what the global one turns on.
+
+// HTTPSShorthand reports whether clone expands owner/repo to an HTTPS URL
+func (c *Config) HTTPSShorthand() bool {
+	return isSet(c.CloneHTTPS)
+}
+
+// GuessRemoteBranch reports whether add tracks a matching remote branch
+func (c *Config) GuessRemoteBranch() bool {
+	return isSet(c.GuessRemote)
+}
+
+// ScaffoldPRBody reports whether add --issue writes a PR body file
+func (c *Config) ScaffoldPRBody() bool {
+	return isSet(c.PRBody)
+}
+
+// TruncateNames reports whether over-long directory names are shortened
+func (c *Config) TruncateNames() bool {
+	return isSet(c.TruncateLongNames)
+}
+
+// IgnoreUntracked reports whether delete removes untracked-only worktrees
+// without --force
+func (c *Config) IgnoreUntracked() bool {
+	return isSet(c.IgnoreUntrackedOnDelete)
+}
+
+// isSet reports whether an optional bool is set to true
+func isSet(b *bool) bool {
+	return b != nil && *b
+}
+
+// Bool returns a pointer to b, for setting an optional bool option
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Values for hooks_merge
+const (
+	HooksMergeReplace = "replace"
+	HooksMergeAppend  = "append"
+)
+
 // Hooks defines user-configurable hook commands
 type Hooks struct {
-	PostClone []string `toml:"post_clone"`
-	PostAdd   []string `toml:"post_add"`
+	PostClone  []string `toml:"post_clone"`
+	PostAdd    []string `toml:"post_add"`
+	PreAdd     []string `toml:"pre_add"`
+	PreDelete  []string `toml:"pre_delete"`
+	PostDelete []string `toml:"post_delete"`
+	PostPrune  []string `toml:"post_prune"`
 }
 
 // DefaultConfig returns the default configuration
@@ -31,6 +122,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		WorktreeRoot:      "",
 		DefaultRemote:     "origin",
+		DefaultForgeHost:  "github.com",
 		DefaultBaseBranch: "",
 		BranchTemplate:    "{{type}}-{{number}}-{{slug}}",
 		GitTimeout:        120,
@@ -53,11 +145,36 @@ func GetConfigDir() string {
 	return filepath.Join(home, ".config", "git-wt")
 }
 
+// configPathOverride replaces the default global config path when set
+var configPathOverride string
+
+// SetConfigPath overrides the global config path (empty restores the default)
+func SetConfigPath(path string) {
+	configPathOverride = path
+}
+
 // GetConfigPath returns the full path to the config file
+// An explicit override (--config) takes precedence over the XDG location
 func GetConfigPath() string {
+	if configPathOverride != "" {
+		return configPathOverride
+	}
 	return filepath.Join(GetConfigDir(), "config.toml")
 }
 
+// checkConfigOverride errors when path is the --config override and does not
+// exist: an explicitly named config file must not silently fall back to the
+// defaults
+func checkConfigOverride(path string) error {
+	if configPathOverride == "" || path != configPathOverride {
+		return nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("config file not found: %s", path)
+	}
+	return nil
+}
+
 // GetInitMarkerPath returns the path to the initialization marker
 func GetInitMarkerPath() string {
 	return filepath.Join(GetConfigDir(), ".initialized")
@@ -65,6 +182,9 @@ func GetInitMarkerPath() string {
 
 // Load loads configuration from the given path
 func Load(path string) (*Config, error) {
+	if err := checkConfigOverride(path); err != nil {
+		return nil, err
+	}
 	cfg := DefaultConfig()
 
 	data, err := os.ReadFile(path)
@@ -85,6 +205,9 @@ func Load(path string) (*Config, error) {
 // loadRaw loads configuration from the given path without applying defaults
 // Returns an empty config if file doesn't exist (for merging purposes)
 func loadRaw(path string) (*Config, error) {
+	if err := checkConfigOverride(path); err != nil {
+		return nil, err
+	}
 	cfg := &Config{}
 
 	data, err := os.ReadFile(path)
@@ -138,6 +261,18 @@ func MergeConfig(base, override *Config) *Config {
 	if override.DefaultRemote != "" {
 		merged.DefaultRemote = override.DefaultRemote
 	}
+	if override.DefaultForgeHost != "" {
+		merged.DefaultForgeHost = override.DefaultForgeHost
+	}
+	if override.CloneHTTPS != nil {
+		merged.CloneHTTPS = override.CloneHTTPS
+	}
+	if override.GitHubHost != "" {
+		merged.GitHubHost = override.GitHubHost
+	}
+	if override.Forge != "" {
+		merged.Forge = override.Forge
+	}
 	if override.DefaultBaseBranch != "" {
 		merged.DefaultBaseBranch = override.DefaultBaseBranch
 	}
@@ -153,16 +288,110 @@ func MergeConfig(base, override *Config) *Config {
 	if override.HookTimeout != 0 {
 		merged.HookTimeout = override.HookTimeout
 	}
-	if len(override.Hooks.PostClone) > 0 {
-		merged.Hooks.PostClone = override.Hooks.PostClone
+	if override.WorktreeSubdir != "" {
+		merged.WorktreeSubdir = override.WorktreeSubdir
+	}
+	if override.IgnoreUntrackedOnDelete != nil {
+		merged.IgnoreUntrackedOnDelete = override.IgnoreUntrackedOnDelete
+	}
+	if len(override.InitialWorktrees) > 0 {
+		merged.InitialWorktrees = override.InitialWorktrees
+	}
+	if override.UseRelativePaths != nil {
+		merged.UseRelativePaths = override.UseRelativePaths
+	}
+	if override.GuessRemote != nil {
+		merged.GuessRemote = override.GuessRemote
+	}
+	if override.PRBody != nil {
+		merged.PRBody = override.PRBody
+	}
+	if override.PRBodyPath != "" {
+		merged.PRBodyPath = override.PRBodyPath
+	}
+	if override.PRBodyTemplate != "" {
+		merged.PRBodyTemplate = override.PRBodyTemplate
+	}
+	if override.TruncateLongNames != nil {
+		merged.TruncateLongNames = override.TruncateLongNames
+	}
+	if override.HookWorkdir != "" {
+		merged.HookWorkdir = override.HookWorkdir
+	}
+	if override.DetachedHead != "" {
+		merged.DetachedHead = override.DetachedHead
+	}
+	if override.WorktreeHooksPath != "" {
+		merged.WorktreeHooksPath = override.WorktreeHooksPath
 	}
-	if len(override.Hooks.PostAdd) > 0 {
-		merged.Hooks.PostAdd = override.Hooks.PostAdd
+	if override.HooksMerge != "" {
+		merged.HooksMerge = override.HooksMerge
 	}
+	appendHooks := merged.HooksMerge == HooksMergeAppend
+	merged.Hooks = Hooks{
+		PostClone:  mergeHookList(base.Hooks.PostClone, override.Hooks.PostClone, appendHooks),
+		PostAdd:    mergeHookList(base.Hooks.PostAdd, override.Hooks.PostAdd, appendHooks),
+		PreAdd:     mergeHookList(base.Hooks.PreAdd, override.Hooks.PreAdd, appendHooks),
+		PreDelete:  mergeHookList(base.Hooks.PreDelete, override.Hooks.PreDelete, appendHooks),
+		PostDelete: mergeHookList(base.Hooks.PostDelete, override.Hooks.PostDelete, appendHooks),
+		PostPrune:  mergeHookList(base.Hooks.PostPrune, override.Hooks.PostPrune, appendHooks),
+	}
+	merged.WorktreeGitConfig = mergeStringMap(base.WorktreeGitConfig, override.WorktreeGitConfig)
+	merged.LabelSubdirs = mergeStringMap(base.LabelSubdirs, override.LabelSubdirs)
+	merged.CommandDefaults = mergeCommandDefaults(base.CommandDefaults, override.CommandDefaults)
 
 	return &merged
 }
 
+// mergeHookList combines a hook list with its override: the override replaces
+// base when set, or runs after it with appendHooks
+func mergeHookList(base, override []string, appendHooks bool) []string {
+	if len(override) == 0 {
+		return base
+	}
+	if !appendHooks || len(base) == 0 {
+		return override
+	}
+	merged := make([]string, 0, len(base)+len(override))
+	merged = append(merged, base...)
+	return append(merged, override...)
+}
+
+// mergeStringMap returns a new map with override keys taking precedence
+func mergeStringMap(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// mergeCommandDefaults merges per-command flag defaults, override flags
+// taking precedence within each command
+func mergeCommandDefaults(base, override CommandDefaults) CommandDefaults {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(CommandDefaults, len(base)+len(override))
+	for _, src := range []CommandDefaults{base, override} {
+		for command, flags := range src {
+			if merged[command] == nil {
+				merged[command] = make(map[string]interface{}, len(flags))
+			}
+			for flag, value := range flags {
+				merged[command][flag] = value
+			}
+		}
+	}
+	return merged
+}
+
 // LoadWithRepo loads config with hierarchy: repo > global > defaults
 func LoadWithRepo(globalPath, projectRoot string) (*Config, error) {
 	// Start with defaults
@@ -191,13 +420,15 @@ func LoadWithRepo(globalPath, projectRoot string) (*Config, error) {
 // LoadEffective loads config and tracks source of each value
 // Returns config, map of field->source path, and error
 func LoadEffective(globalPath, projectRoot string) (*Config, map[string]string, error) {
+	if err := checkConfigOverride(globalPath); err != nil {
+		return nil, nil, err
+	}
 	sources := make(map[string]string)
 	cfg := DefaultConfig()
 
 	// Mark all as default initially
-	for _, field := range []string{"worktree_root", "default_remote", "default_base_branch",
-		"branch_template", "git_timeout", "git_long_timeout", "hook_timeout"} {
-		sources[field] = "default"
+	for _, key := range Keys() {
+		sources[key] = "default"
 	}
 
 	// Load and track global config
@@ -214,6 +445,22 @@ func LoadEffective(globalPath, projectRoot string) (*Config, map[string]string,
 			cfg.DefaultRemote = globalCfg.DefaultRemote
 			sources["default_remote"] = globalPath
 		}
+		if globalCfg.DefaultForgeHost != "" {
+			cfg.DefaultForgeHost = globalCfg.DefaultForgeHost
+			sources["default_forge_host"] = globalPath
+		}
+		if globalCfg.CloneHTTPS != nil {
+			cfg.CloneHTTPS = globalCfg.CloneHTTPS
+			sources["clone_https"] = globalPath
+		}
+		if globalCfg.GitHubHost != "" {
+			cfg.GitHubHost = globalCfg.GitHubHost
+			sources["github_host"] = globalPath
+		}
+		if globalCfg.Forge != "" {
+			cfg.Forge = globalCfg.Forge
+			sources["forge"] = globalPath
+		}
 		if globalCfg.DefaultBaseBranch != "" {
 			cfg.DefaultBaseBranch = globalCfg.DefaultBaseBranch
 			sources["default_base_branch"] = globalPath
@@ -234,11 +481,93 @@ func LoadEffective(globalPath, projectRoot string) (*Config, map[string]string,
 			cfg.HookTimeout = globalCfg.HookTimeout
 			sources["hook_timeout"] = globalPath
 		}
+		if globalCfg.WorktreeSubdir != "" {
+			cfg.WorktreeSubdir = globalCfg.WorktreeSubdir
+			sources["worktree_subdir"] = globalPath
+		}
+		if globalCfg.IgnoreUntrackedOnDelete != nil {
+			cfg.IgnoreUntrackedOnDelete = globalCfg.IgnoreUntrackedOnDelete
+			sources["ignore_untracked_on_delete"] = globalPath
+		}
+		if len(globalCfg.InitialWorktrees) > 0 {
+			cfg.InitialWorktrees = globalCfg.InitialWorktrees
+			sources["initial_worktrees"] = globalPath
+		}
+		if globalCfg.UseRelativePaths != nil {
+			cfg.UseRelativePaths = globalCfg.UseRelativePaths
+			sources["use_relative_paths"] = globalPath
+		}
+		if globalCfg.GuessRemote != nil {
+			cfg.GuessRemote = globalCfg.GuessRemote
+			sources["guess_remote"] = globalPath
+		}
+		if globalCfg.PRBody != nil {
+			cfg.PRBody = globalCfg.PRBody
+			sources["pr_body"] = globalPath
+		}
+		if globalCfg.PRBodyPath != "" {
+			cfg.PRBodyPath = globalCfg.PRBodyPath
+			sources["pr_body_path"] = globalPath
+		}
+		if globalCfg.PRBodyTemplate != "" {
+			cfg.PRBodyTemplate = globalCfg.PRBodyTemplate
+			sources["pr_body_template"] = globalPath
+		}
+		if globalCfg.TruncateLongNames != nil {
+			cfg.TruncateLongNames = globalCfg.TruncateLongNames
+			sources["truncate_long_names"] = globalPath
+		}
+		if globalCfg.HookWorkdir != "" {
+			cfg.HookWorkdir = globalCfg.HookWorkdir
+			sources["hook_workdir"] = globalPath
+		}
+		if globalCfg.DetachedHead != "" {
+			cfg.DetachedHead = globalCfg.DetachedHead
+			sources["detached_head"] = globalPath
+		}
+		if globalCfg.WorktreeHooksPath != "" {
+			cfg.WorktreeHooksPath = globalCfg.WorktreeHooksPath
+			sources["worktree_hooks_path"] = globalPath
+		}
+		if globalCfg.HooksMerge != "" {
+			cfg.HooksMerge = globalCfg.HooksMerge
+			sources["hooks_merge"] = globalPath
+		}
 		if len(globalCfg.Hooks.PostClone) > 0 {
 			cfg.Hooks.PostClone = globalCfg.Hooks.PostClone
+			sources["hooks.post_clone"] = globalPath
 		}
 		if len(globalCfg.Hooks.PostAdd) > 0 {
 			cfg.Hooks.PostAdd = globalCfg.Hooks.PostAdd
+			sources["hooks.post_add"] = globalPath
+		}
+		if len(globalCfg.Hooks.PreAdd) > 0 {
+			cfg.Hooks.PreAdd = globalCfg.Hooks.PreAdd
+			sources["hooks.pre_add"] = globalPath
+		}
+		if len(globalCfg.Hooks.PreDelete) > 0 {
+			cfg.Hooks.PreDelete = globalCfg.Hooks.PreDelete
+			sources["hooks.pre_delete"] = globalPath
+		}
+		if len(globalCfg.Hooks.PostDelete) > 0 {
+			cfg.Hooks.PostDelete = globalCfg.Hooks.PostDelete
+			sources["hooks.post_delete"] = globalPath
+		}
+		if len(globalCfg.Hooks.PostPrune) > 0 {
+			cfg.Hooks.PostPrune = globalCfg.Hooks.PostPrune
+			sources["hooks.post_prune"] = globalPath
+		}
+		if len(globalCfg.WorktreeGitConfig) > 0 {
+			cfg.WorktreeGitConfig = mergeStringMap(cfg.WorktreeGitConfig, globalCfg.WorktreeGitConfig)
+			sources["worktree_git_config"] = globalPath
+		}
+		if len(globalCfg.LabelSubdirs) > 0 {
+			cfg.LabelSubdirs = mergeStringMap(cfg.LabelSubdirs, globalCfg.LabelSubdirs)
+			sources["label_subdirs"] = globalPath
+		}
+		if len(globalCfg.CommandDefaults) > 0 {
+			cfg.CommandDefaults = mergeCommandDefaults(cfg.CommandDefaults, globalCfg.CommandDefaults)
+			sources["command_defaults"] = globalPath
 		}
 	}
 
@@ -258,6 +587,22 @@ func LoadEffective(globalPath, projectRoot string) (*Config, map[string]string,
 				cfg.DefaultRemote = repoCfg.DefaultRemote
 				sources["default_remote"] = repoPath
 			}
+			if repoCfg.DefaultForgeHost != "" {
+				cfg.DefaultForgeHost = repoCfg.DefaultForgeHost
+				sources["default_forge_host"] = repoPath
+			}
+			if repoCfg.CloneHTTPS != nil {
+				cfg.CloneHTTPS = repoCfg.CloneHTTPS
+				sources["clone_https"] = repoPath
+			}
+			if repoCfg.GitHubHost != "" {
+				cfg.GitHubHost = repoCfg.GitHubHost
+				sources["github_host"] = repoPath
+			}
+			if repoCfg.Forge != "" {
+				cfg.Forge = repoCfg.Forge
+				sources["forge"] = repoPath
+			}
 			if repoCfg.DefaultBaseBranch != "" {
 				cfg.DefaultBaseBranch = repoCfg.DefaultBaseBranch
 				sources["default_base_branch"] = repoPath
@@ -278,73 +623,96 @@ func LoadEffective(globalPath, projectRoot string) (*Config, map[string]string,
 				cfg.HookTimeout = repoCfg.HookTimeout
 				sources["hook_timeout"] = repoPath
 			}
-			if len(repoCfg.Hooks.PostClone) > 0 {
-				cfg.Hooks.PostClone = repoCfg.Hooks.PostClone
+			if repoCfg.WorktreeSubdir != "" {
+				cfg.WorktreeSubdir = repoCfg.WorktreeSubdir
+				sources["worktree_subdir"] = repoPath
 			}
-			if len(repoCfg.Hooks.PostAdd) > 0 {
-				cfg.Hooks.PostAdd = repoCfg.Hooks.PostAdd
+			if repoCfg.IgnoreUntrackedOnDelete != nil {
+				cfg.IgnoreUntrackedOnDelete = repoCfg.IgnoreUntrackedOnDelete
+				sources["ignore_untracked_on_delete"] = repoPath
+			}
+			if len(repoCfg.InitialWorktrees) > 0 {
+				cfg.InitialWorktrees = repoCfg.InitialWorktrees
+				sources["initial_worktrees"] = repoPath
+			}
+			if repoCfg.UseRelativePaths != nil {
+				cfg.UseRelativePaths = repoCfg.UseRelativePaths
+				sources["use_relative_paths"] = repoPath
+			}
+			if repoCfg.GuessRemote != nil {
+				cfg.GuessRemote = repoCfg.GuessRemote
+				sources["guess_remote"] = repoPath
+			}
+			if repoCfg.PRBody != nil {
+				cfg.PRBody = repoCfg.PRBody
+				sources["pr_body"] = repoPath
+			}
+			if repoCfg.PRBodyPath != "" {
+				cfg.PRBodyPath = repoCfg.PRBodyPath
+				sources["pr_body_path"] = repoPath
+			}
+			if repoCfg.PRBodyTemplate != "" {
+				cfg.PRBodyTemplate = repoCfg.PRBodyTemplate
+				sources["pr_body_template"] = repoPath
+			}
+			if repoCfg.TruncateLongNames != nil {
+				cfg.TruncateLongNames = repoCfg.TruncateLongNames
+				sources["truncate_long_names"] = repoPath
+			}
+			if repoCfg.HookWorkdir != "" {
+				cfg.HookWorkdir = repoCfg.HookWorkdir
+				sources["hook_workdir"] = repoPath
+			}
+			if repoCfg.DetachedHead != "" {
+				cfg.DetachedHead = repoCfg.DetachedHead
+				sources["detached_head"] = repoPath
+			}
+			if repoCfg.WorktreeHooksPath != "" {
+				cfg.WorktreeHooksPath = repoCfg.WorktreeHooksPath
+				sources["worktree_hooks_path"] = repoPath
+			}
+			if repoCfg.HooksMerge != "" {
+				cfg.HooksMerge = repoCfg.HooksMerge
+				sources["hooks_merge"] = repoPath
+			}
+			appendHooks := cfg.HooksMerge == HooksMergeAppend
+			for _, hook := range []struct {
+				key     string
+				current *[]string
+				repo    []string
+			}{
+				{"hooks.post_clone", &cfg.Hooks.PostClone, repoCfg.Hooks.PostClone},
+				{"hooks.post_add", &cfg.Hooks.PostAdd, repoCfg.Hooks.PostAdd},
+				{"hooks.pre_add", &cfg.Hooks.PreAdd, repoCfg.Hooks.PreAdd},
+				{"hooks.pre_delete", &cfg.Hooks.PreDelete, repoCfg.Hooks.PreDelete},
+				{"hooks.post_delete", &cfg.Hooks.PostDelete, repoCfg.Hooks.PostDelete},
+				{"hooks.post_prune", &cfg.Hooks.PostPrune, repoCfg.Hooks.PostPrune},
+			} {
+				if len(hook.repo) == 0 {
+					continue
+				}
+				// Appended lists come from both files, global first
+				if appendHooks && len(*hook.current) > 0 {
+					sources[hook.key] += ", " + repoPath
+				} else {
+					sources[hook.key] = repoPath
+				}
+				*hook.current = mergeHookList(*hook.current, hook.repo, appendHooks)
+			}
+			if len(repoCfg.WorktreeGitConfig) > 0 {
+				cfg.WorktreeGitConfig = mergeStringMap(cfg.WorktreeGitConfig, repoCfg.WorktreeGitConfig)
+				sources["worktree_git_config"] = repoPath
+			}
+			if len(repoCfg.LabelSubdirs) > 0 {
+				cfg.LabelSubdirs = mergeStringMap(cfg.LabelSubdirs, repoCfg.LabelSubdirs)
+				sources["label_subdirs"] = repoPath
+			}
+			if len(repoCfg.CommandDefaults) > 0 {
+				cfg.CommandDefaults = mergeCommandDefaults(cfg.CommandDefaults, repoCfg.CommandDefaults)
+				sources["command_defaults"] = repoPath
 			}
 		}
 	}
 
 	return cfg, sources, nil
 }
-
-// GenerateConfigTemplate returns a config file template with all options commented
-func GenerateConfigTemplate() string {
-	return `# ============================================================
-# git-wt configuration
-# Generated by: git wt config init
-# Uncomment and modify options as needed
-# ============================================================
-
-# --- Directory Settings ---
-
-# Where to clone repos (empty = current directory)
-# Applies to: clone
-# Flag: --root
-# worktree_root = ""
-
-# --- Remote Settings ---
-
-# Git remote name for operations
-# Applies to: prune, new
-# Flag: --remote
-# default_remote = "origin"
-
-# --- Branch Settings ---
-
-# Base branch for new worktrees (empty = HEAD)
-# Applies to: new
-# Flag: --base
-# default_base_branch = ""
-
-# Branch name template for GitHub issues/PRs
-# Variables: {{type}}, {{number}}, {{slug}}
-# Applies to: new --issue, new --pr
-# Flag: --branch-template
-# branch_template = "{{type}}-{{number}}-{{slug}}"
-
-# --- Timeout Settings (seconds) ---
-
-# Standard git operations (status, branch, etc.)
-# Flag: --timeout
-# git_timeout = 120
-
-# Long git operations (clone, fetch)
-# git_long_timeout = 600
-
-# Hook execution timeout
-# Flag: --hook-timeout
-# hook_timeout = 30
-
-# --- Hooks ---
-# Shell commands to run after operations
-# Environment variables: GIT_WT_PATH, GIT_WT_BRANCH, GIT_WT_PROJECT_ROOT, GIT_WT_DEFAULT_BRANCH
-# Template variables: {{.Path}}, {{.Branch}}, {{.ProjectRoot}}, {{.DefaultBranch}}
-
-# [hooks]
-# post_clone = []
-# post_add = []
-`
-}
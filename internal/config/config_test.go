@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -26,6 +27,49 @@ func TestGetConfigPath(t *testing.T) {
 	}
 }
 
+func TestSetConfigPath_Override(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "custom.toml")
+	if err := os.WriteFile(configPath, []byte(`default_remote = "upstream"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	SetConfigPath(configPath)
+	defer SetConfigPath("")
+
+	if got := GetConfigPath(); got != configPath {
+		t.Errorf("expected %s, got %s", configPath, got)
+	}
+
+	cfg, err := LoadGlobal()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.DefaultRemote != "upstream" {
+		t.Errorf("expected 'upstream' from override config, got %s", cfg.DefaultRemote)
+	}
+
+	// A missing override file is an error, not the defaults
+	missing := filepath.Join(tmpDir, "missing.toml")
+	SetConfigPath(missing)
+	if _, err := LoadGlobal(); err == nil || !strings.Contains(err.Error(), missing) {
+		t.Errorf("expected an error naming %s, got %v", missing, err)
+	}
+	if _, err := LoadWithRepo(missing, ""); err == nil {
+		t.Error("expected LoadWithRepo to fail for a missing override file")
+	}
+	if _, _, err := LoadEffective(missing, ""); err == nil {
+		t.Error("expected LoadEffective to fail for a missing override file")
+	}
+
+	// Clearing the override restores the XDG location
+	SetConfigPath("")
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/test-xdg")
+	if got := GetConfigPath(); got != "/tmp/test-xdg/git-wt/config.toml" {
+		t.Errorf("expected default path after reset, got %s", got)
+	}
+}
+
 func TestLoadConfig_NoFile(t *testing.T) {
 	cfg, err := Load("/nonexistent/config.toml")
 	if err != nil {
@@ -260,3 +304,275 @@ func TestGenerateConfigTemplate(t *testing.T) {
 		t.Error("should have header comment")
 	}
 }
+
+func TestLoadWithRepo_WorktreeGitConfig(t *testing.T) {
+	globalDir := t.TempDir()
+	repoDir := t.TempDir()
+
+	globalConfig := filepath.Join(globalDir, "config.toml")
+	repoConfig := filepath.Join(repoDir, ".git-wt.toml")
+
+	globalContent := `[worktree_git_config]
+"user.name" = "Me"
+"user.email" = "me@personal.com"
+`
+	if err := os.WriteFile(globalConfig, []byte(globalContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repoContent := `[worktree_git_config]
+"user.email" = "me@work.com"
+`
+	if err := os.WriteFile(repoConfig, []byte(repoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadWithRepo(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Repo keys override global keys, other global keys are kept
+	if cfg.WorktreeGitConfig["user.email"] != "me@work.com" {
+		t.Errorf("expected repo user.email, got %s", cfg.WorktreeGitConfig["user.email"])
+	}
+	if cfg.WorktreeGitConfig["user.name"] != "Me" {
+		t.Errorf("expected global user.name, got %s", cfg.WorktreeGitConfig["user.name"])
+	}
+
+	effective, sources, err := LoadEffective(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(effective.WorktreeGitConfig) != 2 {
+		t.Errorf("expected 2 merged entries, got %d", len(effective.WorktreeGitConfig))
+	}
+	if sources["worktree_git_config"] != repoConfig {
+		t.Errorf("expected source %s, got %s", repoConfig, sources["worktree_git_config"])
+	}
+}
+
+func TestLoadWithRepo_LabelSubdirs(t *testing.T) {
+	globalDir := t.TempDir()
+	repoDir := t.TempDir()
+
+	globalConfig := filepath.Join(globalDir, "config.toml")
+	globalContent := `[label_subdirs]
+bug = "bugs"
+feature = "features"
+`
+	if err := os.WriteFile(globalConfig, []byte(globalContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repoContent := `[label_subdirs]
+bug = "fixes"
+`
+	if err := os.WriteFile(filepath.Join(repoDir, ".git-wt.toml"), []byte(repoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadWithRepo(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.LabelSubdirs["bug"] != "fixes" {
+		t.Errorf("expected repo mapping 'fixes', got %s", cfg.LabelSubdirs["bug"])
+	}
+	if cfg.LabelSubdirs["feature"] != "features" {
+		t.Errorf("expected global mapping 'features', got %s", cfg.LabelSubdirs["feature"])
+	}
+}
+
+func TestLoadWithRepo_CommandDefaults(t *testing.T) {
+	globalDir := t.TempDir()
+	repoDir := t.TempDir()
+
+	globalConfig := filepath.Join(globalDir, "config.toml")
+	globalContent := `[command_defaults.delete]
+force = true
+yes = true
+
+[command_defaults.prune]
+yes = true
+`
+	if err := os.WriteFile(globalConfig, []byte(globalContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repoContent := `[command_defaults.delete]
+force = false
+`
+	if err := os.WriteFile(filepath.Join(repoDir, ".git-wt.toml"), []byte(repoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadWithRepo(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.CommandDefaults["delete"]["force"] != false {
+		t.Errorf("expected repo delete.force = false, got %v", cfg.CommandDefaults["delete"]["force"])
+	}
+	if cfg.CommandDefaults["delete"]["yes"] != true {
+		t.Errorf("expected global delete.yes = true, got %v", cfg.CommandDefaults["delete"]["yes"])
+	}
+	if cfg.CommandDefaults["prune"]["yes"] != true {
+		t.Errorf("expected global prune.yes = true, got %v", cfg.CommandDefaults["prune"]["yes"])
+	}
+}
+
+func TestLoadWithRepo_UseRelativePaths(t *testing.T) {
+	globalDir := t.TempDir()
+	repoDir := t.TempDir()
+	globalConfig := filepath.Join(globalDir, "config.toml")
+
+	// Unset defaults to true
+	cfg, err := LoadWithRepo(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !cfg.RelativePaths() {
+		t.Error("expected relative paths by default")
+	}
+
+	// A repo can opt out even when the global config opts in
+	if err := os.WriteFile(globalConfig, []byte("use_relative_paths = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, ".git-wt.toml"), []byte("use_relative_paths = false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadWithRepo(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.RelativePaths() {
+		t.Error("expected repo config to disable relative paths")
+	}
+}
+
+func TestLoadWithRepo_RepoDisablesGlobalBools(t *testing.T) {
+	globalConfig := filepath.Join(t.TempDir(), "config.toml")
+	repoDir := t.TempDir()
+	repoConfig := filepath.Join(repoDir, ".git-wt.toml")
+	keys := []string{"clone_https", "guess_remote", "pr_body", "truncate_long_names", "ignore_untracked_on_delete"}
+	var global, repo strings.Builder
+	for _, key := range keys {
+		global.WriteString(key + " = true\n")
+		repo.WriteString(key + " = false\n")
+	}
+	if err := os.WriteFile(globalConfig, []byte(global.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The global config turns them on...
+	cfg, err := LoadWithRepo(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !cfg.HTTPSShorthand() || !cfg.GuessRemoteBranch() || !cfg.ScaffoldPRBody() || !cfg.TruncateNames() || !cfg.IgnoreUntracked() {
+		t.Errorf("expected the global config to enable every option, got %+v", cfg)
+	}
+
+	// ...and the repo config can turn them off again
+	if err := os.WriteFile(repoConfig, []byte(repo.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadWithRepo(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	effective, sources, err := LoadEffective(globalConfig, repoDir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, c := range []*Config{cfg, effective} {
+		if c.HTTPSShorthand() || c.GuessRemoteBranch() || c.ScaffoldPRBody() || c.TruncateNames() || c.IgnoreUntracked() {
+			t.Errorf("expected the repo config to disable every option, got %+v", c)
+		}
+	}
+	for _, key := range keys {
+		if sources[key] != repoConfig {
+			t.Errorf("%s source = %q, want %q", key, sources[key], repoConfig)
+		}
+	}
+}
+
+func TestLoadWithRepo_HooksMerge(t *testing.T) {
+	globalContent := `[hooks]
+post_add = ["direnv allow"]
+pre_delete = ["make check"]
+`
+	tests := []struct {
+		name        string
+		repoContent string
+		wantPostAdd []string
+		wantSource  func(global, repo string) string
+	}{
+		{
+			name: "replace by default",
+			repoContent: `[hooks]
+post_add = ["npm install"]
+post_prune = ["make clean"]
+`,
+			wantPostAdd: []string{"npm install"},
+			wantSource:  func(global, repo string) string { return repo },
+		},
+		{
+			name: "append",
+			repoContent: `hooks_merge = "append"
+
+[hooks]
+post_add = ["npm install"]
+post_prune = ["make clean"]
+`,
+			wantPostAdd: []string{"direnv allow", "npm install"},
+			wantSource:  func(global, repo string) string { return global + ", " + repo },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalConfig := filepath.Join(t.TempDir(), "config.toml")
+			repoDir := t.TempDir()
+			repoConfig := filepath.Join(repoDir, ".git-wt.toml")
+			if err := os.WriteFile(globalConfig, []byte(globalContent), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(repoConfig, []byte(tt.repoContent), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadWithRepo(globalConfig, repoDir)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			effective, sources, err := LoadEffective(globalConfig, repoDir)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for _, c := range []*Config{cfg, effective} {
+				if !reflect.DeepEqual(c.Hooks.PostAdd, tt.wantPostAdd) {
+					t.Errorf("post_add = %v, want %v", c.Hooks.PostAdd, tt.wantPostAdd)
+				}
+				// A list the repo does not set is inherited either way
+				if !reflect.DeepEqual(c.Hooks.PreDelete, []string{"make check"}) {
+					t.Errorf("pre_delete = %v, want [make check]", c.Hooks.PreDelete)
+				}
+				if !reflect.DeepEqual(c.Hooks.PostPrune, []string{"make clean"}) {
+					t.Errorf("post_prune = %v, want [make clean]", c.Hooks.PostPrune)
+				}
+			}
+			if want := tt.wantSource(globalConfig, repoConfig); sources["hooks.post_add"] != want {
+				t.Errorf("post_add source = %q, want %q", sources["hooks.post_add"], want)
+			}
+			if sources["hooks.pre_delete"] != globalConfig {
+				t.Errorf("pre_delete source = %q, want %q", sources["hooks.pre_delete"], globalConfig)
+			}
+			// Appending to an empty global list takes only the repo's
+			if sources["hooks.post_prune"] != repoConfig {
+				t.Errorf("post_prune source = %q, want %q", sources["hooks.post_prune"], repoConfig)
+			}
+		})
+	}
+}
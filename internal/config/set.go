@@ -0,0 +1,420 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// valueKind is the TOML type of a key settable with SetValue
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindInt
+	kindBool
+	kindList
+)
+
+var tableHeader = regexp.MustCompile(`^\s*\[([^\[\]]+)\]\s*(#.*)?$`)
+
+// walkOptions calls fn with the dotted key (e.g. "hooks.post_add") and type of
+// each option, derived from the Config struct's toml tags in field order.
+// Nested tables are walked into; pointers are reported as their element type.
+func walkOptions(fn func(key string, t reflect.Type)) {
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := prefix + field.Tag.Get("toml")
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				walk(ft, name+".")
+				continue
+			}
+			fn(name, ft)
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+}
+
+// Keys returns every config option key in Config field order, including
+// map-valued ones like label_subdirs
+func Keys() []string {
+	var keys []string
+	walkOptions(func(key string, _ reflect.Type) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// settableKeys maps dotted config keys (e.g. "hooks.post_add") to their kind,
+// derived from the Config struct's toml tags. Map-valued options are omitted.
+func settableKeys() map[string]valueKind {
+	keys := make(map[string]valueKind)
+	walkOptions(func(key string, t reflect.Type) {
+		switch {
+		case t.Kind() == reflect.String:
+			keys[key] = kindString
+		case t.Kind() == reflect.Int:
+			keys[key] = kindInt
+		case t.Kind() == reflect.Bool:
+			keys[key] = kindBool
+		case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+			keys[key] = kindList
+		}
+	})
+	return keys
+}
+
+// SetValue sets key to value in the TOML config file at path, creating the
+// file if needed. List keys accept a JSON or TOML array (a bare value is a
+// single-item list); with appendValue the items are added to the existing
+// list instead of replacing it. Comments and other keys are left untouched.
+// Returns the value that was written.
+func SetValue(path, key, value string, appendValue bool) (interface{}, error) {
+	kind, ok := settableKeys()[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown config key: %s", key)
+	}
+	if appendValue && kind != kindList {
+		return nil, fmt.Errorf("--append requires a list key, %s is not a list", key)
+	}
+
+	parsed, err := parseValue(kind, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if appendValue {
+		existing, err := existingList(data, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", path, err)
+		}
+		parsed = append(existing, parsed.([]string)...)
+	}
+
+	literal, err := tomlLiteral(parsed)
+	if err != nil {
+		return nil, err
+	}
+	updated := setTOMLKey(string(data), key, literal)
+
+	// Refuse to write a file that no longer parses
+	var check Config
+	if err := toml.Unmarshal([]byte(updated), &check); err != nil {
+		return nil, fmt.Errorf("refusing to write invalid config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	return parsed, nil
+}
+
+// UnsetValue removes key from the TOML config file at path so the value falls
+// back to the next config layer or the default. Comments and other keys are
+// left untouched. Reports whether the key was present.
+func UnsetValue(path, key string) (bool, error) {
+	if _, ok := settableKeys()[key]; !ok {
+		return false, fmt.Errorf("unknown config key: %s", key)
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	updated, removed := unsetTOMLKey(string(data), key)
+	if !removed {
+		return false, nil
+	}
+
+	// Refuse to write a file that no longer parses
+	var check Config
+	if err := toml.Unmarshal([]byte(updated), &check); err != nil {
+		return false, fmt.Errorf("refusing to write invalid config: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return false, fmt.Errorf("failed to write config file: %w", err)
+	}
+	return true, nil
+}
+
+// Value returns the value of a settable key in cfg, or false for an unknown
+// key. An unset use_relative_paths reports its effective default and an
+// unset list is empty rather than nil.
+func Value(cfg *Config, key string) (interface{}, bool) {
+	if _, ok := settableKeys()[key]; !ok {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(cfg).Elem()
+	for _, part := range strings.Split(key, ".") {
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Tag.Get("toml") == part {
+				v = v.Field(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			if key == "use_relative_paths" {
+				return cfg.RelativePaths(), true
+			}
+			return reflect.Zero(v.Type().Elem()).Interface(), true
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return []string{}, true
+	}
+	return v.Interface(), true
+}
+
+// parseValue converts a command-line value to the Go type for kind
+func parseValue(kind valueKind, value string) (interface{}, error) {
+	switch kind {
+	case kindInt:
+		return strconv.Atoi(value)
+	case kindBool:
+		return strconv.ParseBool(value)
+	case kindList:
+		return parseList(value)
+	default:
+		return value, nil
+	}
+}
+
+// parseList parses a JSON array (["a", "b"]) or TOML array (['a', "b"]) of
+// strings. Anything not starting with "[" is a single-item list.
+func parseList(value string) ([]string, error) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "[") {
+		return []string{value}, nil
+	}
+
+	var list []string
+	if err := json.Unmarshal([]byte(trimmed), &list); err == nil {
+		return list, nil
+	}
+	var wrapper struct {
+		V []string `toml:"v"`
+	}
+	if _, err := toml.Decode("v = "+trimmed, &wrapper); err != nil {
+		return nil, fmt.Errorf("expected a JSON or TOML array of strings")
+	}
+	if wrapper.V == nil {
+		return []string{}, nil
+	}
+	return wrapper.V, nil
+}
+
+// existingList returns the current items of a list key in a TOML document
+func existingList(data []byte, key string) ([]string, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var current interface{} = doc
+	for _, part := range strings.Split(key, ".") {
+		table, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		current = table[part]
+	}
+
+	items, _ := current.([]interface{})
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
+
+// tomlLiteral renders a value as it would appear on the right of "key = "
+func tomlLiteral(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]interface{}{"v": value}); err != nil {
+		return "", fmt.Errorf("failed to encode value: %w", err)
+	}
+	return strings.TrimSpace(strings.TrimPrefix(buf.String(), "v = ")), nil
+}
+
+// setTOMLKey replaces (or adds) a single "name = literal" assignment in a TOML
+// document, leaving every other line as is. A dotted key such as
+// "hooks.post_add" lives in the [hooks] table; the table is appended when
+// missing. Top-level keys are added before the first table header.
+func setTOMLKey(doc, key, literal string) string {
+	table, name := "", key
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		table, name = key[:i], key[i+1:]
+	}
+	assignment := name + " = " + literal
+
+	lines := strings.Split(doc, "\n")
+	if doc == "" {
+		lines = nil
+	}
+	assign := regexp.MustCompile(`^\s*"?` + regexp.QuoteMeta(name) + `"?\s*=`)
+
+	current := ""
+	tableFound := table == ""
+	firstHeader, lastInTable := -1, -1
+	for i := 0; i < len(lines); i++ {
+		if m := tableHeader.FindStringSubmatch(lines[i]); m != nil {
+			current = strings.TrimSpace(m[1])
+			if firstHeader < 0 {
+				firstHeader = i
+			}
+			if current == table {
+				tableFound = true
+				lastInTable = i
+			}
+			continue
+		}
+		if current != table {
+			continue
+		}
+		if strings.TrimSpace(lines[i]) != "" && !strings.HasPrefix(strings.TrimSpace(lines[i]), "#") {
+			lastInTable = i
+		}
+		if !assign.MatchString(lines[i]) {
+			continue
+		}
+
+		// Replace the assignment, including continuation lines of a
+		// multi-line array
+		end := i
+		depth := bracketDepth(lines[i][strings.Index(lines[i], "=")+1:])
+		for depth > 0 && end+1 < len(lines) {
+			end++
+			depth += bracketDepth(lines[end])
+		}
+		replaced := append([]string{}, lines[:i]...)
+		replaced = append(replaced, assignment)
+		replaced = append(replaced, lines[end+1:]...)
+		return strings.Join(replaced, "\n")
+	}
+
+	var out []string
+	switch {
+	case table == "" && firstHeader >= 0:
+		out = append(out, lines[:firstHeader]...)
+		out = append(out, assignment, "")
+		out = append(out, lines[firstHeader:]...)
+	case tableFound && table != "":
+		out = append(out, lines[:lastInTable+1]...)
+		out = append(out, assignment)
+		out = append(out, lines[lastInTable+1:]...)
+	default:
+		out = lines
+		if len(out) > 0 && out[len(out)-1] == "" {
+			out = out[:len(out)-1]
+		}
+		if table != "" {
+			if len(out) > 0 {
+				out = append(out, "")
+			}
+			out = append(out, "["+table+"]")
+		}
+		out = append(out, assignment, "")
+	}
+	return strings.Join(out, "\n")
+}
+
+// bracketDepth returns the net count of "[" minus "]" in a line of TOML,
+// ignoring brackets inside strings and comments
+func bracketDepth(line string) int {
+	depth := 0
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return depth
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		}
+	}
+	return depth
+}
+
+// unsetTOMLKey removes a "name = ..." assignment, including continuation
+// lines of a multi-line array, from the table a dotted key lives in
+func unsetTOMLKey(doc, key string) (string, bool) {
+	table, name := "", key
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		table, name = key[:i], key[i+1:]
+	}
+	assign := regexp.MustCompile(`^\s*"?` + regexp.QuoteMeta(name) + `"?\s*=`)
+
+	lines := strings.Split(doc, "\n")
+	current := ""
+	for i := 0; i < len(lines); i++ {
+		if m := tableHeader.FindStringSubmatch(lines[i]); m != nil {
+			current = strings.TrimSpace(m[1])
+			continue
+		}
+		if current != table || !assign.MatchString(lines[i]) {
+			continue
+		}
+
+		end := i
+		depth := bracketDepth(lines[i][strings.Index(lines[i], "=")+1:])
+		for depth > 0 && end+1 < len(lines) {
+			end++
+			depth += bracketDepth(lines[end])
+		}
+		kept := append([]string{}, lines[:i]...)
+		kept = append(kept, lines[end+1:]...)
+		return strings.Join(kept, "\n"), true
+	}
+	return doc, false
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetValue_ReplaceHookArray(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `# my settings
+worktree_root = "/custom/path"
+
+[hooks]
+# install deps
+post_add = [
+  "echo old",
+  "echo older",
+]
+post_clone = ["echo clone"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []string{`["npm install", "make"]`, `['npm install', "make"]`} {
+		if _, err := SetValue(configPath, "hooks.post_add", value, false); err != nil {
+			t.Fatalf("SetValue(%s) returned error: %v", value, err)
+		}
+
+		cfg, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("expected valid config, got %v", err)
+		}
+		if !reflect.DeepEqual(cfg.Hooks.PostAdd, []string{"npm install", "make"}) {
+			t.Errorf("post_add = %v, want [npm install make]", cfg.Hooks.PostAdd)
+		}
+		if !reflect.DeepEqual(cfg.Hooks.PostClone, []string{"echo clone"}) {
+			t.Errorf("post_clone changed: %v", cfg.Hooks.PostClone)
+		}
+		if cfg.WorktreeRoot != "/custom/path" {
+			t.Errorf("worktree_root changed: %s", cfg.WorktreeRoot)
+		}
+	}
+
+	data, _ := os.ReadFile(configPath)
+	for _, comment := range []string{"# my settings", "# install deps"} {
+		if !strings.Contains(string(data), comment) {
+			t.Errorf("expected comment %q to be preserved:\n%s", comment, data)
+		}
+	}
+}
+
+func TestSetValue_AppendHookArray(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[hooks]
+post_add = ["npm install"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SetValue(configPath, "hooks.post_add", "make setup", true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	value, err := SetValue(configPath, "hooks.post_add", `["echo a", "echo b"]`, true)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{"npm install", "make setup", "echo a", "echo b"}
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("returned value = %v, want %v", value, expected)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Hooks.PostAdd, expected) {
+		t.Errorf("post_add = %v, want %v", cfg.Hooks.PostAdd, expected)
+	}
+}
+
+func TestSetValue_NewFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "nested", "config.toml")
+
+	if _, err := SetValue(configPath, "hooks.post_clone", "npm ci", true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := SetValue(configPath, "git_timeout", "60", false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := SetValue(configPath, "use_relative_paths", "false", false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Hooks.PostClone, []string{"npm ci"}) {
+		t.Errorf("post_clone = %v, want [npm ci]", cfg.Hooks.PostClone)
+	}
+	if cfg.GitTimeout != 60 {
+		t.Errorf("git_timeout = %d, want 60", cfg.GitTimeout)
+	}
+	if cfg.RelativePaths() {
+		t.Error("expected use_relative_paths = false")
+	}
+}
+
+func TestSetValue_Errors(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	tests := []struct {
+		name   string
+		key    string
+		value  string
+		append bool
+	}{
+		{"unknown key", "no_such_key", "x", false},
+		{"map key", "label_subdirs", "x", false},
+		{"append to scalar", "default_remote", "upstream", true},
+		{"bad int", "git_timeout", "soon", false},
+		{"bad bool", "guess_remote", "maybe", false},
+		{"bad array", "hooks.post_add", "[1, 2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SetValue(configPath, tt.key, tt.value, tt.append); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		t.Error("expected no config file to be written on error")
+	}
+}
+
+func TestUnsetValue(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `# my settings
+default_remote = "upstream"
+git_timeout = 60
+
+[hooks]
+# install deps
+post_add = [
+  "npm install",
+  "make",
+]
+post_clone = ["echo clone"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"hooks.post_add", "default_remote"} {
+		removed, err := UnsetValue(configPath, key)
+		if err != nil {
+			t.Fatalf("UnsetValue(%s) returned error: %v", key, err)
+		}
+		if !removed {
+			t.Errorf("UnsetValue(%s) reported nothing removed", key)
+		}
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+	if cfg.DefaultRemote != DefaultConfig().DefaultRemote {
+		t.Errorf("default_remote = %q, want the default", cfg.DefaultRemote)
+	}
+	if len(cfg.Hooks.PostAdd) != 0 {
+		t.Errorf("post_add = %v, want empty", cfg.Hooks.PostAdd)
+	}
+	if !reflect.DeepEqual(cfg.Hooks.PostClone, []string{"echo clone"}) {
+		t.Errorf("post_clone changed: %v", cfg.Hooks.PostClone)
+	}
+	if cfg.GitTimeout != 60 {
+		t.Errorf("git_timeout changed: %d", cfg.GitTimeout)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	for _, comment := range []string{"# my settings", "# install deps"} {
+		if !strings.Contains(string(data), comment) {
+			t.Errorf("expected comment %q to be preserved:\n%s", comment, data)
+		}
+	}
+
+	removed, err := UnsetValue(configPath, "default_remote")
+	if err != nil || removed {
+		t.Errorf("second UnsetValue = %v, %v; want false, nil", removed, err)
+	}
+	if _, err := UnsetValue(configPath, "no_such_key"); err == nil {
+		t.Error("expected error for unknown key")
+	}
+	if removed, err := UnsetValue(filepath.Join(t.TempDir(), "missing.toml"), "default_remote"); err != nil || removed {
+		t.Errorf("UnsetValue on missing file = %v, %v; want false, nil", removed, err)
+	}
+}
+
+func TestValue(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Hooks.PostAdd = []string{"npm install"}
+
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{"default_remote", "origin"},
+		{"git_timeout", cfg.GitTimeout},
+		{"guess_remote", false},
+		{"use_relative_paths", true},
+		{"hooks.post_add", []string{"npm install"}},
+	}
+	for _, tt := range tests {
+		got, ok := Value(cfg, tt.key)
+		if !ok {
+			t.Errorf("Value(%s) reported unknown key", tt.key)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Value(%s) = %#v, want %#v", tt.key, got, tt.want)
+		}
+	}
+
+	for _, key := range []string{"no_such_key", "label_subdirs", "hooks"} {
+		if _, ok := Value(cfg, key); ok {
+			t.Errorf("Value(%s) should report an unknown key", key)
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	keys := Keys()
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		seen[key] = true
+	}
+
+	// Every settable key is listed, plus the table options, but not the
+	// hooks table itself
+	for key := range settableKeys() {
+		if !seen[key] {
+			t.Errorf("expected %s in Keys()", key)
+		}
+	}
+	for _, key := range []string{"worktree_git_config", "label_subdirs", "command_defaults"} {
+		if !seen[key] {
+			t.Errorf("expected table option %s in Keys()", key)
+		}
+	}
+	if seen["hooks"] {
+		t.Error("expected hooks to be listed by its hook keys only")
+	}
+	if keys[0] != "worktree_root" {
+		t.Errorf("expected Config field order, got %s first", keys[0])
+	}
+}
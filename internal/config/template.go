@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const templateHeader = `# ============================================================
+# git-wt configuration
+# Generated by: git wt config init
+# Uncomment and modify options as needed
+# ============================================================
+`
+
+// GenerateConfigTemplate returns a config file template with all options
+// commented out. It is derived from the Config struct tags, so every option
+// is listed with its documentation and default value.
+func GenerateConfigTemplate() string {
+	var b strings.Builder
+	b.WriteString(templateHeader)
+
+	defaults := reflect.ValueOf(DefaultConfig()).Elem()
+	t := defaults.Type()
+	section := ""
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := tomlKey(field)
+		if key == "" {
+			continue
+		}
+
+		if s := field.Tag.Get("section"); s != "" && s != section {
+			section = s
+			fmt.Fprintf(&b, "\n# --- %s ---\n", section)
+		}
+		b.WriteString("\n")
+		writeComment(&b, field.Tag.Get("comment"))
+		if applies := field.Tag.Get("applies"); applies != "" {
+			fmt.Fprintf(&b, "# Applies to: %s\n", applies)
+		}
+		if flag := field.Tag.Get("flag"); flag != "" {
+			fmt.Fprintf(&b, "# Flag: %s\n", flag)
+		}
+		writeComment(&b, templateEntry(key, field, defaults.Field(i)))
+	}
+	return b.String()
+}
+
+// tomlKey returns the config file key of a struct field, or "" if it has none
+func tomlKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+	if key == "-" {
+		return ""
+	}
+	return key
+}
+
+// templateEntry renders the (uncommented) template lines for one option:
+// key = value for scalars and arrays, a [table] for maps and structs
+func templateEntry(key string, field reflect.StructField, value reflect.Value) string {
+	example := field.Tag.Get("example")
+
+	switch field.Type.Kind() {
+	case reflect.Map:
+		if example != "" {
+			return example
+		}
+		return "[" + key + "]"
+	case reflect.Struct:
+		lines := []string{"[" + key + "]"}
+		for i := 0; i < field.Type.NumField(); i++ {
+			sub := field.Type.Field(i)
+			if subKey := tomlKey(sub); subKey != "" {
+				lines = append(lines, templateEntry(subKey, sub, value.Field(i)))
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	if example != "" {
+		return key + " = " + example
+	}
+	return key + " = " + formatTOMLValue(value)
+}
+
+// formatTOMLValue formats a scalar or array default as a TOML value
+func formatTOMLValue(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.String:
+		return strconv.Quote(value.String())
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Slice:
+		items := make([]string, value.Len())
+		for i := range items {
+			items[i] = formatTOMLValue(value.Index(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Pointer:
+		if value.IsNil() {
+			return formatTOMLValue(reflect.Zero(value.Type().Elem()))
+		}
+		return formatTOMLValue(value.Elem())
+	default:
+		return fmt.Sprintf("%v", value.Interface())
+	}
+}
+
+// writeComment writes text as "# " comment lines
+func writeComment(b *strings.Builder, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString("# " + line + "\n")
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestGenerateConfigTemplate_AllFields(t *testing.T) {
+	template := GenerateConfigTemplate()
+
+	var check func(typ reflect.Type, prefix string)
+	check = func(typ reflect.Type, prefix string) {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			key := tomlKey(field)
+			if key == "" {
+				continue
+			}
+			if !strings.Contains(template, "# "+key+" = ") && !strings.Contains(template, "# ["+prefix+key) {
+				t.Errorf("template is missing option %s%s", prefix, key)
+			}
+			if field.Type.Kind() == reflect.Struct {
+				check(field.Type, key+".")
+			}
+		}
+	}
+	check(reflect.TypeOf(Config{}), "")
+}
+
+// Uncommenting every option must give a valid config: top-level keys have to
+// come before the first table
+func TestGenerateConfigTemplate_Uncommented(t *testing.T) {
+	option := regexp.MustCompile(`^# ((\[|"[^"]*" = |[a-z_]+ = ).*)$`)
+
+	var lines []string
+	for _, line := range strings.Split(GenerateConfigTemplate(), "\n") {
+		if m := option.FindStringSubmatch(line); m != nil {
+			lines = append(lines, m[1])
+		}
+	}
+
+	var cfg Config
+	if _, err := toml.Decode(strings.Join(lines, "\n"), &cfg); err != nil {
+		t.Fatalf("uncommented template is not valid: %v\n%s", err, strings.Join(lines, "\n"))
+	}
+	if cfg.HookWorkdir != "worktree" || cfg.DefaultRemote != "origin" {
+		t.Errorf("expected top-level options to stay top-level, got hook_workdir=%q default_remote=%q", cfg.HookWorkdir, cfg.DefaultRemote)
+	}
+	if cfg.LabelSubdirs["bug"] != "bugs" {
+		t.Errorf("expected label_subdirs example, got %v", cfg.LabelSubdirs)
+	}
+}
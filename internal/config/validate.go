@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// branchTemplatePlaceholders are the variables github.RenderBranchName expands
+var branchTemplatePlaceholders = []string{"{{type}}", "{{number}}", "{{slug}}", "{{author}}", "{{label}}", "{{date}}"}
+
+// Problem is a single issue found in a config file
+type Problem struct {
+	Path    string `json:"path"`
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	if p.Key == "" {
+		return fmt.Sprintf("%s: %s", p.Path, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.Path, p.Key, p.Message)
+}
+
+// Validate checks the config file at path for unknown keys (which Load
+// silently ignores) and invalid values. A missing file has no problems.
+func Validate(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var cfg Config
+	md, err := toml.Decode(string(data), &cfg)
+	if err != nil {
+		return []Problem{{Path: path, Message: err.Error()}}, nil
+	}
+
+	var problems []Problem
+	for _, key := range md.Undecoded() {
+		problems = append(problems, Problem{Path: path, Key: key.String(), Message: "unknown key"})
+	}
+
+	timeouts := map[string]int{
+		"git_timeout":      cfg.GitTimeout,
+		"git_long_timeout": cfg.GitLongTimeout,
+		"hook_timeout":     cfg.HookTimeout,
+	}
+	for key, value := range timeouts {
+		if md.IsDefined(key) && value <= 0 {
+			problems = append(problems, Problem{Path: path, Key: key, Message: fmt.Sprintf("must be a positive number of seconds, got %d", value)})
+		}
+	}
+
+	if md.IsDefined("branch_template") && !hasBranchPlaceholder(cfg.BranchTemplate) {
+		problems = append(problems, Problem{Path: path, Key: "branch_template", Message: "must contain at least one of " + strings.Join(branchTemplatePlaceholders, ", ")})
+	}
+
+	if md.IsDefined("hooks_merge") && cfg.HooksMerge != HooksMergeReplace && cfg.HooksMerge != HooksMergeAppend {
+		problems = append(problems, Problem{Path: path, Key: "hooks_merge", Message: fmt.Sprintf("must be %q or %q, got %q", HooksMergeReplace, HooksMergeAppend, cfg.HooksMerge)})
+	}
+
+	if md.IsDefined("default_remote") && strings.TrimSpace(cfg.DefaultRemote) == "" {
+		problems = append(problems, Problem{Path: path, Key: "default_remote", Message: "must not be empty"})
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems, nil
+}
+
+func hasBranchPlaceholder(template string) bool {
+	for _, placeholder := range branchTemplatePlaceholders {
+		if strings.Contains(template, placeholder) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // problem keys
+	}{
+		{
+			name: "valid",
+			content: `git_timeout = 30
+branch_template = "{{type}}/{{number}}"
+default_remote = "upstream"
+
+[worktree_git_config]
+"user.email" = "me@example.com"
+
+[label_subdirs]
+bug = "fixes"
+
+[hooks]
+post_add = ["npm install"]
+`,
+		},
+		{
+			name:    "unknown keys",
+			content: "git_timout = 30\n\n[hooks]\npost_ad = [\"x\"]\n",
+			want:    []string{"git_timout", "hooks.post_ad"},
+		},
+		{
+			name:    "non-positive timeouts",
+			content: "git_timeout = 0\nhook_timeout = -5\ngit_long_timeout = 600\n",
+			want:    []string{"git_timeout", "hook_timeout"},
+		},
+		{
+			name:    "template without placeholders",
+			content: "branch_template = \"fixed-name\"\n",
+			want:    []string{"branch_template"},
+		},
+		{
+			name:    "unknown hooks_merge",
+			content: "hooks_merge = \"prepend\"\n",
+			want:    []string{"hooks_merge"},
+		},
+		{
+			name:    "empty default remote",
+			content: "default_remote = \"\"\n",
+			want:    []string{"default_remote"},
+		},
+		{
+			name:    "wrong type",
+			content: "git_timeout = \"soon\"\n",
+			want:    []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			problems, err := Validate(path)
+			if err != nil {
+				t.Fatalf("Validate returned error: %v", err)
+			}
+			var keys []string
+			for _, p := range problems {
+				if p.Path != path {
+					t.Errorf("problem path = %s, want %s", p.Path, path)
+				}
+				keys = append(keys, p.Key)
+			}
+			if !reflect.DeepEqual(keys, tt.want) {
+				t.Errorf("problem keys = %v, want %v (%v)", keys, tt.want, problems)
+			}
+		})
+	}
+}
+
+func TestValidate_MissingFile(t *testing.T) {
+	problems, err := Validate(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil || problems != nil {
+		t.Errorf("Validate(missing) = %v, %v; want nil, nil", problems, err)
+	}
+}
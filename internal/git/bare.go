@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // Constants for bare repo structure
@@ -30,19 +31,61 @@ func BareCloneWithTimeout(url, targetDir string, timeoutSec int, extraArgs ...st
 	args = append(args, extraArgs...)
 	args = append(args, url, bareDir)
 
-	// Clone as bare with progress shown to user
-	if err := RunWithProgressAndTimeout(targetDir, timeoutSec, args...); err != nil {
+	// Clone as bare with progress shown to user, failing fast on auth prompts
+	if err := runWithProgress(targetDir, timeoutSec, NonInteractiveEnv, args...); err != nil {
 		return fmt.Errorf("failed to clone: %w", err)
 	}
 
+	return setupBareRepo(targetDir, timeoutSec)
+}
+
+// ResumeBareClone completes an interrupted bare clone by re-running the
+// post-clone setup and fetching whatever objects are still missing
+func ResumeBareClone(targetDir string, timeoutSec int) error {
+	if !IsResumableClone(targetDir) {
+		return fmt.Errorf("no resumable bare repository in %s", targetDir)
+	}
+	return setupBareRepo(targetDir, timeoutSec)
+}
+
+// IsResumableClone checks if targetDir holds a bare repo with an origin
+// remote whose setup never finished: the .git pointer is missing or nothing
+// has been fetched into refs/remotes/origin yet. A complete project is not
+// resumable.
+func IsResumableClone(targetDir string) bool {
+	bareDir := filepath.Join(targetDir, BareDir)
+	if info, err := os.Stat(bareDir); err != nil || !info.IsDir() {
+		return false
+	}
+
+	output, err := RunInDir(bareDir, "rev-parse", "--is-bare-repository")
+	if err != nil || output != "true" {
+		return false
+	}
+
+	if _, err := RunInDir(bareDir, "config", "--get", "remote.origin.url"); err != nil {
+		return false
+	}
+
+	if !IsBareRepo(targetDir) {
+		return true
+	}
+	refs, err := RunInDir(bareDir, "for-each-ref", "--count=1", "--format=%(refname)", "refs/remotes/origin/")
+	return err == nil && refs == ""
+}
+
+// setupBareRepo creates the .git pointer, configures the fetch refspec and
+// fetches remote tracking branches for a freshly cloned (or resumed) bare repo
+func setupBareRepo(targetDir string, timeoutSec int) error {
+	bareDir := filepath.Join(targetDir, BareDir)
+
 	// Create .git file pointing to .bare
-	gitFile := filepath.Join(targetDir, GitPointerFile)
-	if err := os.WriteFile(gitFile, []byte(fmt.Sprintf("gitdir: ./%s\n", BareDir)), 0644); err != nil {
-		return fmt.Errorf("failed to create .git file: %w", err)
+	if err := WriteGitPointer(targetDir); err != nil {
+		return err
 	}
 
 	// Configure fetch to get all remote branches
-	if _, err := RunInDirWithTimeout(bareDir, timeoutSec, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*"); err != nil {
+	if _, err := RunInDirWithTimeout(bareDir, timeoutSec, "config", "remote.origin.fetch", fetchRefspec("origin")); err != nil {
 		return fmt.Errorf("failed to configure fetch: %w", err)
 	}
 
@@ -100,6 +143,72 @@ func GetProjectRoot(worktreePath string) (string, error) {
 	return "", fmt.Errorf("not in a git-wt project")
 }
 
+// FindBareRoot walks up from path to the nearest directory containing a
+// .bare directory. Unlike GetProjectRoot it does not require a valid .git
+// pointer, so a project with a missing or broken pointer can still be found.
+func FindBareRoot(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+	for {
+		if info, err := os.Stat(filepath.Join(dir, BareDir)); err == nil && info.IsDir() {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("not in a git-wt project")
+		}
+		dir = parent
+	}
+}
+
+// CheckGitPointer verifies that the project root's .git file points at its
+// .bare directory
+func CheckGitPointer(projectRoot string) error {
+	gitFile := filepath.Join(projectRoot, GitPointerFile)
+	info, err := os.Stat(gitFile)
+	if err != nil {
+		return fmt.Errorf("%s is missing", GitPointerFile)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory, not a pointer file", GitPointerFile)
+	}
+
+	target, err := WorktreeGitDir(projectRoot)
+	if err != nil {
+		return err
+	}
+	if resolvePath(target) != resolvePath(filepath.Join(projectRoot, BareDir)) {
+		return fmt.Errorf("%s points to %s", GitPointerFile, target)
+	}
+	return nil
+}
+
+// WriteGitPointer (re)writes the project root's .git file to point at .bare
+func WriteGitPointer(projectRoot string) error {
+	gitFile := filepath.Join(projectRoot, GitPointerFile)
+	if err := os.WriteFile(gitFile, []byte(fmt.Sprintf("gitdir: ./%s\n", BareDir)), 0644); err != nil {
+		return fmt.Errorf("failed to create .git file: %w", err)
+	}
+	return nil
+}
+
+// HasRemoteHead reports whether refs/remotes/<remote>/HEAD is set
+func HasRemoteHead(projectRoot, remote string) bool {
+	_, err := RunInDir(projectRoot, "symbolic-ref", "--quiet", "refs/remotes/"+remote+"/HEAD")
+	return err == nil
+}
+
+// SetRemoteHead sets refs/remotes/<remote>/HEAD from the remote's default
+// branch (git remote set-head --auto), which queries the remote
+func SetRemoteHead(projectRoot, remote string, timeoutSec int) error {
+	if _, err := RunInDirWithTimeout(projectRoot, timeoutSec, "remote", "set-head", remote, "--auto"); err != nil {
+		return fmt.Errorf("failed to set %s/HEAD: %w", remote, err)
+	}
+	return nil
+}
+
 // GetDefaultBranch returns the default branch name (main or master)
 func GetDefaultBranch(dir string) (string, error) {
 	// Try to get from remote HEAD
@@ -124,3 +233,16 @@ func GetDefaultBranch(dir string) (string, error) {
 	// Return error if neither exists
 	return "", fmt.Errorf("could not determine default branch: neither %s nor %s found", DefaultBranch, FallbackBranch)
 }
+
+// LastFetchTime returns when the bare repo last fetched, based on the mtime
+// of .bare/FETCH_HEAD. A zero time means the repo has never been fetched.
+func LastFetchTime(projectRoot string) (time.Time, error) {
+	info, err := os.Stat(filepath.Join(projectRoot, BareDir, "FETCH_HEAD"))
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read fetch time: %w", err)
+	}
+	return info.ModTime(), nil
+}
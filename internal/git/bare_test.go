@@ -1,9 +1,12 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestIsBareRepo(t *testing.T) {
@@ -47,3 +50,126 @@ func TestGetProjectRoot(t *testing.T) {
 		t.Errorf("expected %s, got %s", tmpDir, root)
 	}
 }
+
+func TestIsResumableClone(t *testing.T) {
+	src := initTestRepo(t)
+
+	// Empty directory is not resumable
+	target := t.TempDir()
+	if IsResumableClone(target) {
+		t.Error("expected false for directory without .bare")
+	}
+
+	// Simulate a clone interrupted after git created the bare repo
+	runTestGit(t, target, "clone", "--bare", src, filepath.Join(target, BareDir))
+	if !IsResumableClone(target) {
+		t.Fatal("expected true for bare repo without a .git pointer")
+	}
+
+	// Interrupted during the first fetch: pointer written, nothing fetched
+	if err := WriteGitPointer(target); err != nil {
+		t.Fatal(err)
+	}
+	if !IsResumableClone(target) {
+		t.Fatal("expected true for bare repo without remote tracking branches")
+	}
+
+	if err := ResumeBareClone(target, 60); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !IsBareRepo(target) {
+		t.Error("expected .git pointer to be created on resume")
+	}
+	if _, err := RunInDir(target, "rev-parse", "--verify", "refs/remotes/origin/main"); err != nil {
+		t.Errorf("expected remote tracking branch after resume: %v", err)
+	}
+	if IsResumableClone(target) {
+		t.Error("expected false for a completed clone")
+	}
+}
+
+func TestResumeBareClone_NotResumable(t *testing.T) {
+	if err := ResumeBareClone(t.TempDir(), 60); err == nil {
+		t.Error("expected error for directory without a bare repo")
+	}
+}
+
+func TestLastFetchTime(t *testing.T) {
+	projectRoot := t.TempDir()
+	if err := os.Mkdir(filepath.Join(projectRoot, BareDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Never fetched
+	fetched, err := LastFetchTime(projectRoot)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !fetched.IsZero() {
+		t.Errorf("expected zero time when never fetched, got %v", fetched)
+	}
+
+	fetchHead := filepath.Join(projectRoot, BareDir, "FETCH_HEAD")
+	if err := os.WriteFile(fetchHead, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(fetchHead, want, want); err != nil {
+		t.Fatal(err)
+	}
+
+	fetched, err = LastFetchTime(projectRoot)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !fetched.Equal(want) {
+		t.Errorf("expected %v, got %v", want, fetched)
+	}
+}
+
+func TestBareClone_NonInteractiveEnv(t *testing.T) {
+	// Stand-in git that records its environment and fails like an auth error
+	binDir := t.TempDir()
+	envFile := filepath.Join(t.TempDir(), "env")
+	script := "#!/bin/sh\nenv > \"" + envFile + "\"\necho 'fatal: could not read Username: terminal prompts disabled' >&2\nexit 128\n"
+	if err := os.WriteFile(filepath.Join(binDir, "git"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	err := BareCloneWithTimeout("https://example.com/private.git", t.TempDir(), 30)
+	if err == nil {
+		t.Fatal("expected clone to fail")
+	}
+	if !IsAuthError(err) {
+		t.Errorf("expected auth error, got %v", err)
+	}
+
+	data, readErr := os.ReadFile(envFile)
+	if readErr != nil {
+		t.Fatalf("expected fake git to record its environment: %v", readErr)
+	}
+	for _, want := range NonInteractiveEnv {
+		if !strings.Contains(string(data), want+"\n") {
+			t.Errorf("expected clone environment to contain %s", want)
+		}
+	}
+}
+
+func TestIsAuthError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{errors.New("fatal: Authentication failed for 'https://github.com/o/r.git/'"), true},
+		{errors.New("git@github.com: Permission denied (publickey)."), true},
+		{errors.New("fatal: repository 'x' does not exist"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAuthError(tt.err); got != tt.expected {
+			t.Errorf("IsAuthError(%v) = %v, want %v", tt.err, got, tt.expected)
+		}
+	}
+}
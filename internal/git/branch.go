@@ -0,0 +1,405 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CountUnpushedCommits returns the number of commits on a branch that are not
+// reachable from any remote-tracking ref (i.e. work that exists only locally)
+func CountUnpushedCommits(projectRoot, branchName string) (int, error) {
+	output, err := RunInDir(projectRoot, "rev-list", "--count", "refs/heads/"+branchName, "--not", "--remotes")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unpushed commits: %w", err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse commit count: %w", err)
+	}
+	return count, nil
+}
+
+// MergedBranches returns the local branches fully merged into base, per git
+// branch --merged. base itself is included.
+func MergedBranches(projectRoot, base string) (map[string]bool, error) {
+	output, err := RunInDir(projectRoot, "branch", "--merged", base, "--format=%(refname:short)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches merged into %s: %w", base, err)
+	}
+	merged := map[string]bool{}
+	for _, branch := range strings.Split(output, "\n") {
+		if branch = strings.TrimSpace(branch); branch != "" {
+			merged[branch] = true
+		}
+	}
+	return merged, nil
+}
+
+// CountUnmergedCommits returns the number of commits on a branch that are
+// neither merged into the default branch nor pushed to any remote, i.e. the
+// work lost by force-deleting the branch
+func CountUnmergedCommits(projectRoot, branchName, defaultBranch string) (int, error) {
+	args := []string{"rev-list", "--count", "refs/heads/" + branchName, "--not", "--remotes"}
+	if defaultBranch != "" {
+		if _, err := RunInDir(projectRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+defaultBranch); err == nil {
+			args = append(args, "refs/heads/"+defaultBranch)
+		}
+	}
+
+	output, err := RunInDir(projectRoot, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unmerged commits: %w", err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse commit count: %w", err)
+	}
+	return count, nil
+}
+
+// BranchExists reports whether a branch exists locally or on any remote
+func BranchExists(projectRoot, branchName string) bool {
+	if _, err := RunInDir(projectRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+branchName); err == nil {
+		return true
+	}
+	output, err := RunInDir(projectRoot, "for-each-ref", "--format=%(refname)", "refs/remotes/*/"+branchName)
+	return err == nil && strings.TrimSpace(output) != ""
+}
+
+// GuessRemoteBranch returns "<remote>/<branch>" when the branch exists on the
+// remote but not locally, mirroring git's --guess-remote. Returns "" otherwise.
+func GuessRemoteBranch(projectRoot, remote, branchName string) string {
+	if _, err := RunInDir(projectRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+branchName); err == nil {
+		return ""
+	}
+	if _, err := RunInDir(projectRoot, "rev-parse", "--verify", "--quiet", "refs/remotes/"+remote+"/"+branchName); err != nil {
+		return ""
+	}
+	return remote + "/" + branchName
+}
+
+// ResolveRef returns the commit SHA a ref points to. The ref may be a local
+// branch, a remote branch (origin/main), a tag, or a (short) commit SHA.
+func ResolveRef(projectRoot, ref string) (string, error) {
+	output, err := RunInDir(projectRoot, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil || output == "" {
+		return "", fmt.Errorf("ref not found: %s", ref)
+	}
+	return output, nil
+}
+
+// RemoteFromRef returns the remote a remote-tracking ref belongs to, e.g.
+// "upstream" for "upstream/feature" or "refs/remotes/upstream/feature".
+// Returns "" when ref is not a branch of a configured remote.
+func RemoteFromRef(projectRoot, ref string) string {
+	name := strings.TrimPrefix(ref, "refs/remotes/")
+	output, err := RunInDir(projectRoot, "remote")
+	if err != nil {
+		return ""
+	}
+
+	// Prefer the longest match so "up" does not shadow "up/stream"
+	match := ""
+	for _, remote := range strings.Fields(output) {
+		if strings.HasPrefix(name, remote+"/") && len(remote) > len(match) {
+			match = remote
+		}
+	}
+	if match == "" {
+		return ""
+	}
+	if _, err := RunInDir(projectRoot, "rev-parse", "--verify", "--quiet", "refs/remotes/"+name); err != nil {
+		return ""
+	}
+	return match
+}
+
+// ListBranches returns the names of local branches followed by remote
+// branches (as <remote>/<branch>), skipping remote HEAD aliases
+func ListBranches(projectRoot string) ([]string, error) {
+	output, err := RunInDir(projectRoot, "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	var branches []string
+	for _, ref := range strings.Split(output, "\n") {
+		if ref == "" || strings.HasSuffix(ref, "/HEAD") {
+			continue
+		}
+		if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+			branches = append(branches, name)
+		} else {
+			branches = append(branches, strings.TrimPrefix(ref, "refs/remotes/"))
+		}
+	}
+	return branches, nil
+}
+
+// GetExactTag returns a tag pointing at the commit checked out in a
+// worktree, or "" when there is none
+func GetExactTag(worktreePath string) string {
+	output, err := RunInDir(worktreePath, "describe", "--tags", "--exact-match", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return output
+}
+
+// GetCommitSubject returns the subject line of the commit checked out in a
+// worktree. Worktrees without commits return an empty subject.
+func GetCommitSubject(worktreePath string) (string, error) {
+	output, err := RunInDir(worktreePath, "log", "-1", "--format=%s")
+	if err != nil {
+		if _, headErr := RunInDir(worktreePath, "rev-parse", "--verify", "--quiet", "HEAD"); headErr != nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get commit subject: %w", err)
+	}
+	return output, nil
+}
+
+// FindRenamedRemoteBranch looks for the remote branch that a branch whose
+// remote-tracking ref disappeared may have been renamed to: the one its
+// upstream config (branch.<name>.merge) names, or failing that the only one
+// whose tip is the worktree's HEAD commit. A similar name alone is not enough,
+// and several branches at the commit are ambiguous. The remote's default
+// branch never counts. Returns "" when there is no candidate.
+func FindRenamedRemoteBranch(projectRoot, remote, branch, commit string) (string, error) {
+	prefix := "refs/remotes/" + remote + "/"
+	output, err := RunInDir(projectRoot, "for-each-ref", "--format=%(refname) %(objectname)", prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to list remote branches: %w", err)
+	}
+
+	exclude := map[string]bool{"HEAD": true, branch: true, DefaultBranch: true, FallbackBranch: true}
+	if head, err := RunInDir(projectRoot, "symbolic-ref", "--quiet", prefix+"HEAD"); err == nil {
+		exclude[strings.TrimPrefix(head, prefix)] = true
+	}
+
+	tips := make(map[string]string)
+	var atCommit []string
+	for _, line := range strings.Split(output, "\n") {
+		ref, sha, ok := strings.Cut(line, " ")
+		name := strings.TrimPrefix(ref, prefix)
+		if !ok || exclude[name] {
+			continue
+		}
+		tips[name] = sha
+		if commit != "" && sha == commit {
+			atCommit = append(atCommit, name)
+		}
+	}
+
+	// An upstream set to the new name (git branch -u) is explicit
+	if upstreamRemote, err := RunInDir(projectRoot, "config", "--get", "branch."+branch+".remote"); err == nil && upstreamRemote == remote {
+		if merge, err := RunInDir(projectRoot, "config", "--get", "branch."+branch+".merge"); err == nil {
+			if name := strings.TrimPrefix(merge, "refs/heads/"); tips[name] != "" {
+				return name, nil
+			}
+		}
+	}
+
+	if len(atCommit) == 1 {
+		return atCommit[0], nil
+	}
+	return "", nil
+}
+
+// GetLastCommitTime returns the committer time of the commit checked out in
+// a worktree. Worktrees without commits return the zero time.
+func GetLastCommitTime(worktreePath string) (time.Time, error) {
+	output, err := RunInDir(worktreePath, "log", "-1", "--format=%ct")
+	if err != nil {
+		if _, headErr := RunInDir(worktreePath, "rev-parse", "--verify", "--quiet", "HEAD"); headErr != nil {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get commit time: %w", err)
+	}
+	seconds, err := strconv.ParseInt(output, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse commit time %q: %w", output, err)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// UpstreamSync describes how a branch compares with its upstream
+type UpstreamSync struct {
+	Upstream string // e.g. origin/feature; "" when the branch tracks nothing
+	Ahead    int    // commits on HEAD not on the upstream
+	Behind   int    // commits on the upstream not on HEAD
+}
+
+// GetUpstreamSync returns how far the branch checked out in a worktree is
+// ahead of and behind its upstream. A branch without an upstream (or whose
+// upstream is gone) returns an empty Upstream.
+func GetUpstreamSync(worktreePath string) (UpstreamSync, error) {
+	upstream, err := RunInDir(worktreePath, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil || upstream == "" {
+		return UpstreamSync{}, nil
+	}
+
+	sync := UpstreamSync{Upstream: upstream}
+	output, err := RunInDir(worktreePath, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	if err != nil {
+		return sync, fmt.Errorf("failed to compare with %s: %w", upstream, err)
+	}
+	counts := strings.Fields(output)
+	if len(counts) != 2 {
+		return sync, fmt.Errorf("unexpected rev-list output %q", output)
+	}
+	if sync.Ahead, err = strconv.Atoi(counts[0]); err != nil {
+		return sync, fmt.Errorf("unexpected rev-list output %q", output)
+	}
+	if sync.Behind, err = strconv.Atoi(counts[1]); err != nil {
+		return sync, fmt.Errorf("unexpected rev-list output %q", output)
+	}
+	return sync, nil
+}
+
+// PullBranch updates the branch checked out in a worktree from its upstream,
+// fast-forward only unless rebase is set
+func PullBranch(worktreePath string, rebase bool, timeoutSec int) error {
+	mode := "--ff-only"
+	if rebase {
+		mode = "--rebase"
+	}
+	if _, err := RunInDirWithTimeout(worktreePath, timeoutSec, "pull", mode); err != nil {
+		return fmt.Errorf("failed to pull: %w", err)
+	}
+	return nil
+}
+
+// PushBranch publishes a branch to remote and sets it as the upstream
+func PushBranch(worktreePath, remote, branchName string, timeoutSec int) error {
+	if _, err := RunInDirWithTimeout(worktreePath, timeoutSec, "push", "--set-upstream", remote, branchName); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// PullRequestRef returns the ref a pull request's head is published under
+// on GitHub remotes, which also covers PRs opened from forks
+func PullRequestRef(number int) string {
+	return fmt.Sprintf("refs/pull/%d/head", number)
+}
+
+// FetchPullRequestHead fetches a pull request's head from remote into a new
+// local branch and makes the branch pull from it, like gh pr checkout does
+// for PRs from forks
+func FetchPullRequestHead(projectRoot, remote string, number int, branchName string, timeoutSec int) error {
+	ref := PullRequestRef(number)
+	if _, err := RunInDirWithTimeout(projectRoot, timeoutSec, "fetch", remote, ref+":refs/heads/"+branchName); err != nil {
+		return fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+	}
+	if _, err := RunInDir(projectRoot, "config", "branch."+branchName+".remote", remote); err != nil {
+		return fmt.Errorf("failed to set upstream for %s: %w", branchName, err)
+	}
+	if _, err := RunInDir(projectRoot, "config", "branch."+branchName+".merge", ref); err != nil {
+		return fmt.Errorf("failed to set upstream for %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// IsDetachedHead reports whether the repository or worktree at dir has a
+// detached HEAD
+func IsDetachedHead(dir string) bool {
+	if _, err := RunInDir(dir, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return false
+	}
+	_, err := RunInDir(dir, "symbolic-ref", "--quiet", "HEAD")
+	return err != nil
+}
+
+// GetLastCommitAuthor returns the author email of the commit checked out in a
+// worktree. Worktrees without commits return an empty email.
+func GetLastCommitAuthor(worktreePath string) (string, error) {
+	output, err := RunInDir(worktreePath, "log", "-1", "--format=%ae")
+	if err != nil {
+		if _, headErr := RunInDir(worktreePath, "rev-parse", "--verify", "--quiet", "HEAD"); headErr != nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get commit author: %w", err)
+	}
+	return output, nil
+}
+
+// SetBranchDescription stores description as branch.<name>.description, the
+// same setting git branch --edit-description writes
+func SetBranchDescription(dir, branchName, description string) error {
+	key := "branch." + branchName + ".description"
+	if _, err := RunInDir(dir, "config", key, description); err != nil {
+		return fmt.Errorf("failed to set %s: %w", key, err)
+	}
+	return nil
+}
+
+// BranchDescription returns a branch's description, or "" if it has none
+func BranchDescription(dir, branchName string) string {
+	output, err := RunInDir(dir, "config", "--get", "branch."+branchName+".description")
+	if err != nil {
+		return ""
+	}
+	return output
+}
+
+// UniqueBranchName returns name if it is free, otherwise the first of
+// name-2, name-3, ... that is neither an existing branch nor occupies an
+// existing worktree directory
+func UniqueBranchName(projectRoot, name string) string {
+	candidate := name
+	for n := 2; branchTaken(projectRoot, candidate); n++ {
+		candidate = fmt.Sprintf("%s-%d", name, n)
+	}
+	return candidate
+}
+
+// branchTaken reports whether a branch name or its worktree directory is in use
+func branchTaken(projectRoot, name string) bool {
+	if BranchExists(projectRoot, name) {
+		return true
+	}
+	_, err := os.Stat(WorktreePath(projectRoot, "", name))
+	return err == nil
+}
+
+// CountStashesByBranch returns the number of stash entries per branch.
+// Stashes are repo-wide in git, so each one is attributed to the branch it
+// was created on, as recorded in its message ("WIP on <branch>: ..." or
+// "On <branch>: ..."). Stashes made on a detached HEAD are not counted.
+func CountStashesByBranch(projectRoot string) (map[string]int, error) {
+	// git stash list needs a work tree; reading the reflog works from the bare repo
+	if _, err := RunInDir(projectRoot, "rev-parse", "--verify", "--quiet", "refs/stash"); err != nil {
+		return map[string]int{}, nil
+	}
+	output, err := RunInDir(projectRoot, "log", "--walk-reflogs", "--format=%gs", "refs/stash")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stashes: %w", err)
+	}
+	return parseStashBranches(output), nil
+}
+
+// parseStashBranches counts stash reflog subjects per originating branch
+func parseStashBranches(output string) map[string]int {
+	counts := make(map[string]int)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		var rest string
+		switch {
+		case strings.HasPrefix(line, "WIP on "):
+			rest = strings.TrimPrefix(line, "WIP on ")
+		case strings.HasPrefix(line, "On "):
+			rest = strings.TrimPrefix(line, "On ")
+		default:
+			continue
+		}
+		branch, _, found := strings.Cut(rest, ":")
+		if !found || branch == "(no branch)" {
+			continue
+		}
+		counts[branch]++
+	}
+	return counts
+}
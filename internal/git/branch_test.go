@@ -0,0 +1,437 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCountUnpushedCommits(t *testing.T) {
+	dir := initTestRepo(t)
+
+	runTestGit(t, dir, "checkout", "-b", "feature")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "local work")
+
+	// No remote-tracking refs yet: every commit is unpushed
+	count, err := CountUnpushedCommits(dir, "feature")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 unpushed commits, got %d", count)
+	}
+
+	// Simulate main having been pushed
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/main", "main")
+	count, err = CountUnpushedCommits(dir, "feature")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 unpushed commit, got %d", count)
+	}
+
+	// Simulate the feature branch having been pushed
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/feature", "feature")
+	count, err = CountUnpushedCommits(dir, "feature")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 unpushed commits, got %d", count)
+	}
+}
+
+func TestCountUnpushedCommits_MissingBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	if _, err := CountUnpushedCommits(dir, "does-not-exist"); err == nil {
+		t.Error("expected error for missing branch")
+	}
+}
+
+func TestCountUnmergedCommits(t *testing.T) {
+	dir := initTestRepo(t)
+
+	runTestGit(t, dir, "checkout", "-b", "feature")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "first")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "second")
+	runTestGit(t, dir, "checkout", "main")
+
+	// Commits on top of main that exist nowhere else
+	count, err := CountUnmergedCommits(dir, "feature", "main")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 unmerged commits, got %d", count)
+	}
+
+	// Pushing one commit leaves one at risk
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/feature", "feature~1")
+	count, err = CountUnmergedCommits(dir, "feature", "main")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 unmerged commit, got %d", count)
+	}
+
+	// Merging into the default branch makes the branch safe to delete
+	runTestGit(t, dir, "merge", "--ff-only", "feature")
+	count, err = CountUnmergedCommits(dir, "feature", "main")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 unmerged commits, got %d", count)
+	}
+}
+
+func TestCountUnmergedCommits_MissingDefaultBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "checkout", "-b", "feature")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "work")
+
+	// An unknown default branch is ignored rather than failing the count
+	count, err := CountUnmergedCommits(dir, "feature", "develop")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 unmerged commits, got %d", count)
+	}
+}
+
+func TestBranchExists(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "branch", "develop")
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/staging", "main")
+
+	tests := []struct {
+		branch   string
+		expected bool
+	}{
+		{"main", true},
+		{"develop", true},
+		{"staging", true},
+		{"missing", false},
+	}
+
+	for _, tt := range tests {
+		if got := BranchExists(dir, tt.branch); got != tt.expected {
+			t.Errorf("BranchExists(%q) = %v, want %v", tt.branch, got, tt.expected)
+		}
+	}
+}
+
+func TestBranchDescription(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "branch", "fix/login")
+
+	if got := BranchDescription(dir, "fix/login"); got != "" {
+		t.Errorf("expected no description, got %q", got)
+	}
+	if err := SetBranchDescription(dir, "fix/login", "Closes #42"); err != nil {
+		t.Fatalf("SetBranchDescription: %v", err)
+	}
+	if got := BranchDescription(dir, "fix/login"); got != "Closes #42" {
+		t.Errorf("BranchDescription = %q, want %q", got, "Closes #42")
+	}
+	if got := runTestGit(t, dir, "config", "branch.fix/login.description"); got != "Closes #42" {
+		t.Errorf("git config reports %q", got)
+	}
+}
+
+func TestGuessRemoteBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "branch", "local")
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/local", "main")
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/remote-only", "main")
+	runTestGit(t, dir, "update-ref", "refs/remotes/upstream/other", "main")
+
+	tests := []struct {
+		branch   string
+		expected string
+	}{
+		{"remote-only", "origin/remote-only"},
+		{"local", ""},
+		{"other", ""},
+		{"missing", ""},
+	}
+
+	for _, tt := range tests {
+		if got := GuessRemoteBranch(dir, "origin", tt.branch); got != tt.expected {
+			t.Errorf("GuessRemoteBranch(%q) = %q, want %q", tt.branch, got, tt.expected)
+		}
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "tag", "v1.0")
+	sha := runTestGit(t, dir, "rev-parse", "HEAD")
+
+	for _, ref := range []string{"main", "v1.0", sha, sha[:7]} {
+		got, err := ResolveRef(dir, ref)
+		if err != nil {
+			t.Errorf("ResolveRef(%q) returned error: %v", ref, err)
+		} else if got != sha {
+			t.Errorf("ResolveRef(%q) = %s, want %s", ref, got, sha)
+		}
+	}
+
+	if _, err := ResolveRef(dir, "missing"); err == nil {
+		t.Error("expected error for missing ref")
+	}
+}
+
+func TestListBranches(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "branch", "develop")
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/main", "main")
+	runTestGit(t, dir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+
+	branches, err := ListBranches(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []string{"develop", "main", "origin/main"}
+	if !reflect.DeepEqual(branches, expected) {
+		t.Errorf("ListBranches() = %v, want %v", branches, expected)
+	}
+}
+
+func TestGetCommitSubject(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "Add login form", "-m", "Longer body text")
+
+	subject, err := GetCommitSubject(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if subject != "Add login form" {
+		t.Errorf("expected subject only, got %q", subject)
+	}
+}
+
+func TestGetCommitSubject_NoCommits(t *testing.T) {
+	dir := t.TempDir()
+	runTestGit(t, dir, "init", "--initial-branch=main")
+
+	subject, err := GetCommitSubject(dir)
+	if err != nil {
+		t.Fatalf("expected no error for empty repo, got %v", err)
+	}
+	if subject != "" {
+		t.Errorf("expected empty subject, got %q", subject)
+	}
+}
+
+func TestFindRenamedRemoteBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "checkout", "-q", "-b", "feature/login")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "login work")
+	commit := runTestGit(t, dir, "rev-parse", "HEAD")
+	main := runTestGit(t, dir, "rev-parse", "main")
+
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/main", main)
+	runTestGit(t, dir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/other", main)
+
+	// No candidate: only the default branch and unrelated branches remain
+	if renamed, err := FindRenamedRemoteBranch(dir, "origin", "feature/login", commit); err != nil || renamed != "" {
+		t.Errorf("expected no candidate, got %q (%v)", renamed, err)
+	}
+
+	// A similar name alone is not evidence of a rename
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/feat/login", main)
+	if renamed, _ := FindRenamedRemoteBranch(dir, "origin", "feature/login", commit); renamed != "" {
+		t.Errorf("expected no candidate by name alone, got %q", renamed)
+	}
+
+	// The only branch at the worktree's commit
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/signin", commit)
+	if renamed, _ := FindRenamedRemoteBranch(dir, "origin", "feature/login", commit); renamed != "signin" {
+		t.Errorf("expected signin by commit, got %q", renamed)
+	}
+
+	// Several branches at the commit are ambiguous
+	runTestGit(t, dir, "update-ref", "refs/remotes/origin/login-copy", commit)
+	if renamed, _ := FindRenamedRemoteBranch(dir, "origin", "feature/login", commit); renamed != "" {
+		t.Errorf("expected no candidate for an ambiguous commit, got %q", renamed)
+	}
+
+	// Upstream config naming the new branch wins
+	runTestGit(t, dir, "config", "branch.feature/login.remote", "origin")
+	runTestGit(t, dir, "config", "branch.feature/login.merge", "refs/heads/feat/login")
+	if renamed, _ := FindRenamedRemoteBranch(dir, "origin", "feature/login", commit); renamed != "feat/login" {
+		t.Errorf("expected feat/login from the upstream config, got %q", renamed)
+	}
+}
+
+func TestGetLastCommitTime(t *testing.T) {
+	dir := initTestRepo(t)
+	t.Setenv("GIT_COMMITTER_DATE", "2024-03-01T12:00:00Z")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "dated")
+
+	when, err := GetLastCommitTime(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC); !when.Equal(want) {
+		t.Errorf("expected %v, got %v", want, when)
+	}
+
+	empty := t.TempDir()
+	runTestGit(t, empty, "init", "--initial-branch=main")
+	if when, err := GetLastCommitTime(empty); err != nil || !when.IsZero() {
+		t.Errorf("expected zero time for empty repo, got %v, %v", when, err)
+	}
+}
+
+func TestGetUpstreamSync(t *testing.T) {
+	dir := initTestRepo(t)
+
+	if sync, err := GetUpstreamSync(dir); err != nil || sync.Upstream != "" {
+		t.Errorf("expected no upstream, got %+v (%v)", sync, err)
+	}
+
+	// Track a local "upstream" branch, then diverge: 2 ahead, 1 behind
+	runTestGit(t, dir, "branch", "base")
+	runTestGit(t, dir, "branch", "--set-upstream-to=base")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "local 1")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "local 2")
+	runTestGit(t, dir, "checkout", "-q", "base")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "upstream 1")
+	runTestGit(t, dir, "checkout", "-q", "main")
+
+	sync, err := GetUpstreamSync(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sync.Upstream != "base" || sync.Ahead != 2 || sync.Behind != 1 {
+		t.Errorf("expected base 2 ahead 1 behind, got %+v", sync)
+	}
+}
+
+func TestIsDetachedHead(t *testing.T) {
+	dir := initTestRepo(t)
+	if IsDetachedHead(dir) {
+		t.Error("expected branch checkout not to be detached")
+	}
+
+	runTestGit(t, dir, "checkout", "--detach")
+	if !IsDetachedHead(dir) {
+		t.Error("expected detached HEAD")
+	}
+}
+
+func TestGetLastCommitAuthor(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "Other work", "--author", "Jane Doe <jane@example.com>")
+
+	author, err := GetLastCommitAuthor(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if author != "jane@example.com" {
+		t.Errorf("expected author email, got %q", author)
+	}
+
+	empty := t.TempDir()
+	runTestGit(t, empty, "init", "--initial-branch=main")
+	if author, err := GetLastCommitAuthor(empty); err != nil || author != "" {
+		t.Errorf("expected empty author for empty repo, got %q, %v", author, err)
+	}
+}
+
+func TestUniqueBranchName(t *testing.T) {
+	dir := initTestRepo(t)
+
+	if got := UniqueBranchName(dir, "issue-42-fix-login"); got != "issue-42-fix-login" {
+		t.Errorf("expected unchanged name when free, got %s", got)
+	}
+
+	// Force collisions with an existing branch and an existing directory
+	runTestGit(t, dir, "branch", "issue-42-fix-login")
+	if got := UniqueBranchName(dir, "issue-42-fix-login"); got != "issue-42-fix-login-2" {
+		t.Errorf("expected -2 suffix, got %s", got)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "issue-42-fix-login-2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := UniqueBranchName(dir, "issue-42-fix-login"); got != "issue-42-fix-login-3" {
+		t.Errorf("expected -3 suffix, got %s", got)
+	}
+}
+
+func TestFetchPullRequestHead(t *testing.T) {
+	projectRoot := initTestProject(t)
+	src := runTestGit(t, projectRoot, "remote", "get-url", "origin")
+
+	// A PR from a fork only exists on the remote as refs/pull/<n>/head
+	runTestGit(t, src, "checkout", "-b", "fork-fix")
+	runTestGit(t, src, "commit", "--allow-empty", "-m", "fix from fork")
+	head := runTestGit(t, src, "rev-parse", "HEAD")
+	runTestGit(t, src, "update-ref", "refs/pull/5/head", head)
+	runTestGit(t, src, "checkout", "main")
+	runTestGit(t, src, "branch", "-D", "fork-fix")
+
+	if err := FetchPullRequestHead(projectRoot, "origin", 5, "alice/fix", 30); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := runTestGit(t, projectRoot, "rev-parse", "refs/heads/alice/fix"); got != head {
+		t.Errorf("expected branch at %s, got %s", head, got)
+	}
+	if got := runTestGit(t, projectRoot, "config", "branch.alice/fix.merge"); got != "refs/pull/5/head" {
+		t.Errorf("expected branch to pull from refs/pull/5/head, got %q", got)
+	}
+
+	if err := FetchPullRequestHead(projectRoot, "origin", 6, "bob/missing", 30); err == nil {
+		t.Error("expected error for a PR ref that does not exist")
+	}
+}
+
+func TestParseStashBranches(t *testing.T) {
+	output := `WIP on feature/auth: 0123abc Add login
+On feature/auth: half-done refactor
+On main: experiment
+WIP on (no branch): 4567def detached work
+garbage line`
+
+	got := parseStashBranches(output)
+	want := map[string]int{"feature/auth": 2, "main": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseStashBranches() = %v, want %v", got, want)
+	}
+	if got := parseStashBranches(""); len(got) != 0 {
+		t.Errorf("expected no stashes, got %v", got)
+	}
+}
+
+func TestCountStashesByBranch(t *testing.T) {
+	projectRoot := initTestProject(t)
+
+	counts, err := CountStashesByBranch(projectRoot)
+	if err != nil || len(counts) != 0 {
+		t.Fatalf("expected no stashes, got %v (%v)", counts, err)
+	}
+
+	path := addTestWorktree(t, projectRoot, "feature/stash")
+	if err := os.WriteFile(filepath.Join(path, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runTestGit(t, path, "add", "wip.txt")
+	runTestGit(t, path, "stash", "push", "-m", "saved for later")
+
+	counts, err = CountStashesByBranch(projectRoot)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if counts["feature/stash"] != 1 || len(counts) != 1 {
+		t.Errorf("expected one stash on feature/stash, got %v", counts)
+	}
+}
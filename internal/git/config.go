@@ -0,0 +1,103 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetLocalConfig sets a git config value scoped to a single worktree
+// Enables extensions.worktreeConfig so the value does not leak into the
+// shared repository config used by every other worktree
+func SetLocalConfig(worktreePath, key, value string) error {
+	if err := enableWorktreeConfig(worktreePath); err != nil {
+		return err
+	}
+	if _, err := RunInDir(worktreePath, "config", "--worktree", key, value); err != nil {
+		return fmt.Errorf("failed to set %s: %w", key, err)
+	}
+	return nil
+}
+
+// SetUpstreamOnPush configures a worktree so its first plain `git push`
+// publishes the branch to remote and sets it as upstream: push.autoSetupRemote
+// is scoped to the worktree and branch.<name>.pushRemote names the remote.
+// Returns the config that was set.
+func SetUpstreamOnPush(worktreePath, branchName, remote string) (map[string]string, error) {
+	if err := SetLocalConfig(worktreePath, "push.autoSetupRemote", "true"); err != nil {
+		return nil, err
+	}
+	key := "branch." + branchName + ".pushRemote"
+	if _, err := RunInDir(worktreePath, "config", key, remote); err != nil {
+		return nil, fmt.Errorf("failed to set %s: %w", key, err)
+	}
+	return map[string]string{
+		"push.autoSetupRemote": "true",
+		key:                    remote,
+	}, nil
+}
+
+// GetLocalConfig reads a git config value as seen from a worktree
+func GetLocalConfig(worktreePath, key string) (string, error) {
+	output, err := RunInDir(worktreePath, "config", "--get", key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return output, nil
+}
+
+// HooksDir returns the absolute directory git runs hooks from in a worktree,
+// honoring core.hooksPath (relative values resolve from the worktree root)
+func HooksDir(worktreePath string) (string, error) {
+	output, err := RunInDir(worktreePath, "rev-parse", "--path-format=absolute", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve hooks path: %w", err)
+	}
+	return output, nil
+}
+
+// Identity returns the git user as "Name <email>" from user.name and
+// user.email, or whichever of the two is set ("" if neither is)
+func Identity(dir string) string {
+	name, _ := GetLocalConfig(dir, "user.name")
+	email, _ := GetLocalConfig(dir, "user.email")
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	case email != "":
+		return "<" + email + ">"
+	default:
+		return name
+	}
+}
+
+// enableWorktreeConfig turns on per-worktree config for the repository
+// In a bare layout core.bare=true must move to the bare repo's own
+// config.worktree, otherwise every linked worktree would be treated as bare
+func enableWorktreeConfig(worktreePath string) error {
+	if output, err := RunInDir(worktreePath, "config", "--get", "extensions.worktreeConfig"); err == nil && output == "true" {
+		return nil
+	}
+
+	commonDir, err := RunInDir(worktreePath, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return fmt.Errorf("failed to locate repository: %w", err)
+	}
+	commonDir = strings.TrimSpace(commonDir)
+
+	isBare, _ := RunInDir(commonDir, "config", "--get", "core.bare")
+
+	if _, err := RunInDir(commonDir, "config", "extensions.worktreeConfig", "true"); err != nil {
+		return fmt.Errorf("failed to enable worktree config: %w", err)
+	}
+
+	if isBare == "true" {
+		if _, err := RunInDir(commonDir, "config", "--worktree", "core.bare", "true"); err != nil {
+			return fmt.Errorf("failed to scope core.bare to the bare repository: %w", err)
+		}
+		if _, err := RunInDir(commonDir, "config", "--unset", "core.bare"); err != nil {
+			return fmt.Errorf("failed to scope core.bare to the bare repository: %w", err)
+		}
+	}
+
+	return nil
+}
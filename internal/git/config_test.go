@@ -0,0 +1,84 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestSetLocalConfig(t *testing.T) {
+	projectRoot := initTestProject(t)
+	work := addTestWorktree(t, projectRoot, "work")
+	other := addTestWorktree(t, projectRoot, "other")
+
+	if err := SetLocalConfig(work, "user.email", "me@work.com"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	value, err := GetLocalConfig(work, "user.email")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value != "me@work.com" {
+		t.Errorf("expected me@work.com, got %s", value)
+	}
+
+	// Value must not leak into sibling worktrees
+	if value, err := GetLocalConfig(other, "user.email"); err == nil && value == "me@work.com" {
+		t.Error("expected config to be scoped to a single worktree")
+	}
+
+	// Sibling worktrees must still be usable (core.bare scoped to .bare)
+	if _, err := RunInDir(other, "status", "--porcelain"); err != nil {
+		t.Errorf("expected sibling worktree to remain usable, got %v", err)
+	}
+}
+
+func TestSetLocalConfig_Idempotent(t *testing.T) {
+	projectRoot := initTestProject(t)
+	work := addTestWorktree(t, projectRoot, "work")
+
+	for _, email := range []string{"first@example.com", "second@example.com"} {
+		if err := SetLocalConfig(work, "user.email", email); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	value, err := GetLocalConfig(work, "user.email")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value != "second@example.com" {
+		t.Errorf("expected second@example.com, got %s", value)
+	}
+}
+
+func TestSetUpstreamOnPush(t *testing.T) {
+	projectRoot := initTestProject(t)
+	work := addTestWorktree(t, projectRoot, "feature/push")
+	other := addTestWorktree(t, projectRoot, "other")
+
+	applied, err := SetUpstreamOnPush(work, "feature/push", "origin")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(applied) != 2 {
+		t.Errorf("expected 2 applied keys, got %v", applied)
+	}
+
+	for key, want := range map[string]string{
+		"push.autoSetupRemote":           "true",
+		"branch.feature/push.pushRemote": "origin",
+	} {
+		value, err := GetLocalConfig(work, key)
+		if err != nil {
+			t.Fatalf("expected %s to be set, got %v", key, err)
+		}
+		if value != want {
+			t.Errorf("%s = %q, want %q", key, value, want)
+		}
+	}
+
+	// autoSetupRemote is scoped to the new worktree only
+	if value, err := GetLocalConfig(other, "push.autoSetupRemote"); err == nil && value == "true" {
+		t.Error("expected push.autoSetupRemote to be scoped to a single worktree")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -16,6 +17,24 @@ const (
 	LongTimeout    = 10 * time.Minute // For clone/fetch operations
 )
 
+// maxStderrLines is how many trailing stderr lines a progress failure reports
+const maxStderrLines = 5
+
+// NonInteractiveEnv makes git fail fast instead of waiting on a credential
+// prompt the user may never see
+var NonInteractiveEnv = []string{"GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never"}
+
+// authErrorMarkers are fragments of git/ssh output that indicate missing or
+// rejected credentials
+var authErrorMarkers = []string{
+	"terminal prompts disabled",
+	"could not read username",
+	"could not read password",
+	"authentication failed",
+	"permission denied (publickey",
+	"invalid username or password",
+}
+
 // Run executes a git command and returns the output
 func Run(args ...string) (string, error) {
 	return RunInDir("", args...)
@@ -75,6 +94,12 @@ func RunWithProgress(dir string, args ...string) error {
 
 // RunWithProgressAndTimeout executes a git command with progress output and specified timeout
 func RunWithProgressAndTimeout(dir string, timeoutSec int, args ...string) error {
+	return runWithProgress(dir, timeoutSec, nil, args...)
+}
+
+// runWithProgress runs a git command with progress output, adding env to the
+// inherited environment
+func runWithProgress(dir string, timeoutSec int, env []string, args ...string) error {
 	timeout := time.Duration(timeoutSec) * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -86,22 +111,61 @@ func RunWithProgressAndTimeout(dir string, timeoutSec int, args ...string) error
 
 	// WaitDelay ensures process cleanup even if context is cancelled
 	cmd.WaitDelay = 5 * time.Second
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
-	// Connect to terminal for progress display
+	// Connect to terminal for progress display, keeping a copy of stderr
+	// so failures can report what git said
+	var stderr bytes.Buffer
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
 
 	if err := cmd.Run(); err != nil {
 		if ctx.Err() != nil {
 			// Handle both DeadlineExceeded and Canceled
 			return fmt.Errorf("git %s: %w", strings.Join(args, " "), ctx.Err())
 		}
+		if errMsg := lastLines(stderr.String(), maxStderrLines); errMsg != "" {
+			return fmt.Errorf("git %s failed: %s", strings.Join(args, " "), errMsg)
+		}
 		return fmt.Errorf("git %s failed", strings.Join(args, " "))
 	}
 
 	return nil
 }
 
+// lastLines returns the last n non-empty lines of git output joined by
+// newlines. Progress updates are separated by carriage returns, so those
+// count as line breaks too.
+func lastLines(output string, n int) string {
+	var lines []string
+	for _, line := range strings.FieldsFunc(output, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsAuthError reports whether a git error was caused by missing or rejected
+// credentials
+func IsAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range authErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // RunSilent executes a git command without capturing output
 func RunSilent(args ...string) error {
 	_, err := Run(args...)
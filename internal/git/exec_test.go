@@ -1,6 +1,8 @@
 package git
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -30,3 +32,39 @@ func TestRunInDir_GitVersion(t *testing.T) {
 		t.Fatal("expected output, got empty string")
 	}
 }
+
+func TestRunWithProgressAndTimeout_IncludesStderr(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing-repo")
+
+	err := RunWithProgressAndTimeout(dir, 30, "clone", missing, filepath.Join(dir, "dest"))
+	if err == nil {
+		t.Fatal("expected clone of missing repository to fail")
+	}
+	if !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected error to include git's stderr, got %q", err.Error())
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		n        int
+		expected string
+	}{
+		{"empty", "", 3, ""},
+		{"fewer than n", "one\ntwo\n", 3, "one\ntwo"},
+		{"trims to last n", "a\nb\nc\nd\n", 2, "c\nd"},
+		{"progress carriage returns", "Receiving 10%\rReceiving 50%\rfatal: boom\n", 1, "fatal: boom"},
+		{"skips blank lines", "first\n\n  \nlast\n", 5, "first\nlast"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastLines(tt.output, tt.n); got != tt.expected {
+				t.Errorf("lastLines() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ListIgnoredFiles returns the untracked, gitignored files in a worktree
+// (build artifacts, local env files), relative to the worktree root
+func ListIgnoredFiles(worktreePath string) ([]string, error) {
+	output, err := RunInDir(worktreePath, "ls-files", "--others", "--ignored", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ignored files: %w", err)
+	}
+
+	var files []string
+	for _, name := range strings.Split(output, "\x00") {
+		if name != "" {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// CopyIgnoredFiles copies the gitignored files of the src worktree into the
+// dest worktree, preserving modes and symlinks. Files that already exist in
+// dest are left alone. Returns the number of files copied.
+func CopyIgnoredFiles(src, dest string) (int, error) {
+	files, err := ListIgnoredFiles(src)
+	if err != nil {
+		return 0, err
+	}
+
+	copied := 0
+	for _, name := range files {
+		from := filepath.Join(src, name)
+		to := filepath.Join(dest, name)
+		if _, err := os.Lstat(to); err == nil {
+			continue
+		}
+
+		info, err := os.Lstat(from)
+		if err != nil {
+			return copied, fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			return copied, fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(from)
+			if err != nil {
+				return copied, fmt.Errorf("failed to copy %s: %w", name, err)
+			}
+			if err := os.Symlink(link, to); err != nil {
+				return copied, fmt.Errorf("failed to copy %s: %w", name, err)
+			}
+		case info.Mode().IsRegular():
+			if err := copyFile(from, to, info.Mode().Perm()); err != nil {
+				return copied, fmt.Errorf("failed to copy %s: %w", name, err)
+			}
+		default:
+			continue
+		}
+		copied++
+	}
+	return copied, nil
+}
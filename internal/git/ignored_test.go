@@ -0,0 +1,73 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCopyIgnoredFiles(t *testing.T) {
+	projectRoot := initTestProject(t)
+	src := addTestWorktree(t, projectRoot, "source")
+	dest := addTestWorktree(t, projectRoot, "dest")
+
+	files := map[string]string{
+		".gitignore":          ".env\nbuild/\n",
+		".env":                "SECRET=1\n",
+		"build/out/app.bin":   "binary",
+		"notes.txt":           "untracked but not ignored",
+		"build/already-there": "source copy",
+	}
+	for name, content := range files {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dest, "build"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dest, "build", "already-there"), []byte("dest copy"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ignored, err := ListIgnoredFiles(src)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := []string{".env", "build/already-there", "build/out/app.bin"}; !reflect.DeepEqual(ignored, want) {
+		t.Errorf("ListIgnoredFiles() = %v, want %v", ignored, want)
+	}
+
+	copied, err := CopyIgnoredFiles(src, dest)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if copied != 2 {
+		t.Errorf("expected 2 files copied, got %d", copied)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dest, ".env"))
+	if err != nil || string(data) != "SECRET=1\n" {
+		t.Errorf("expected .env to be copied, got %q (%v)", data, err)
+	}
+	if info, err := os.Stat(filepath.Join(dest, ".env")); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("expected .env mode 0600, got %v (%v)", info.Mode().Perm(), err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "build", "out", "app.bin")); err != nil {
+		t.Errorf("expected nested ignored file to be copied: %v", err)
+	}
+	// Untracked files that are not ignored are skipped, existing files kept
+	for _, name := range []string{"notes.txt", ".gitignore"} {
+		if _, err := os.Stat(filepath.Join(dest, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s not to be copied", name)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dest, "build", "already-there")); string(data) != "dest copy" {
+		t.Errorf("expected existing file to be kept, got %q", data)
+	}
+}
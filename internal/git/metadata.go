@@ -0,0 +1,133 @@
+package git
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MetadataFile is the sidecar file git-wt writes into a worktree's
+// administrative directory (.bare/worktrees/<name>/), so it is removed
+// together with the worktree by git itself
+const MetadataFile = "git-wt.json"
+
+// Metadata records how git-wt created a worktree
+type Metadata struct {
+	Issue     int    `json:"issue,omitempty"`
+	PR        int    `json:"pr,omitempty"`
+	MR        int    `json:"mr,omitempty"`         // GitLab merge request
+	CreatedBy string `json:"created_by,omitempty"` // git identity, "Name <email>"
+	CreatedAt string `json:"created_at,omitempty"` // RFC 3339, UTC
+}
+
+// NewMetadata returns metadata stamped with the current git identity (as
+// configured for the worktree) and the current time
+func NewMetadata(worktreePath string) Metadata {
+	return Metadata{
+		CreatedBy: Identity(worktreePath),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// WriteMetadata stores metadata for a worktree
+func WriteMetadata(worktreePath string, meta Metadata) error {
+	gitDir, err := WorktreeGitDir(worktreePath)
+	if err != nil {
+		return err
+	}
+
+	// Keep "Name <email>" readable instead of \u003c-escaped
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(meta); err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(gitDir, MetadataFile), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return nil
+}
+
+// ReadMetadata loads metadata for a worktree
+// Returns nil without error if the worktree has no metadata (e.g. it was
+// created outside git-wt)
+func ReadMetadata(worktreePath string) (*Metadata, error) {
+	gitDir, err := WorktreeGitDir(worktreePath)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(gitDir, MetadataFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+	return &meta, nil
+}
+
+// FindWorktreeByMetadata returns the worktree whose metadata records one of
+// the issue, PR or MR numbers in want (zero values are ignored). Returns nil
+// without error if none matches.
+func FindWorktreeByMetadata(projectRoot string, want Metadata) (*Worktree, error) {
+	worktrees, err := ListWorktrees(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == "" {
+			continue
+		}
+		meta, err := ReadMetadata(wt.Path)
+		if err != nil || meta == nil {
+			continue
+		}
+		if (want.Issue > 0 && meta.Issue == want.Issue) || (want.PR > 0 && meta.PR == want.PR) || (want.MR > 0 && meta.MR == want.MR) {
+			return &wt, nil
+		}
+	}
+	return nil, nil
+}
+
+// WorktreeGitDir resolves a worktree's administrative directory by reading
+// its .git file ("gitdir: <path>"), avoiding a git subprocess per worktree
+func WorktreeGitDir(worktreePath string) (string, error) {
+	gitPath := filepath.Join(worktreePath, GitPointerFile)
+
+	info, err := os.Stat(gitPath)
+	if err != nil {
+		return "", fmt.Errorf("not a worktree: %s", worktreePath)
+	}
+	if info.IsDir() {
+		return gitPath, nil
+	}
+
+	data, err := os.ReadFile(gitPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", gitPath, err)
+	}
+
+	line := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(line, "gitdir: ") {
+		return "", fmt.Errorf("invalid .git file: %s", gitPath)
+	}
+
+	dir := strings.TrimPrefix(line, "gitdir: ")
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(worktreePath, dir)
+	}
+	return filepath.Clean(dir), nil
+}
@@ -0,0 +1,144 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetadata_RoundTrip(t *testing.T) {
+	projectRoot := initTestProject(t)
+	path := addTestWorktree(t, projectRoot, "issue-42-fix-login")
+
+	if err := WriteMetadata(path, Metadata{Issue: 42}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Sidecar lives in the worktree's admin dir, not the working tree
+	if _, err := os.Stat(filepath.Join(path, MetadataFile)); !os.IsNotExist(err) {
+		t.Error("expected metadata to stay out of the working tree")
+	}
+
+	meta, err := ReadMetadata(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if meta == nil || meta.Issue != 42 {
+		t.Errorf("expected issue 42, got %+v", meta)
+	}
+}
+
+func TestMetadata_CreatedBy(t *testing.T) {
+	projectRoot := initTestProject(t)
+	path := addTestWorktree(t, projectRoot, "feature/shared")
+	runTestGit(t, projectRoot, "config", "user.name", "Ada Lovelace")
+	runTestGit(t, projectRoot, "config", "user.email", "ada@example.com")
+
+	meta := NewMetadata(path)
+	if meta.CreatedBy != "Ada Lovelace <ada@example.com>" {
+		t.Errorf("expected git identity, got %q", meta.CreatedBy)
+	}
+	if _, err := time.Parse(time.RFC3339, meta.CreatedAt); err != nil {
+		t.Errorf("expected RFC 3339 timestamp, got %q", meta.CreatedAt)
+	}
+
+	meta.Issue = 7
+	if err := WriteMetadata(path, meta); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(projectRoot, BareDir, "worktrees", "feature-shared", MetadataFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{`"created_by": "Ada Lovelace <ada@example.com>"`, `"created_at": "`, `"issue": 7`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("expected %s in metadata:\n%s", field, data)
+		}
+	}
+
+	got, err := ReadMetadata(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if *got != meta {
+		t.Errorf("round trip = %+v, want %+v", *got, meta)
+	}
+}
+
+func TestFindWorktreeByMetadata(t *testing.T) {
+	projectRoot := initTestProject(t)
+	issuePath := addTestWorktree(t, projectRoot, "issue-42-fix-login")
+	prPath := addTestWorktree(t, projectRoot, "pr-7-docs")
+	mrPath := addTestWorktree(t, projectRoot, "mr-3-api")
+	addTestWorktree(t, projectRoot, "plain")
+
+	if err := WriteMetadata(issuePath, Metadata{Issue: 42}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteMetadata(prPath, Metadata{PR: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteMetadata(mrPath, Metadata{MR: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		want   Metadata
+		branch string
+	}{
+		{"issue", Metadata{Issue: 42}, "issue-42-fix-login"},
+		{"pr", Metadata{PR: 7}, "pr-7-docs"},
+		{"mr", Metadata{MR: 3}, "mr-3-api"},
+		{"issue number is not a pr", Metadata{PR: 42}, ""},
+		{"mr number is not a pr", Metadata{PR: 3}, ""},
+		{"no match", Metadata{Issue: 99}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wt, err := FindWorktreeByMetadata(projectRoot, tt.want)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			got := ""
+			if wt != nil {
+				got = wt.Branch
+			}
+			if got != tt.branch {
+				t.Errorf("expected branch %q, got %q", tt.branch, got)
+			}
+		})
+	}
+}
+
+func TestReadMetadata_Missing(t *testing.T) {
+	projectRoot := initTestProject(t)
+	path := addTestWorktree(t, projectRoot, "feature")
+
+	meta, err := ReadMetadata(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if meta != nil {
+		t.Errorf("expected nil metadata, got %+v", meta)
+	}
+}
+
+func TestWorktreeGitDir_RelativePointer(t *testing.T) {
+	worktree := t.TempDir()
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: ../.bare/worktrees/feature\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := WorktreeGitDir(worktree)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := filepath.Join(filepath.Dir(worktree), ".bare", "worktrees", "feature")
+	if dir != expected {
+		t.Errorf("expected %s, got %s", expected, dir)
+	}
+}
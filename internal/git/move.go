@@ -0,0 +1,140 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// MoveProject relocates a whole git-wt project (the bare repo and every
+// worktree inside it) to dest, then repairs the links between them.
+// Worktrees living outside the project root stay where they are but are
+// repaired to point at the new bare repo. Returns the output of
+// git worktree repair.
+func MoveProject(projectRoot, dest string) (string, error) {
+	root, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+	dest, err = filepath.Abs(dest)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	if _, err := os.Lstat(dest); err == nil {
+		return "", fmt.Errorf("destination already exists: %s", dest)
+	}
+	if info, err := os.Stat(filepath.Dir(dest)); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("destination parent directory does not exist: %s", filepath.Dir(dest))
+	}
+	if isWithin(resolvePath(root), resolvePath(filepath.Dir(dest))) {
+		return "", fmt.Errorf("cannot move a project into itself: %s", dest)
+	}
+
+	worktrees, err := ListWorktrees(root)
+	if err != nil {
+		return "", err
+	}
+
+	// Work out where each linked worktree will be after the move
+	var newPaths []string
+	for _, wt := range worktrees {
+		if wt.Branch == "" && filepath.Base(wt.Path) == BareDir {
+			continue
+		}
+		rel, err := filepath.Rel(resolvePath(root), resolvePath(wt.Path))
+		if err == nil && isWithin(resolvePath(root), resolvePath(wt.Path)) {
+			newPaths = append(newPaths, filepath.Join(dest, rel))
+		} else {
+			newPaths = append(newPaths, wt.Path)
+		}
+	}
+
+	if err := moveDir(root, dest); err != nil {
+		return "", err
+	}
+
+	args := append([]string{"worktree", "repair"}, newPaths...)
+	output, err := RunInDir(dest, args...)
+	if err != nil {
+		return "", fmt.Errorf("moved to %s but failed to repair worktrees: %w", dest, err)
+	}
+	return output, nil
+}
+
+// moveDir renames src to dest, falling back to copy-and-delete when they are
+// on different filesystems
+func moveDir(src, dest string) error {
+	err := os.Rename(src, dest)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, syscall.EXDEV) {
+		return fmt.Errorf("failed to move project: %w", err)
+	}
+
+	if err := copyTree(src, dest); err != nil {
+		_ = os.RemoveAll(dest)
+		return fmt.Errorf("failed to copy project: %w", err)
+	}
+	if err := os.RemoveAll(src); err != nil {
+		return fmt.Errorf("copied project to %s but failed to remove %s: %w", dest, src, err)
+	}
+	return nil
+}
+
+// copyTree copies a directory tree, preserving file modes and symlinks
+func copyTree(src, dest string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		default:
+			return nil
+		}
+	})
+}
+
+// copyFile copies a regular file's contents with the given permissions
+func copyFile(src, dest string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMoveProject(t *testing.T) {
+	projectRoot := initTestProject(t)
+	addTestWorktree(t, projectRoot, "feature/a")
+	outside := filepath.Join(t.TempDir(), "outside")
+	runTestGit(t, projectRoot, "worktree", "add", outside, "-b", "outside")
+
+	dest := filepath.Join(t.TempDir(), "moved")
+	if _, err := MoveProject(projectRoot, dest); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := os.Stat(projectRoot); !os.IsNotExist(err) {
+		t.Error("expected old project root to be gone")
+	}
+
+	// Worktrees must resolve from both sides after the move
+	for _, wtPath := range []string{filepath.Join(dest, "feature-a"), outside} {
+		if _, err := RunInDir(wtPath, "status", "--porcelain"); err != nil {
+			t.Errorf("expected %s to remain usable, got %v", wtPath, err)
+		}
+	}
+	wt, err := FindWorktreeForBranch(dest, "feature/a")
+	if err != nil || wt == nil {
+		t.Fatalf("expected feature/a worktree to be listed, got %v", err)
+	}
+	if resolvePath(wt.Path) != resolvePath(filepath.Join(dest, "feature-a")) {
+		t.Errorf("expected worktree under new root, got %s", wt.Path)
+	}
+
+	broken, err := CheckWorktreeLinks(dest)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, link := range broken {
+		if link.Path != outside {
+			t.Errorf("expected no broken links inside the project after move, got %+v", link)
+		}
+	}
+}
+
+func TestMoveProject_DestinationExists(t *testing.T) {
+	projectRoot := initTestProject(t)
+	dest := t.TempDir()
+
+	if _, err := MoveProject(projectRoot, dest); err == nil {
+		t.Error("expected error when destination exists")
+	}
+	if _, err := MoveProject(projectRoot, filepath.Join(projectRoot, "nested")); err == nil {
+		t.Error("expected error when moving a project into itself")
+	}
+	if !IsBareRepo(projectRoot) {
+		t.Error("expected project to be left in place")
+	}
+}
+
+func TestCopyTree(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "run.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("sub/run.sh", filepath.Join(src, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "copy")
+	if err := copyTree(src, dest); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dest, "sub", "run.sh"))
+	if err != nil {
+		t.Fatalf("expected copied file, got %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("expected mode 0755, got %v", info.Mode().Perm())
+	}
+	if link, err := os.Readlink(filepath.Join(dest, "link")); err != nil || link != "sub/run.sh" {
+		t.Errorf("expected symlink to be preserved, got %q (%v)", link, err)
+	}
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RemoteURL returns the URL of a remote, or an error if it does not exist
+func RemoteURL(projectRoot, name string) (string, error) {
+	return RunInDir(projectRoot, "remote", "get-url", name)
+}
+
+// fetchRefspec is the refspec that fetches every branch of a remote into
+// refs/remotes/<name>/*, which a bare clone does not set up by default
+func fetchRefspec(name string) string {
+	return "+refs/heads/*:refs/remotes/" + name + "/*"
+}
+
+// SetFetchRefspec configures a remote to fetch all its branches, replacing
+// any existing fetch refspecs
+func SetFetchRefspec(projectRoot, name string) error {
+	if _, err := RunInDir(projectRoot, "config", "--replace-all", "remote."+name+".fetch", fetchRefspec(name)); err != nil {
+		return fmt.Errorf("failed to configure fetch: %w", err)
+	}
+	return nil
+}
+
+// ConfigureRemote makes name a working remote for the project. An existing
+// remote gets its URL updated when url differs; a missing one is added with
+// url or, without a url, renamed from current. The fetch refspec is always
+// (re)set. Returns the git remote commands that were run.
+func ConfigureRemote(projectRoot, name, url, current string) ([]string, error) {
+	var actions []string
+	run := func(args ...string) error {
+		if _, err := RunInDir(projectRoot, append([]string{"remote"}, args...)...); err != nil {
+			return err
+		}
+		actions = append(actions, "git remote "+strings.Join(args, " "))
+		return nil
+	}
+
+	existingURL, err := RemoteURL(projectRoot, name)
+	switch {
+	case err == nil && url != "" && url != existingURL:
+		err = run("set-url", name, url)
+	case err == nil:
+	case url != "":
+		err = run("add", name, url)
+	case current != "" && current != name:
+		if _, curErr := RemoteURL(projectRoot, current); curErr != nil {
+			return nil, fmt.Errorf("remote %s does not exist (pass a URL to add it)", name)
+		}
+		err = run("rename", current, name)
+	default:
+		return nil, fmt.Errorf("remote %s does not exist (pass a URL to add it)", name)
+	}
+	if err != nil {
+		return actions, err
+	}
+
+	if err := SetFetchRefspec(projectRoot, name); err != nil {
+		return actions, err
+	}
+	return actions, nil
+}
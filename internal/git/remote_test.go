@@ -0,0 +1,53 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigureRemote(t *testing.T) {
+	dir := initTestRepo(t)
+	runTestGit(t, dir, "remote", "add", "origin", "https://example.com/old.git")
+
+	// Missing remote without a URL: renamed from the current one
+	actions, err := ConfigureRemote(dir, "upstream", "", "origin")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := []string{"git remote rename origin upstream"}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("expected actions %v, got %v", want, actions)
+	}
+	if url, _ := RemoteURL(dir, "upstream"); url != "https://example.com/old.git" {
+		t.Errorf("expected renamed remote to keep its URL, got %q", url)
+	}
+	if got := runTestGit(t, dir, "config", "--get-all", "remote.upstream.fetch"); got != "+refs/heads/*:refs/remotes/upstream/*" {
+		t.Errorf("unexpected fetch refspec %q", got)
+	}
+
+	// Existing remote with a new URL
+	actions, err = ConfigureRemote(dir, "upstream", "https://example.com/new.git", "upstream")
+	if err != nil || len(actions) != 1 {
+		t.Fatalf("expected set-url, got %v (%v)", actions, err)
+	}
+	if url, _ := RemoteURL(dir, "upstream"); url != "https://example.com/new.git" {
+		t.Errorf("expected updated URL, got %q", url)
+	}
+
+	// Existing remote, same URL: only the refspec is (re)set
+	if actions, err = ConfigureRemote(dir, "upstream", "", "upstream"); err != nil || len(actions) != 0 {
+		t.Errorf("expected no remote changes, got %v (%v)", actions, err)
+	}
+
+	// Missing remote with a URL is added
+	if _, err = ConfigureRemote(dir, "fork", "https://example.com/fork.git", "upstream"); err != nil {
+		t.Fatalf("expected remote to be added, got %v", err)
+	}
+	if _, err := RemoteURL(dir, "fork"); err != nil {
+		t.Error("expected fork remote to exist")
+	}
+
+	// Missing remote, no URL and nothing to rename
+	if _, err = ConfigureRemote(dir, "nope", "", "missing"); err == nil {
+		t.Error("expected error for unknown remote without URL")
+	}
+}
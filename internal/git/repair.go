@@ -0,0 +1,136 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BrokenLink describes a worktree whose gitdir links need repair
+type BrokenLink struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// CheckWorktreeLinks reports worktrees whose links between .bare/worktrees
+// and the worktree's .git file no longer agree, without changing anything.
+// It checks both directions: each admin entry's recorded path must lie inside
+// the project root and point back to it, and each worktree directory in the
+// project root must point to an admin entry that points back to it.
+func CheckWorktreeLinks(projectRoot string) ([]BrokenLink, error) {
+	root := resolvePath(projectRoot)
+	adminRoot := filepath.Join(root, BareDir, "worktrees")
+
+	var broken []BrokenLink
+	seen := make(map[string]bool)
+	add := func(path, reason string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		broken = append(broken, BrokenLink{Path: path, Reason: reason})
+	}
+
+	// Admin side: .bare/worktrees/<name>/gitdir -> <worktree>/.git
+	entries, err := os.ReadDir(adminRoot)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", adminRoot, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		adminDir := filepath.Join(adminRoot, entry.Name())
+		wtGitFile, err := readGitdirFile(adminDir)
+		if err != nil {
+			add(adminDir, err.Error())
+			continue
+		}
+		wtPath := filepath.Dir(wtGitFile)
+
+		if !isWithin(root, resolvePath(wtPath)) {
+			add(wtPath, "recorded path is outside the project root")
+			continue
+		}
+		// Missing directories are either moved (caught below) or prunable
+		if _, err := os.Stat(wtGitFile); err != nil {
+			continue
+		}
+		target, err := WorktreeGitDir(wtPath)
+		if err != nil {
+			add(wtPath, err.Error())
+			continue
+		}
+		if resolvePath(target) != resolvePath(adminDir) {
+			add(wtPath, fmt.Sprintf(".git points to %s", target))
+		}
+	}
+
+	// Worktree side: <project>/<dir>/.git -> .bare/worktrees/<name>
+	children, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+	for _, child := range children {
+		if !child.IsDir() || child.Name() == BareDir {
+			continue
+		}
+		wtPath := filepath.Join(root, child.Name())
+		info, err := os.Stat(filepath.Join(wtPath, GitPointerFile))
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		adminDir, err := WorktreeGitDir(wtPath)
+		if err != nil {
+			add(wtPath, err.Error())
+			continue
+		}
+		if _, err := os.Stat(adminDir); err != nil {
+			add(wtPath, fmt.Sprintf(".git points to missing %s", adminDir))
+			continue
+		}
+		back, err := readGitdirFile(adminDir)
+		if err != nil {
+			add(wtPath, err.Error())
+			continue
+		}
+		if resolvePath(filepath.Dir(back)) != resolvePath(wtPath) {
+			add(wtPath, fmt.Sprintf("registered at %s", filepath.Dir(back)))
+		}
+	}
+
+	return broken, nil
+}
+
+// readGitdirFile reads an admin entry's gitdir file, which records the path
+// of the worktree's .git file
+func readGitdirFile(adminDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(adminDir, "gitdir"))
+	if err != nil {
+		return "", fmt.Errorf("missing gitdir file in %s", adminDir)
+	}
+	path := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(adminDir, path)
+	}
+	return filepath.Clean(path), nil
+}
+
+// resolvePath resolves symlinks where possible so paths compare reliably
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// isWithin reports whether path is root or lies beneath it
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckWorktreeLinks_Healthy(t *testing.T) {
+	projectRoot := initTestProject(t)
+	addTestWorktree(t, projectRoot, "feature/a")
+
+	broken, err := CheckWorktreeLinks(projectRoot)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(broken) != 0 {
+		t.Errorf("expected no broken links, got %+v", broken)
+	}
+}
+
+func TestCheckWorktreeLinks_MovedWorktree(t *testing.T) {
+	projectRoot := initTestProject(t)
+	oldPath := addTestWorktree(t, projectRoot, "feature/a")
+	newPath := filepath.Join(projectRoot, "renamed")
+	if err := os.Rename(oldPath, newPath); err != nil {
+		t.Fatal(err)
+	}
+
+	broken, err := CheckWorktreeLinks(projectRoot)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(broken) != 1 {
+		t.Fatalf("expected 1 broken link, got %+v", broken)
+	}
+	if filepath.Base(broken[0].Path) != "renamed" {
+		t.Errorf("expected moved worktree to be reported, got %s", broken[0].Path)
+	}
+
+	// Running the real repair fixes what the check reported
+	if _, err := RunInDir(projectRoot, "worktree", "repair", newPath); err != nil {
+		t.Fatal(err)
+	}
+	broken, err = CheckWorktreeLinks(projectRoot)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(broken) != 0 {
+		t.Errorf("expected no broken links after repair, got %+v", broken)
+	}
+}
+
+func TestCheckWorktreeLinks_MovedProject(t *testing.T) {
+	projectRoot := initTestProject(t)
+	addTestWorktree(t, projectRoot, "feature/a")
+
+	movedRoot := filepath.Join(t.TempDir(), "moved")
+	if err := os.Rename(projectRoot, movedRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	broken, err := CheckWorktreeLinks(movedRoot)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(broken) == 0 {
+		t.Error("expected broken links after moving the project")
+	}
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initTestRepo creates a regular git repository with a single commit on main
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	runTestGit(t, dir, "init", "--initial-branch=main")
+	runTestGit(t, dir, "commit", "--allow-empty", "-m", "initial commit")
+	return dir
+}
+
+// runTestGit runs a git command in dir, failing the test on error
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// initTestProject creates a git-wt style project (.bare + .git pointer) cloned
+// from a fresh source repository, returning the project root
+func initTestProject(t *testing.T) string {
+	t.Helper()
+	src := initTestRepo(t)
+	projectRoot := t.TempDir()
+
+	runTestGit(t, projectRoot, "clone", "--bare", src, filepath.Join(projectRoot, BareDir))
+	if err := os.WriteFile(filepath.Join(projectRoot, GitPointerFile), []byte("gitdir: ./"+BareDir+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return projectRoot
+}
+
+// addTestWorktree adds a worktree on a new branch without relying on
+// --relative-paths (unavailable on older git versions)
+func addTestWorktree(t *testing.T, projectRoot, branch string) string {
+	t.Helper()
+	path := filepath.Join(projectRoot, FlattenBranchName(branch))
+	runTestGit(t, projectRoot, "worktree", "add", path, "-b", branch)
+	return path
+}
@@ -1,8 +1,13 @@
 package git
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"unicode/utf8"
 )
 
 // ValidateProjectName validates a project name for safety
@@ -33,6 +38,51 @@ func ValidateProjectName(name string) error {
 	return nil
 }
 
+// ValidateSubdir validates a worktree subdirectory (relative to the project root)
+func ValidateSubdir(subdir string) error {
+	if subdir == "" {
+		return nil
+	}
+	if filepath.IsAbs(subdir) {
+		return fmt.Errorf("worktree subdirectory must be relative to the project root: %s", subdir)
+	}
+	for _, part := range strings.Split(filepath.ToSlash(filepath.Clean(subdir)), "/") {
+		if part == ".." {
+			return fmt.Errorf("worktree subdirectory cannot contain '..': %s", subdir)
+		}
+		if part == BareDir || part == GitPointerFile {
+			return fmt.Errorf("reserved worktree subdirectory: %s", subdir)
+		}
+	}
+	return nil
+}
+
+// ResolveWorktreePath resolves an explicit worktree directory, relative to
+// the project root unless absolute. It must lie inside the project root or
+// next to it (in its parent directory), outside the bare repository, and must
+// not exist yet.
+func ResolveWorktreePath(projectRoot, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectRoot, path)
+	}
+	path = filepath.Clean(path)
+
+	rel, err := filepath.Rel(filepath.Dir(projectRoot), path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || path == projectRoot {
+		return "", fmt.Errorf("worktree path must be inside or next to the project root: %s", path)
+	}
+	if inRoot, err := filepath.Rel(projectRoot, path); err == nil {
+		first := strings.Split(filepath.ToSlash(inRoot), "/")[0]
+		if first == BareDir || first == GitPointerFile {
+			return "", fmt.Errorf("reserved worktree path: %s", path)
+		}
+	}
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("worktree path already exists: %s", path)
+	}
+	return path, nil
+}
+
 // ValidateBranchName validates a git branch name
 func ValidateBranchName(name string) error {
 	if name == "" {
@@ -83,3 +133,57 @@ func ValidateBranchName(name string) error {
 func FlattenBranchName(branch string) string {
 	return strings.ReplaceAll(branch, "/", "-")
 }
+
+// DetachedDirName derives a worktree directory name from a tag or commit ref:
+// slashes are flattened, other characters outside [A-Za-z0-9._-] become
+// dashes, and full commit SHAs are shortened to 12 characters
+// e.g. "release/v1.2" -> "release-v1.2", "HEAD~3" -> "HEAD-3"
+func DetachedDirName(ref string) string {
+	ref = strings.TrimPrefix(ref, "refs/tags/")
+	if len(ref) == 40 && strings.Trim(strings.ToLower(ref), "0123456789abcdef") == "" {
+		return ref[:12]
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, ref)
+}
+
+// MaxDirNameLen is the conservative per-component filename limit (NAME_MAX)
+// shared by common filesystems such as ext4, APFS and NTFS
+const MaxDirNameLen = 255
+
+// ValidateDirNameLength checks that a branch's flattened directory name fits
+// within MaxDirNameLen bytes
+func ValidateDirNameLength(branch string) error {
+	if n := len(FlattenBranchName(branch)); n > MaxDirNameLen {
+		return fmt.Errorf("worktree directory name for %q is %d bytes, over the %d-byte filesystem limit (use --truncate-long-names or a shorter branch name)", branch, n, MaxDirNameLen)
+	}
+	return nil
+}
+
+// SafeDirName flattens a branch name into a directory name of at most maxLen
+// bytes. Longer names are truncated and suffixed with a short hash of the full
+// branch name, so distinct branches keep distinct directories.
+func SafeDirName(branch string, maxLen int) string {
+	name := FlattenBranchName(branch)
+	if len(name) <= maxLen {
+		return name
+	}
+
+	sum := sha1.Sum([]byte(branch))
+	suffix := "-" + hex.EncodeToString(sum[:])[:8]
+	keep := maxLen - len(suffix)
+	if keep < 0 {
+		keep = 0
+	}
+	// Cut on a rune boundary so multi-byte names stay valid UTF-8
+	for keep > 0 && !utf8.RuneStart(name[keep]) {
+		keep--
+	}
+	return strings.TrimRight(name[:keep], "-.") + suffix
+}
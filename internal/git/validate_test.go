@@ -1,7 +1,11 @@
 package git
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestValidateProjectName(t *testing.T) {
@@ -88,3 +92,117 @@ func TestFlattenBranchName(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveWorktreePath(t *testing.T) {
+	parent := t.TempDir()
+	projectRoot := filepath.Join(parent, "project")
+	if err := os.MkdirAll(filepath.Join(projectRoot, "taken"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := map[string]string{
+		"work/auth":                              filepath.Join(projectRoot, "work", "auth"),
+		"../project-auth":                        filepath.Join(parent, "project-auth"),
+		filepath.Join(parent, "elsewhere"):       filepath.Join(parent, "elsewhere"),
+		filepath.Join(projectRoot, "nested/dir"): filepath.Join(projectRoot, "nested", "dir"),
+	}
+	for input, want := range valid {
+		got, err := ResolveWorktreePath(projectRoot, input)
+		if err != nil || got != want {
+			t.Errorf("ResolveWorktreePath(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{".", "..", "../../outside", "/tmp", BareDir + "/wt", "taken"} {
+		if _, err := ResolveWorktreePath(projectRoot, input); err == nil {
+			t.Errorf("expected %q to be rejected", input)
+		}
+	}
+}
+
+func TestDetachedDirName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"v1.2.3", "v1.2.3"},
+		{"refs/tags/release/v2", "release-v2"},
+		{"HEAD~3", "HEAD-3"},
+		{"0123456789abcdef0123456789abcdef01234567", "0123456789ab"},
+		{"abc1234", "abc1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := DetachedDirName(tt.input); result != tt.expected {
+				t.Errorf("DetachedDirName(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSafeDirName(t *testing.T) {
+	atLimit := "feature/" + strings.Repeat("a", MaxDirNameLen-len("feature/"))
+	overLimit := atLimit + "bcd"
+
+	if got := SafeDirName("feature/auth", MaxDirNameLen); got != "feature-auth" {
+		t.Errorf("expected short names to be flattened only, got %q", got)
+	}
+	if got := SafeDirName(atLimit, MaxDirNameLen); got != FlattenBranchName(atLimit) {
+		t.Errorf("expected name at the limit to be unchanged, got %d bytes", len(got))
+	}
+
+	got := SafeDirName(overLimit, MaxDirNameLen)
+	if len(got) > MaxDirNameLen {
+		t.Errorf("expected at most %d bytes, got %d", MaxDirNameLen, len(got))
+	}
+	if !strings.HasPrefix(got, "feature-aaa") {
+		t.Errorf("expected truncated name to keep its prefix, got %q", got)
+	}
+	if other := SafeDirName(atLimit+"xyz", MaxDirNameLen); other == got {
+		t.Errorf("expected distinct branches to get distinct names, both %q", got)
+	}
+	if again := SafeDirName(overLimit, MaxDirNameLen); again != got {
+		t.Errorf("expected stable output, got %q then %q", got, again)
+	}
+
+	// Multi-byte names are cut on a rune boundary
+	multi := SafeDirName(strings.Repeat("é", 200), MaxDirNameLen)
+	if len(multi) > MaxDirNameLen || !utf8.ValidString(multi) {
+		t.Errorf("expected valid UTF-8 within the limit, got %d bytes", len(multi))
+	}
+}
+
+func TestValidateDirNameLength(t *testing.T) {
+	if err := ValidateDirNameLength(strings.Repeat("a", MaxDirNameLen)); err != nil {
+		t.Errorf("expected name at the limit to be valid, got %v", err)
+	}
+	if err := ValidateDirNameLength(strings.Repeat("a", MaxDirNameLen+1)); err == nil {
+		t.Error("expected error for name over the limit")
+	}
+}
+
+func TestValidateSubdir(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"simple", "wt", false},
+		{"nested", "work/trees", false},
+		{"absolute", "/tmp/wt", true},
+		{"parent", "../outside", true},
+		{"bare dir", ".bare", true},
+		{"git pointer", ".git", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSubdir(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSubdir(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
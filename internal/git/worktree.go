@@ -2,15 +2,21 @@ package git
 
 import (
 	"fmt"
+	"io/fs"
 	"path/filepath"
 	"strings"
 )
 
 // Worktree represents a git worktree
 type Worktree struct {
-	Path   string
-	Branch string
-	Commit string
+	Path       string
+	Branch     string
+	Commit     string
+	Prunable   string // Why git considers the entry stale ("" if it is not)
+	Locked     bool   // The worktree is locked (git worktree lock)
+	LockReason string // Why it is locked, if a reason was given
+	Detached   bool   // HEAD is not on a branch
+	Bare       bool   // The bare repository itself, not a checkout
 }
 
 // CreateWorktree creates a new worktree with a new branch
@@ -19,45 +25,148 @@ func CreateWorktree(projectRoot, branchName string) (string, error) {
 	return CreateWorktreeWithBase(projectRoot, branchName, "")
 }
 
+// WorktreeOptions controls how a new worktree is created
+type WorktreeOptions struct {
+	Base            string // Base ref for the new branch (empty = HEAD)
+	Subdir          string // Directory under the project root to group worktrees in
+	NoRelativePaths bool   // Record absolute gitdir paths instead of --relative-paths
+	Track           bool   // Set Base as the upstream of the new branch
+	NoCheckout      bool   // Create the worktree without checking out any files
+	TruncateName    bool   // Shorten directory names over MaxDirNameLen instead of failing
+	Existing        bool   // Check out an existing local branch instead of creating one
+	Detach          bool   // Check out the ref with a detached HEAD instead of on a branch
+	Path            string // Explicit worktree directory, overriding the name and Subdir
+}
+
 // CreateWorktreeWithBase creates a new worktree with a new branch from a specific base
 // The directory name is flattened (slashes become dashes)
 // Uses --relative-paths for portability (Git 2.36+)
 func CreateWorktreeWithBase(projectRoot, branchName, baseBranch string) (string, error) {
-	// Flatten branch name for directory (e.g., feature/auth -> feature-auth)
-	dirName := FlattenBranchName(branchName)
-	worktreePath := filepath.Join(projectRoot, dirName)
+	return CreateWorktreeWithOptions(projectRoot, branchName, WorktreeOptions{Base: baseBranch})
+}
 
-	// Create worktree with new branch, optionally from a base branch
-	// Use --relative-paths so the repo can be moved without breaking paths
-	args := []string{"worktree", "add", "--relative-paths", worktreePath, "-b", branchName}
-	if baseBranch != "" {
-		args = append(args, baseBranch)
+// CreateWorktreeWithOptions creates a new worktree with a new branch
+// The directory name is flattened (slashes become dashes) and placed under
+// opts.Subdir when set, unless opts.Path gives the directory explicitly
+// Uses --relative-paths for portability (Git 2.36+) unless opts.NoRelativePaths
+func CreateWorktreeWithOptions(projectRoot, branchName string, opts WorktreeOptions) (string, error) {
+	worktreePath := WorktreePath(projectRoot, opts.Subdir, branchName)
+	if opts.Path != "" {
+		worktreePath = opts.Path
+	} else if opts.TruncateName {
+		worktreePath = filepath.Join(projectRoot, opts.Subdir, SafeDirName(branchName, MaxDirNameLen))
+	} else if err := ValidateDirNameLength(branchName); err != nil {
+		return "", err
 	}
 
-	if _, err := RunInDir(projectRoot, args...); err != nil {
+	if _, err := RunInDir(projectRoot, worktreeAddArgs(worktreePath, branchName, opts)...); err != nil {
 		return "", fmt.Errorf("failed to create worktree: %w", err)
 	}
 
 	return worktreePath, nil
 }
 
-// CreateWorktreeFromBranch creates a worktree from an existing branch
-// The directory name is flattened (slashes become dashes)
-// Uses --relative-paths for portability (Git 2.36+)
-func CreateWorktreeFromBranch(projectRoot, branchName string) (string, error) {
+// worktreeAddArgs builds the git worktree add arguments for a new branch,
+// optionally from a base branch
+func worktreeAddArgs(worktreePath, branchName string, opts WorktreeOptions) []string {
+	args := []string{"worktree", "add"}
+	// Use --relative-paths so the repo can be moved without breaking paths
+	if !opts.NoRelativePaths {
+		args = append(args, "--relative-paths")
+	}
+	if opts.Track {
+		args = append(args, "--track")
+	}
+	if opts.NoCheckout {
+		args = append(args, "--no-checkout")
+	}
+	if opts.Detach {
+		return append(args, "--detach", worktreePath, branchName)
+	}
+	if opts.Existing {
+		return append(args, worktreePath, branchName)
+	}
+	args = append(args, worktreePath, "-b", branchName)
+	if opts.Base != "" {
+		args = append(args, opts.Base)
+	}
+	return args
+}
+
+// CreateDetachedWorktree creates a worktree with a detached HEAD at ref (a tag
+// or commit), in dirName under the project root and opts.Subdir (or at
+// opts.Path). Only the Path, Subdir, NoRelativePaths and NoCheckout options
+// apply.
+func CreateDetachedWorktree(projectRoot, ref, dirName string, opts WorktreeOptions) (string, error) {
+	worktreePath := filepath.Join(projectRoot, opts.Subdir, dirName)
+	if opts.Path != "" {
+		worktreePath = opts.Path
+	}
+	opts = WorktreeOptions{NoRelativePaths: opts.NoRelativePaths, NoCheckout: opts.NoCheckout, Detach: true}
+	if _, err := RunInDir(projectRoot, worktreeAddArgs(worktreePath, ref, opts)...); err != nil {
+		return "", fmt.Errorf("failed to create worktree: %w", err)
+	}
+	return worktreePath, nil
+}
+
+// WorktreeAddSmart creates a worktree for a branch that already exists: a
+// local branch is checked out as is, and a branch only on remote gets a local
+// branch tracking it. Returns the worktree path and the tracked remote branch
+// ("" for a local branch); opts.Base and opts.Track are ignored.
+func WorktreeAddSmart(projectRoot, remote, branchName string, opts WorktreeOptions) (string, string, error) {
+	opts.Base, opts.Track, opts.Existing = "", false, false
+	if _, err := RunInDir(projectRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+branchName); err == nil {
+		opts.Existing = true
+	} else if tracking := GuessRemoteBranch(projectRoot, remote, branchName); tracking != "" {
+		opts.Base, opts.Track = tracking, true
+	} else {
+		return "", "", fmt.Errorf("branch %s not found locally or on %s", branchName, remote)
+	}
+
+	worktreePath, err := CreateWorktreeWithOptions(projectRoot, branchName, opts)
+	return worktreePath, opts.Base, err
+}
+
+// CreateWorktreeFromBranch creates a worktree from an existing branch at
+// opts.Path, or, when that is empty, in the flattened branch name (slashes
+// become dashes) under the project root. Only the Path, NoRelativePaths and
+// NoCheckout options apply.
+func CreateWorktreeFromBranch(projectRoot, branchName string, opts WorktreeOptions) (string, error) {
 	// Flatten branch name for directory (e.g., feature/auth -> feature-auth)
-	dirName := FlattenBranchName(branchName)
-	worktreePath := filepath.Join(projectRoot, dirName)
+	worktreePath := opts.Path
+	if worktreePath == "" {
+		worktreePath = filepath.Join(projectRoot, FlattenBranchName(branchName))
+	}
 
-	// Create worktree from existing branch
-	// Use --relative-paths so the repo can be moved without breaking paths
-	if _, err := RunInDir(projectRoot, "worktree", "add", "--relative-paths", worktreePath, branchName); err != nil {
+	opts = WorktreeOptions{NoRelativePaths: opts.NoRelativePaths, NoCheckout: opts.NoCheckout, Existing: true}
+	if _, err := RunInDir(projectRoot, worktreeAddArgs(worktreePath, branchName, opts)...); err != nil {
 		return "", fmt.Errorf("failed to create worktree: %w", err)
 	}
 
 	return worktreePath, nil
 }
 
+// WorktreePath returns the directory for a branch's worktree
+// e.g. ("/project", "worktrees", "feature/auth") -> "/project/worktrees/feature-auth"
+func WorktreePath(projectRoot, subdir, branchName string) string {
+	return filepath.Join(projectRoot, subdir, FlattenBranchName(branchName))
+}
+
+// FindWorktreeForBranch returns the worktree that has branchName checked out
+// Returns nil without error if no worktree matches
+func FindWorktreeForBranch(projectRoot, branchName string) (*Worktree, error) {
+	worktrees, err := ListWorktrees(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == branchName {
+			return &wt, nil
+		}
+	}
+	return nil, nil
+}
+
 // ListWorktrees lists all worktrees in the project
 func ListWorktrees(projectRoot string) ([]Worktree, error) {
 	output, err := RunInDir(projectRoot, "worktree", "list", "--porcelain")
@@ -92,6 +201,18 @@ func parseWorktreeList(output string) []Worktree {
 			branch := strings.TrimPrefix(line, "branch ")
 			// Extract branch name from refs/heads/... (preserves slashes in names like feature/auth)
 			current.Branch = strings.TrimPrefix(branch, "refs/heads/")
+		} else if line == "detached" {
+			current.Detached = true
+		} else if line == "bare" {
+			current.Bare = true
+		} else if strings.HasPrefix(line, "locked") {
+			current.Locked = true
+			current.LockReason = strings.TrimSpace(strings.TrimPrefix(line, "locked"))
+		} else if strings.HasPrefix(line, "prunable") {
+			current.Prunable = strings.TrimSpace(strings.TrimPrefix(line, "prunable"))
+			if current.Prunable == "" {
+				current.Prunable = "prunable"
+			}
 		}
 	}
 
@@ -119,6 +240,45 @@ func RemoveWorktreeForce(projectRoot, worktreePath string) error {
 	return nil
 }
 
+// LockWorktree locks a worktree so git worktree prune, move and remove leave
+// it alone, e.g. while it lives on a removable drive. reason may be empty.
+func LockWorktree(projectRoot, worktreePath, reason string) error {
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	args = append(args, worktreePath)
+	if _, err := RunInDir(projectRoot, args...); err != nil {
+		return fmt.Errorf("failed to lock worktree: %w", err)
+	}
+	return nil
+}
+
+// UnlockWorktree removes the lock from a worktree
+func UnlockWorktree(projectRoot, worktreePath string) error {
+	if _, err := RunInDir(projectRoot, "worktree", "unlock", worktreePath); err != nil {
+		return fmt.Errorf("failed to unlock worktree: %w", err)
+	}
+	return nil
+}
+
+// MoveWorktree moves a worktree to newPath, keeping its branch and git links
+func MoveWorktree(projectRoot, oldPath, newPath string) error {
+	if _, err := RunInDir(projectRoot, "worktree", "move", oldPath, newPath); err != nil {
+		return fmt.Errorf("failed to move worktree: %w", err)
+	}
+	return nil
+}
+
+// RenameBranch renames a local branch, including when it is checked out in a
+// worktree; its upstream and other branch config move with it
+func RenameBranch(projectRoot, oldName, newName string) error {
+	if _, err := RunInDir(projectRoot, "branch", "-m", oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename branch: %w", err)
+	}
+	return nil
+}
+
 // DeleteBranch deletes a local branch
 func DeleteBranch(projectRoot, branchName string) error {
 	if _, err := RunInDir(projectRoot, "branch", "-D", branchName); err != nil {
@@ -135,24 +295,126 @@ func PruneWorktrees(projectRoot string) error {
 	return nil
 }
 
-// GetWorktreeStatus returns the status of a worktree (clean, modified files count)
-func GetWorktreeStatus(worktreePath string) (string, error) {
-	output, err := RunInDir(worktreePath, "status", "--porcelain")
+// WorktreeSize returns the total size in bytes of the regular files in a
+// worktree directory. Symlinks are not followed.
+func WorktreeSize(worktreePath string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(worktreePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute size of %s: %w", worktreePath, err)
+	}
+	return size, nil
+}
+
+// WorktreeStatus counts the changes in a worktree by kind
+type WorktreeStatus struct {
+	Modified   int `json:"modified"`
+	Untracked  int `json:"untracked"`
+	Staged     int `json:"staged"`
+	Conflicted int `json:"conflicted"`
+}
+
+// IsClean reports whether the worktree has no changes at all
+func (s WorktreeStatus) IsClean() bool {
+	return s.Modified == 0 && s.Untracked == 0 && s.Staged == 0 && s.Conflicted == 0
+}
+
+// UntrackedOnly reports whether untracked files are the only changes
+func (s WorktreeStatus) UntrackedOnly() bool {
+	return s.Untracked > 0 && s.Modified == 0 && s.Staged == 0 && s.Conflicted == 0
+}
+
+// String formats the status for display, e.g. "2 modified, 1 untracked"
+func (s WorktreeStatus) String() string {
+	if s.IsClean() {
+		return "clean"
+	}
+
+	var parts []string
+	if s.Conflicted > 0 {
+		parts = append(parts, fmt.Sprintf("%d conflicted", s.Conflicted))
+	}
+	if s.Modified > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", s.Modified))
+	}
+	if s.Staged > 0 {
+		parts = append(parts, fmt.Sprintf("%d staged", s.Staged))
+	}
+	if s.Untracked > 0 {
+		parts = append(parts, fmt.Sprintf("%d untracked", s.Untracked))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// GetWorktreeStatus returns the change counts of a worktree
+func GetWorktreeStatus(worktreePath string) (WorktreeStatus, error) {
+	output, err := RunInDir(worktreePath, "status", "--porcelain=v2")
 	if err != nil {
-		return "unknown", nil
+		return WorktreeStatus{}, fmt.Errorf("failed to get worktree status: %w", err)
 	}
+	return parseStatusPorcelain(output), nil
+}
 
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	if output == "" || len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
-		return "clean", nil
+// parseStatusPorcelain parses `git status --porcelain=v2` output. Tracked
+// entries ("1" and "2" lines) carry an XY code where X is the index (staged)
+// state and Y the worktree state, with "." meaning unchanged; a file staged
+// and then modified again counts as both. "u" lines are unmerged (conflicted)
+// and "?" lines untracked.
+func parseStatusPorcelain(output string) WorktreeStatus {
+	var status WorktreeStatus
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "?":
+			status.Untracked++
+		case "u":
+			status.Conflicted++
+		case "1", "2":
+			xy := fields[1]
+			if len(xy) != 2 {
+				continue
+			}
+			if xy[0] != '.' {
+				status.Staged++
+			}
+			if xy[1] != '.' {
+				status.Modified++
+			}
+		}
 	}
+	return status
+}
 
-	return fmt.Sprintf("%d modified", len(lines)), nil
+// StatusString returns the display status of a worktree, or "unknown"
+// when git status fails
+func StatusString(worktreePath string) string {
+	status, err := GetWorktreeStatus(worktreePath)
+	if err != nil {
+		return "unknown"
+	}
+	return status.String()
 }
 
 // RepairWorktrees repairs worktree paths after a repository has been moved
-func RepairWorktrees(projectRoot string) (string, error) {
-	output, err := RunInDir(projectRoot, "worktree", "repair")
+// Worktrees that were themselves moved must be passed in paths
+func RepairWorktrees(projectRoot string, paths ...string) (string, error) {
+	output, err := RunInDir(projectRoot, append([]string{"worktree", "repair"}, paths...)...)
 	if err != nil {
 		return "", fmt.Errorf("failed to repair worktrees: %w", err)
 	}
@@ -1,6 +1,10 @@
 package git
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -26,6 +30,7 @@ branch refs/heads/main
 worktree /path/to/feature
 HEAD def456
 branch refs/heads/feature
+locked on a USB drive
 `
 
 	worktrees := parseWorktreeList(output)
@@ -45,6 +50,60 @@ branch refs/heads/feature
 	if worktrees[1].Branch != "feature" {
 		t.Errorf("expected feature, got %s", worktrees[1].Branch)
 	}
+
+	if worktrees[0].Locked || !worktrees[1].Locked || worktrees[1].LockReason != "on a USB drive" {
+		t.Errorf("expected only feature to be locked, got %+v and %+v", worktrees[0], worktrees[1])
+	}
+}
+
+func TestParseWorktreeList_Flags(t *testing.T) {
+	output := `worktree /proj/.bare
+bare
+
+worktree /proj/main
+HEAD abc123
+branch refs/heads/main
+
+worktree /proj/v1.2
+HEAD def456
+detached
+
+worktree /proj/usb
+HEAD 789abc
+branch refs/heads/usb
+locked
+
+worktree /proj/gone
+HEAD 123def
+branch refs/heads/gone
+prunable gitdir file points to non-existent location
+`
+
+	tests := []struct {
+		path     string
+		branch   string
+		bare     bool
+		detached bool
+		locked   bool
+		prunable string
+	}{
+		{path: "/proj/.bare", bare: true},
+		{path: "/proj/main", branch: "main"},
+		{path: "/proj/v1.2", detached: true},
+		{path: "/proj/usb", branch: "usb", locked: true},
+		{path: "/proj/gone", branch: "gone", prunable: "gitdir file points to non-existent location"},
+	}
+
+	worktrees := parseWorktreeList(output)
+	if len(worktrees) != len(tests) {
+		t.Fatalf("expected %d worktrees, got %d", len(tests), len(worktrees))
+	}
+	for i, tt := range tests {
+		wt := worktrees[i]
+		if wt.Path != tt.path || wt.Branch != tt.branch || wt.Bare != tt.bare || wt.Detached != tt.detached || wt.Locked != tt.locked || wt.LockReason != "" || wt.Prunable != tt.prunable {
+			t.Errorf("worktree %d = %+v, want %+v", i, wt, tt)
+		}
+	}
 }
 
 func TestParseWorktreeList_BranchWithSlashes(t *testing.T) {
@@ -73,3 +132,414 @@ branch refs/heads/fix/security/issue-42
 		t.Errorf("expected fix/security/issue-42, got %s", worktrees[1].Branch)
 	}
 }
+
+func TestWorktreePath(t *testing.T) {
+	tests := []struct {
+		subdir string
+		branch string
+		want   string
+	}{
+		{"", "main", "/proj/main"},
+		{"", "feature/auth", "/proj/feature-auth"},
+		{"wt", "feature/auth", "/proj/wt/feature-auth"},
+		{"work/trees", "fix", "/proj/work/trees/fix"},
+	}
+
+	for _, tt := range tests {
+		if got := WorktreePath("/proj", tt.subdir, tt.branch); got != tt.want {
+			t.Errorf("WorktreePath(%q, %q) = %s, want %s", tt.subdir, tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestFindWorktreeForBranch(t *testing.T) {
+	projectRoot := initTestProject(t)
+	wtPath := filepath.Join(projectRoot, "wt", "feature-x")
+	runTestGit(t, projectRoot, "worktree", "add", wtPath, "-b", "feature/x")
+
+	wt, err := FindWorktreeForBranch(projectRoot, "feature/x")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if wt == nil {
+		t.Fatal("expected worktree for feature/x")
+	}
+	resolved, _ := filepath.EvalSymlinks(wtPath)
+	got, _ := filepath.EvalSymlinks(wt.Path)
+	if got != resolved {
+		t.Errorf("expected path %s, got %s", resolved, got)
+	}
+
+	missing, err := FindWorktreeForBranch(projectRoot, "nope")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if missing != nil {
+		t.Errorf("expected nil for unknown branch, got %+v", missing)
+	}
+}
+
+func TestParseStatusPorcelain(t *testing.T) {
+	output := `1 .M N... 100644 100644 100644 abc abc modified.go
+1 M. N... 100644 100644 100644 abc def staged.go
+1 MM N... 100644 100644 100644 abc def both.go
+1 A. N... 000000 100644 100644 000 def added.go
+2 R. N... 100644 100644 100644 abc abc R100 new.go	old.go
+u UU N... 100644 100644 100644 100644 a b c conflict.go
+? untracked.txt
+? other.txt
+! ignored.log`
+
+	status := parseStatusPorcelain(output)
+
+	if status.Modified != 2 {
+		t.Errorf("expected 2 modified, got %d", status.Modified)
+	}
+	if status.Staged != 4 {
+		t.Errorf("expected 4 staged, got %d", status.Staged)
+	}
+	if status.Conflicted != 1 {
+		t.Errorf("expected 1 conflicted, got %d", status.Conflicted)
+	}
+	if status.Untracked != 2 {
+		t.Errorf("expected 2 untracked, got %d", status.Untracked)
+	}
+	if status.IsClean() || status.UntrackedOnly() {
+		t.Error("expected mixed status to be neither clean nor untracked-only")
+	}
+}
+
+func TestParseStatusPorcelain_Clean(t *testing.T) {
+	status := parseStatusPorcelain("")
+	if !status.IsClean() {
+		t.Errorf("expected clean status, got %+v", status)
+	}
+	if status.String() != "clean" {
+		t.Errorf("expected 'clean', got %s", status.String())
+	}
+}
+
+func TestWorktreeStatus_String(t *testing.T) {
+	tests := []struct {
+		status        WorktreeStatus
+		expected      string
+		untrackedOnly bool
+	}{
+		{WorktreeStatus{}, "clean", false},
+		{WorktreeStatus{Modified: 2, Untracked: 1}, "2 modified, 1 untracked", false},
+		{WorktreeStatus{Staged: 1}, "1 staged", false},
+		{WorktreeStatus{Untracked: 3}, "3 untracked", true},
+		{WorktreeStatus{Conflicted: 1, Untracked: 2}, "1 conflicted, 2 untracked", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.String(); got != tt.expected {
+			t.Errorf("String() = %q, want %q", got, tt.expected)
+		}
+		if got := tt.status.UntrackedOnly(); got != tt.untrackedOnly {
+			t.Errorf("%+v UntrackedOnly() = %v, want %v", tt.status, got, tt.untrackedOnly)
+		}
+	}
+}
+
+func TestGetWorktreeStatus(t *testing.T) {
+	repo := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := GetWorktreeStatus(repo)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !status.UntrackedOnly() || status.Untracked != 1 {
+		t.Errorf("expected 1 untracked file only, got %+v", status)
+	}
+
+	runTestGit(t, repo, "add", "new.txt")
+	status, err = GetWorktreeStatus(repo)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status.Staged != 1 || status.Untracked != 0 {
+		t.Errorf("expected 1 staged file, got %+v", status)
+	}
+}
+
+func TestWorktreeAddArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     WorktreeOptions
+		expected []string
+	}{
+		{"default", WorktreeOptions{}, []string{"worktree", "add", "--relative-paths", "/p/feat", "-b", "feat"}},
+		{"with base", WorktreeOptions{Base: "develop"}, []string{"worktree", "add", "--relative-paths", "/p/feat", "-b", "feat", "develop"}},
+		{"no relative paths", WorktreeOptions{NoRelativePaths: true}, []string{"worktree", "add", "/p/feat", "-b", "feat"}},
+		{"track", WorktreeOptions{Base: "origin/feat", Track: true}, []string{"worktree", "add", "--relative-paths", "--track", "/p/feat", "-b", "feat", "origin/feat"}},
+		{"no checkout", WorktreeOptions{NoCheckout: true}, []string{"worktree", "add", "--relative-paths", "--no-checkout", "/p/feat", "-b", "feat"}},
+		{"existing branch", WorktreeOptions{Existing: true, Base: "develop"}, []string{"worktree", "add", "--relative-paths", "/p/feat", "feat"}},
+		{"detached", WorktreeOptions{Detach: true, NoCheckout: true}, []string{"worktree", "add", "--relative-paths", "--no-checkout", "--detach", "/p/feat", "feat"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := worktreeAddArgs("/p/feat", "feat", tt.opts)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("worktreeAddArgs() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateWorktreeWithOptions_Path(t *testing.T) {
+	projectRoot := initTestProject(t)
+	custom := filepath.Join(projectRoot, "work", "auth")
+
+	path, err := CreateWorktreeWithOptions(projectRoot, "feature/auth", WorktreeOptions{Path: custom, Subdir: "ignored", NoRelativePaths: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if path != custom {
+		t.Errorf("expected %s, got %s", custom, path)
+	}
+
+	// The branch keeps its full name and is found by branch, not directory
+	wt, err := FindWorktreeForBranch(projectRoot, "feature/auth")
+	if err != nil || wt == nil || wt.Path != custom {
+		t.Errorf("expected feature/auth at %s, got %+v (%v)", custom, wt, err)
+	}
+}
+
+func TestCreateDetachedWorktree(t *testing.T) {
+	projectRoot := initTestProject(t)
+	runTestGit(t, projectRoot, "tag", "v1.0.0")
+
+	path, err := CreateDetachedWorktree(projectRoot, "refs/tags/v1.0.0", "v1.0.0", WorktreeOptions{Subdir: "releases", NoRelativePaths: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := filepath.Join(projectRoot, "releases", "v1.0.0"); path != want {
+		t.Errorf("expected %s, got %s", want, path)
+	}
+	if _, err := RunInDir(path, "symbolic-ref", "-q", "HEAD"); err == nil {
+		t.Error("expected a detached HEAD")
+	}
+}
+
+func TestCreateWorktreeFromBranch(t *testing.T) {
+	projectRoot := initTestProject(t)
+	runTestGit(t, projectRoot, "branch", "feature/auth", "main")
+
+	path, err := CreateWorktreeFromBranch(projectRoot, "feature/auth", WorktreeOptions{NoRelativePaths: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := filepath.Join(projectRoot, "feature-auth"); path != want {
+		t.Errorf("expected %s, got %s", want, path)
+	}
+
+	custom := filepath.Join(projectRoot, "trunk")
+	if path, err := CreateWorktreeFromBranch(projectRoot, "main", WorktreeOptions{Path: custom, NoRelativePaths: true}); err != nil || path != custom {
+		t.Errorf("expected main at %s, got %s (%v)", custom, path, err)
+	}
+}
+
+func TestWorktreeAddSmart(t *testing.T) {
+	projectRoot := initTestProject(t)
+	runTestGit(t, projectRoot, "branch", "local")
+	runTestGit(t, projectRoot, "update-ref", "refs/remotes/origin/shared", "HEAD")
+	if err := SetFetchRefspec(projectRoot, "origin"); err != nil {
+		t.Fatal(err)
+	}
+	opts := WorktreeOptions{NoRelativePaths: true}
+
+	path, tracking, err := WorktreeAddSmart(projectRoot, "origin", "local", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tracking != "" || path != filepath.Join(projectRoot, "local") {
+		t.Errorf("expected local branch checked out at %s, got %s (tracking %q)", filepath.Join(projectRoot, "local"), path, tracking)
+	}
+
+	path, tracking, err = WorktreeAddSmart(projectRoot, "origin", "shared", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tracking != "origin/shared" {
+		t.Errorf("expected to track origin/shared, got %q", tracking)
+	}
+	if upstream := runTestGit(t, path, "rev-parse", "--abbrev-ref", "@{upstream}"); upstream != "origin/shared" {
+		t.Errorf("expected upstream origin/shared, got %s", upstream)
+	}
+
+	if _, _, err := WorktreeAddSmart(projectRoot, "origin", "missing", opts); err == nil {
+		t.Error("expected error for a branch that does not exist")
+	}
+}
+
+func TestCreateWorktreeWithOptions_NoRelativePaths(t *testing.T) {
+	projectRoot := initTestProject(t)
+
+	path, err := CreateWorktreeWithOptions(projectRoot, "feature/abs", WorktreeOptions{NoRelativePaths: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(path, GitPointerFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitdir := strings.TrimPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !filepath.IsAbs(gitdir) {
+		t.Errorf("expected absolute gitdir, got %s", gitdir)
+	}
+}
+
+func TestCreateWorktreeWithOptions_Track(t *testing.T) {
+	projectRoot := initTestProject(t)
+	runTestGit(t, projectRoot, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
+	runTestGit(t, projectRoot, "update-ref", "refs/remotes/origin/feature/remote", "main")
+
+	base := GuessRemoteBranch(projectRoot, "origin", "feature/remote")
+	if base != "origin/feature/remote" {
+		t.Fatalf("expected guessed base origin/feature/remote, got %q", base)
+	}
+
+	path, err := CreateWorktreeWithOptions(projectRoot, "feature/remote", WorktreeOptions{Base: base, Track: true, NoRelativePaths: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if upstream := runTestGit(t, path, "rev-parse", "--abbrev-ref", "@{upstream}"); upstream != "origin/feature/remote" {
+		t.Errorf("expected upstream origin/feature/remote, got %q", upstream)
+	}
+}
+
+func TestCreateWorktreeWithOptions_NoCheckout(t *testing.T) {
+	projectRoot := initTestProject(t)
+
+	path, err := CreateWorktreeWithOptions(projectRoot, "feature/sparse", WorktreeOptions{NoCheckout: true, NoRelativePaths: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != GitPointerFile {
+			t.Errorf("expected no checked out files, found %s", entry.Name())
+		}
+	}
+	if branch := runTestGit(t, path, "rev-parse", "--abbrev-ref", "HEAD"); branch != "feature/sparse" {
+		t.Errorf("expected branch feature/sparse, got %q", branch)
+	}
+}
+
+func TestWorktreeSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b.txt"), make([]byte, 50), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "a.txt"), filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := WorktreeSize(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if size != 150 {
+		t.Errorf("expected 150 bytes, got %d", size)
+	}
+
+	if _, err := WorktreeSize(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestCreateWorktreeWithOptions_LongName(t *testing.T) {
+	projectRoot := initTestProject(t)
+	// Each ref component is short, but the flattened name is not
+	branch := strings.Repeat("feature/", 40) + "end"
+
+	if _, err := CreateWorktreeWithOptions(projectRoot, branch, WorktreeOptions{NoRelativePaths: true}); err == nil {
+		t.Fatal("expected error for directory name over the limit")
+	}
+
+	path, err := CreateWorktreeWithOptions(projectRoot, branch, WorktreeOptions{NoRelativePaths: true, TruncateName: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(filepath.Base(path)) > MaxDirNameLen {
+		t.Errorf("expected directory name within the limit, got %d bytes", len(filepath.Base(path)))
+	}
+	if got := runTestGit(t, path, "rev-parse", "--abbrev-ref", "HEAD"); got != branch {
+		t.Errorf("expected full branch name to be kept, got %q", got)
+	}
+}
+
+func TestMoveWorktreeAndRenameBranch(t *testing.T) {
+	projectRoot := initTestProject(t)
+	oldPath := addTestWorktree(t, projectRoot, "feature/atuh")
+	newPath := filepath.Join(projectRoot, "feature-auth")
+
+	// The branch is checked out in the worktree being moved
+	if err := RenameBranch(projectRoot, "feature/atuh", "feature/auth"); err != nil {
+		t.Fatalf("RenameBranch returned error: %v", err)
+	}
+	if err := MoveWorktree(projectRoot, oldPath, newPath); err != nil {
+		t.Fatalf("MoveWorktree returned error: %v", err)
+	}
+
+	if BranchExists(projectRoot, "feature/atuh") {
+		t.Error("expected old branch to be gone")
+	}
+	wt, err := FindWorktreeForBranch(projectRoot, "feature/auth")
+	if err != nil || wt == nil {
+		t.Fatalf("expected worktree for feature/auth, got %+v (%v)", wt, err)
+	}
+	resolved, _ := filepath.EvalSymlinks(newPath)
+	if got, _ := filepath.EvalSymlinks(wt.Path); got != resolved {
+		t.Errorf("expected path %s, got %s", resolved, got)
+	}
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be gone", oldPath)
+	}
+
+	if err := MoveWorktree(projectRoot, oldPath, newPath); err == nil {
+		t.Error("expected error moving a missing worktree")
+	}
+}
+
+func TestLockWorktree(t *testing.T) {
+	projectRoot := initTestProject(t)
+	path := addTestWorktree(t, projectRoot, "usb")
+
+	if err := LockWorktree(projectRoot, path, "on a USB drive"); err != nil {
+		t.Fatalf("LockWorktree returned error: %v", err)
+	}
+	wt, err := FindWorktreeForBranch(projectRoot, "usb")
+	if err != nil || wt == nil || !wt.Locked || wt.LockReason != "on a USB drive" {
+		t.Fatalf("expected locked worktree, got %+v (%v)", wt, err)
+	}
+	if err := RemoveWorktreeForce(projectRoot, path); err == nil {
+		t.Error("expected git to refuse removing a locked worktree")
+	}
+
+	if err := UnlockWorktree(projectRoot, path); err != nil {
+		t.Fatalf("UnlockWorktree returned error: %v", err)
+	}
+	if wt, _ := FindWorktreeForBranch(projectRoot, "usb"); wt == nil || wt.Locked {
+		t.Errorf("expected unlocked worktree, got %+v", wt)
+	}
+	if err := UnlockWorktree(projectRoot, path); err == nil {
+		t.Error("expected error unlocking an unlocked worktree")
+	}
+}
@@ -4,9 +4,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // Issue represents a GitHub issue
@@ -14,6 +20,7 @@ type Issue struct {
 	Number int     `json:"number"`
 	Title  string  `json:"title"`
 	Body   string  `json:"body"`
+	Author Author  `json:"author"`
 	Labels []Label `json:"labels"`
 	URL    string  `json:"url"`
 }
@@ -25,13 +32,17 @@ type Label struct {
 
 // PullRequest represents a GitHub pull request
 type PullRequest struct {
-	Number int           `json:"number"`
-	Title  string        `json:"title"`
-	Body   string        `json:"body"`
-	Author Author        `json:"author"`
-	State  string        `json:"state"`
-	URL    string        `json:"url"`
-	Files  []ChangedFile `json:"files"`
+	Number              int           `json:"number"`
+	Title               string        `json:"title"`
+	Body                string        `json:"body"`
+	Author              Author        `json:"author"`
+	State               string        `json:"state"`
+	URL                 string        `json:"url"`
+	Files               []ChangedFile `json:"files"`
+	Labels              []Label       `json:"labels"`
+	HeadRefName         string        `json:"headRefName"`
+	HeadRepositoryOwner Author        `json:"headRepositoryOwner"`
+	IsCrossRepository   bool          `json:"isCrossRepository"`
 }
 
 // Author represents a GitHub user
@@ -44,10 +55,26 @@ type ChangedFile struct {
 	Path string `json:"path"`
 }
 
+// Options configures how gh is invoked
+type Options struct {
+	// Host is a GitHub Enterprise hostname, passed to gh as GH_HOST
+	// (empty = gh's default host)
+	Host string
+}
+
+// command builds a gh command, pointed at opts.Host when one is set
+func (opts Options) command(args ...string) *exec.Cmd {
+	cmd := exec.Command("gh", args...)
+	if opts.Host != "" {
+		cmd.Env = append(os.Environ(), "GH_HOST="+opts.Host)
+	}
+	return cmd
+}
+
 // GetIssue fetches an issue by number
-func GetIssue(number int) (*Issue, error) {
-	cmd := exec.Command("gh", "issue", "view", fmt.Sprintf("%d", number),
-		"--json", "number,title,body,labels,url")
+func GetIssue(number int, opts Options) (*Issue, error) {
+	cmd := opts.command("issue", "view", fmt.Sprintf("%d", number),
+		"--json", "number,title,body,author,labels,url")
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -70,9 +97,9 @@ func GetIssue(number int) (*Issue, error) {
 }
 
 // GetPullRequest fetches a PR by number
-func GetPullRequest(number int) (*PullRequest, error) {
-	cmd := exec.Command("gh", "pr", "view", fmt.Sprintf("%d", number),
-		"--json", "number,title,body,author,state,url,files")
+func GetPullRequest(number int, opts Options) (*PullRequest, error) {
+	cmd := opts.command("pr", "view", fmt.Sprintf("%d", number),
+		"--json", "number,title,body,author,state,url,files,labels,headRefName,headRepositoryOwner,isCrossRepository")
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -94,6 +121,123 @@ func GetPullRequest(number int) (*PullRequest, error) {
 	return &pr, nil
 }
 
+// ListItem is an open issue or PR as returned by gh issue/pr list
+type ListItem struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// ListOpen lists open issues (kind "issue") or pull requests (kind "pr")
+func ListOpen(kind string, opts Options) ([]ListItem, error) {
+	cmd := opts.command(kind, "list", "--state", "open", "--limit", "100", "--json", "number,title")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		stderrStr := strings.TrimSpace(stderr.String())
+		if stderrStr != "" {
+			return nil, fmt.Errorf("failed to list %ss: %s", kind, stderrStr)
+		}
+		return nil, fmt.Errorf("failed to list %ss: %w", kind, err)
+	}
+
+	return parseList(stdout.Bytes())
+}
+
+// parseList decodes the JSON output of gh issue/pr list
+func parseList(data []byte) ([]ListItem, error) {
+	var items []ListItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse list response: %w", err)
+	}
+	return items, nil
+}
+
+// LinkedBranches lists the branches linked to an issue (created from its
+// Development section on GitHub), as reported by gh issue develop --list
+func LinkedBranches(number int, opts Options) ([]string, error) {
+	cmd := opts.command("issue", "develop", "--list", fmt.Sprintf("%d", number))
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		stderrStr := strings.TrimSpace(stderr.String())
+		if stderrStr != "" {
+			return nil, fmt.Errorf("failed to list linked branches for issue #%d: %s", number, stderrStr)
+		}
+		return nil, fmt.Errorf("failed to list linked branches for issue #%d: %w", number, err)
+	}
+
+	return parseLinkedBranches(stdout.String()), nil
+}
+
+// parseLinkedBranches extracts branch names from gh issue develop --list
+// output, one "<branch>\t<url>" line per branch
+func parseLinkedBranches(output string) []string {
+	var branches []string
+	for _, line := range strings.Split(output, "\n") {
+		name, _, _ := strings.Cut(line, "\t")
+		if name = strings.TrimSpace(name); name != "" {
+			branches = append(branches, name)
+		}
+	}
+	return branches
+}
+
+// PRCreateOptions configures CreatePullRequest. Without a Title, the title
+// and body are filled from the branch's commits (gh pr create --fill).
+type PRCreateOptions struct {
+	Options
+	Title    string
+	Body     string
+	BodyFile string
+	Draft    bool
+}
+
+// CreatePullRequest opens a pull request for the branch checked out in dir,
+// which must already be pushed, and returns its URL
+func CreatePullRequest(dir string, opts PRCreateOptions) (string, error) {
+	cmd := opts.command(prCreateArgs(opts)...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		stderrStr := strings.TrimSpace(stderr.String())
+		if stderrStr != "" {
+			return "", fmt.Errorf("failed to create PR: %s", stderrStr)
+		}
+		return "", fmt.Errorf("failed to create PR: %w", err)
+	}
+
+	// gh prints the new PR's URL as the last line
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+// prCreateArgs builds the gh pr create arguments for opts
+func prCreateArgs(opts PRCreateOptions) []string {
+	args := []string{"pr", "create"}
+	switch {
+	case opts.Title == "":
+		args = append(args, "--fill")
+	case opts.BodyFile != "":
+		args = append(args, "--title", opts.Title, "--body-file", opts.BodyFile)
+	default:
+		args = append(args, "--title", opts.Title, "--body", opts.Body)
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	return args
+}
+
 // GHAvailable checks if gh CLI is installed and authenticated
 func GHAvailable() bool {
 	cmd := exec.Command("gh", "auth", "status")
@@ -102,8 +246,8 @@ func GHAvailable() bool {
 
 // Slugify converts a string to a URL-friendly slug
 func Slugify(s string) string {
-	// Convert to lowercase
-	s = strings.ToLower(s)
+	// Convert to lowercase, folding accented letters to ASCII
+	s = transliterate(strings.ToLower(s))
 
 	// Replace spaces with hyphens
 	s = strings.ReplaceAll(s, " ", "-")
@@ -129,17 +273,106 @@ func Slugify(s string) string {
 	return s
 }
 
-// GenerateBranchName generates a branch name from issue/PR using hardcoded format
-// Format: prefix-number-titleslug (e.g., "issue-42-fix-login-bug")
-func GenerateBranchName(prefix string, number int, title string) string {
-	slug := Slugify(title)
-	return fmt.Sprintf("%s-%d-%s", prefix, number, slug)
+// asciiFolds spells out letters that do not decompose into an ASCII letter
+// plus combining marks
+var asciiFolds = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ł': "l", 'þ': "th", 'ı': "i",
+}
+
+// transliterate folds accented Latin letters to their base letters ("é" ->
+// "e"). Other non-ASCII characters (emoji, non-Latin scripts) are left for
+// Slugify to strip.
+func transliterate(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining mark split off by the decomposition
+		case asciiFolds[r] != "":
+			b.WriteString(asciiFolds[r])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// DefaultBranchTemplate is the branch name template used when
+// branch_template is not set (e.g. "issue-42-fix-login-bug")
+const DefaultBranchTemplate = "{{type}}-{{number}}-{{slug}}"
+
+// BranchData holds the values a branch name template can use
+type BranchData struct {
+	Type   string    // {{type}}: "issue", "pr" or "mr"
+	Number int       // {{number}}
+	Title  string    // {{slug}}, slugified
+	Author string    // {{author}}, slugified
+	Labels []string  // {{label}}: the first label, slugified
+	Date   time.Time // {{date}}: YYYY-MM-DD
+}
+
+// RenderBranchName expands the variables in a branch name template, e.g.
+// "{{type}}/{{number}}-{{slug}}". Empty values (no author or labels) are
+// dropped along with the hyphen or path separator they would leave behind.
+// A title that slugifies to nothing becomes "<type>-<number>" when the
+// template has no {{number}} of its own. An empty template uses
+// DefaultBranchTemplate.
+func RenderBranchName(template string, data BranchData) string {
+	if template == "" {
+		template = DefaultBranchTemplate
+	}
+	var label, date string
+	if len(data.Labels) > 0 {
+		label = Slugify(data.Labels[0])
+	}
+	if !data.Date.IsZero() {
+		date = data.Date.Format("2006-01-02")
+	}
+	slug := Slugify(data.Title)
+	if slug == "" && !strings.Contains(template, "{{number}}") {
+		slug = fmt.Sprintf("%s-%d", data.Type, data.Number)
+	}
+
+	replacer := strings.NewReplacer(
+		"{{type}}", data.Type,
+		"{{number}}", strconv.Itoa(data.Number),
+		"{{slug}}", slug,
+		"{{author}}", Slugify(data.Author),
+		"{{label}}", label,
+		"{{date}}", date,
+	)
+	return cleanBranchName(replacer.Replace(template))
+}
+
+var repeatedHyphens = regexp.MustCompile(`-+`)
+
+// cleanBranchName removes the empty path segments and doubled or dangling
+// hyphens left by empty template values
+func cleanBranchName(name string) string {
+	var segments []string
+	for _, segment := range strings.Split(name, "/") {
+		segment = strings.Trim(repeatedHyphens.ReplaceAllString(segment, "-"), "-")
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, "/")
 }
 
 // GetLabelNames returns a slice of label names
 func (i *Issue) GetLabelNames() []string {
-	names := make([]string, len(i.Labels))
-	for idx, label := range i.Labels {
+	return labelNames(i.Labels)
+}
+
+// GetLabelNames returns a slice of label names
+func (pr *PullRequest) GetLabelNames() []string {
+	return labelNames(pr.Labels)
+}
+
+// labelNames returns the names of labels, in order
+func labelNames(labels []Label) []string {
+	names := make([]string, len(labels))
+	for idx, label := range labels {
 		names[idx] = label.Name
 	}
 	return names
@@ -1,7 +1,9 @@
 package github
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSlugify(t *testing.T) {
@@ -13,6 +15,10 @@ func TestSlugify(t *testing.T) {
 		{"Add new feature!", "add-new-feature"},
 		{"  Multiple   Spaces  ", "multiple-spaces"},
 		{"UPPERCASE", "uppercase"},
+		{"Café résumé 日本語", "cafe-resume"},
+		{"Straße Ærø Łódź", "strasse-aero-lodz"},
+		{"Fix 🐛 crash on 🚀 launch", "fix-crash-on-launch"},
+		{"日本語のタイトル", ""},
 	}
 
 	for _, tt := range tests {
@@ -36,10 +42,109 @@ func TestGenerateBranchName(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		branch := GenerateBranchName(tt.prefix, tt.number, tt.title)
+		branch := RenderBranchName("", BranchData{Type: tt.prefix, Number: tt.number, Title: tt.title})
 		if branch != tt.expected {
-			t.Errorf("GenerateBranchName(%q, %d, %q) = %q, want %q",
+			t.Errorf("RenderBranchName(%q, %d, %q) = %q, want %q",
 				tt.prefix, tt.number, tt.title, branch, tt.expected)
 		}
 	}
 }
+
+func TestRenderBranchName(t *testing.T) {
+	data := BranchData{
+		Type:   "issue",
+		Number: 42,
+		Title:  "Fix login redirect",
+		Author: "Octo-Cat",
+		Labels: []string{"Good First Issue", "bug"},
+		Date:   time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		template string
+		data     BranchData
+		expected string
+	}{
+		{"{{type}}/{{number}}-{{slug}}", data, "issue/42-fix-login-redirect"},
+		{"{{author}}/{{type}}-{{number}}", data, "octo-cat/issue-42"},
+		{"{{label}}/{{number}}-{{slug}}", data, "good-first-issue/42-fix-login-redirect"},
+		{"{{date}}-{{number}}", data, "2026-03-05-42"},
+		{"feat/{{type}}-{{number}}", data, "feat/issue-42"},
+		// Empty values leave no stray separators behind
+		{"{{label}}/{{type}}-{{number}}", BranchData{Type: "pr", Number: 7}, "pr-7"},
+		{"{{type}}-{{author}}-{{number}}-{{slug}}", BranchData{Type: "pr", Number: 7}, "pr-7"},
+		// A title with nothing to transliterate falls back to type-number
+		{"", BranchData{Type: "issue", Number: 42, Title: "日本語のタイトル"}, "issue-42"},
+		{"feature/{{slug}}", BranchData{Type: "issue", Number: 42, Title: "日本語のタイトル"}, "feature/issue-42"},
+		{"{{slug}}", BranchData{Type: "pr", Number: 7}, "pr-7"},
+	}
+
+	for _, tt := range tests {
+		if got := RenderBranchName(tt.template, tt.data); got != tt.expected {
+			t.Errorf("RenderBranchName(%q) = %q, want %q", tt.template, got, tt.expected)
+		}
+	}
+}
+
+func TestParseLinkedBranches(t *testing.T) {
+	output := "42-fix-login\thttps://github.com/o/r/tree/42-fix-login\nfeature/login-v2\thttps://github.com/o/r/tree/feature/login-v2\n"
+
+	branches := parseLinkedBranches(output)
+	if len(branches) != 2 || branches[0] != "42-fix-login" || branches[1] != "feature/login-v2" {
+		t.Errorf("unexpected branches: %v", branches)
+	}
+	if branches := parseLinkedBranches(""); len(branches) != 0 {
+		t.Errorf("expected no branches, got %v", branches)
+	}
+}
+
+func TestPRCreateArgs(t *testing.T) {
+	tests := []struct {
+		opts     PRCreateOptions
+		expected string
+	}{
+		{PRCreateOptions{}, "pr create --fill"},
+		{PRCreateOptions{Draft: true}, "pr create --fill --draft"},
+		{PRCreateOptions{Title: "Fix login", Body: "Closes #42"}, "pr create --title Fix login --body Closes #42"},
+		{PRCreateOptions{Title: "Fix login", BodyFile: "PR_BODY.md", Draft: true}, "pr create --title Fix login --body-file PR_BODY.md --draft"},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(prCreateArgs(tt.opts), " "); got != tt.expected {
+			t.Errorf("prCreateArgs(%+v) = %q, want %q", tt.opts, got, tt.expected)
+		}
+	}
+}
+
+func TestParseList(t *testing.T) {
+	data := []byte(`[{"number":42,"title":"Fix login"},{"number":7,"title":"Add docs"}]`)
+
+	items, err := parseList(data)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(items) != 2 || items[0].Number != 42 || items[0].Title != "Fix login" || items[1].Number != 7 {
+		t.Errorf("unexpected items: %+v", items)
+	}
+
+	if items, err := parseList([]byte("[]")); err != nil || len(items) != 0 {
+		t.Errorf("expected empty list, got %+v (%v)", items, err)
+	}
+	if _, err := parseList([]byte("not json")); err == nil {
+		t.Error("expected error for invalid output")
+	}
+}
+
+func TestOptionsCommand(t *testing.T) {
+	if cmd := (Options{}).command("issue", "view", "1"); cmd.Env != nil {
+		t.Errorf("expected the inherited environment without a host, got %v", cmd.Env)
+	}
+
+	cmd := Options{Host: "github.example.com"}.command("issue", "view", "1")
+	if got := cmd.Env[len(cmd.Env)-1]; got != "GH_HOST=github.example.com" {
+		t.Errorf("expected GH_HOST to be set last, got %q", got)
+	}
+	if strings.Join(cmd.Args, " ") != "gh issue view 1" {
+		t.Errorf("unexpected args: %v", cmd.Args)
+	}
+}
@@ -0,0 +1,88 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// MergeRequest represents a GitLab merge request
+type MergeRequest struct {
+	IID          int      `json:"iid"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	Author       Author   `json:"author"`
+	Labels       []string `json:"labels"`
+	State        string   `json:"state"`
+	WebURL       string   `json:"web_url"`
+	SourceBranch string   `json:"source_branch"`
+}
+
+// Author represents a GitLab user
+type Author struct {
+	Username string `json:"username"`
+}
+
+// GetMergeRequest fetches a merge request by number (its project-level IID)
+func GetMergeRequest(number int) (*MergeRequest, error) {
+	cmd := exec.Command("glab", "mr", "view", fmt.Sprintf("%d", number), "--output", "json")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		stderrStr := strings.TrimSpace(stderr.String())
+		if stderrStr != "" {
+			return nil, fmt.Errorf("failed to fetch MR !%d: %s", number, stderrStr)
+		}
+		return nil, fmt.Errorf("failed to fetch MR !%d: %w", number, err)
+	}
+
+	return parseMergeRequest(stdout.Bytes())
+}
+
+// parseMergeRequest decodes the JSON output of glab mr view
+func parseMergeRequest(data []byte) (*MergeRequest, error) {
+	var mr MergeRequest
+	if err := json.Unmarshal(data, &mr); err != nil {
+		return nil, fmt.Errorf("failed to parse MR response: %w", err)
+	}
+	return &mr, nil
+}
+
+// GlabAvailable checks if glab CLI is installed and authenticated
+func GlabAvailable() bool {
+	cmd := exec.Command("glab", "auth", "status")
+	return cmd.Run() == nil
+}
+
+// IsGitLabURL reports whether a remote URL (https, ssh or scp-like
+// git@host:path) points at a GitLab host, i.e. one whose name contains
+// "gitlab" (gitlab.com, gitlab.example.com, ...)
+func IsGitLabURL(url string) bool {
+	return strings.Contains(strings.ToLower(urlHost(url)), "gitlab")
+}
+
+// urlHost extracts the host from a git remote URL, or "" for local paths
+func urlHost(url string) string {
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		host, _, _ := strings.Cut(rest, "/")
+		if _, h, ok := strings.Cut(host, "@"); ok {
+			host = h
+		}
+		host, _, _ = strings.Cut(host, ":")
+		return host
+	}
+	// scp-like syntax: [user@]host:path
+	host, _, ok := strings.Cut(url, ":")
+	if !ok || strings.Contains(host, "/") {
+		return ""
+	}
+	if _, h, ok := strings.Cut(host, "@"); ok {
+		host = h
+	}
+	return host
+}
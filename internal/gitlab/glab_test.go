@@ -0,0 +1,40 @@
+package gitlab
+
+import "testing"
+
+func TestParseMergeRequest(t *testing.T) {
+	data := []byte(`{"iid": 7, "id": 123456, "title": "Fix login redirect", "author": {"username": "alice"}, "state": "opened", "web_url": "https://gitlab.com/acme/app/-/merge_requests/7", "source_branch": "fix-login"}`)
+
+	mr, err := parseMergeRequest(data)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if mr.IID != 7 || mr.Title != "Fix login redirect" || mr.Author.Username != "alice" || mr.SourceBranch != "fix-login" {
+		t.Errorf("unexpected merge request: %+v", mr)
+	}
+
+	if _, err := parseMergeRequest([]byte("not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestIsGitLabURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://gitlab.com/acme/app.git", true},
+		{"git@gitlab.com:acme/app.git", true},
+		{"ssh://git@gitlab.example.com:2222/acme/app.git", true},
+		{"https://github.com/acme/app.git", false},
+		{"git@github.com:acme/app.git", false},
+		{"https://github.com/acme/gitlab.git", false},
+		{"/srv/git/gitlab-mirror.git", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsGitLabURL(tt.url); got != tt.want {
+			t.Errorf("IsGitLabURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
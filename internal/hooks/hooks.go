@@ -1,8 +1,11 @@
 package hooks
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -15,51 +18,171 @@ type Context struct {
 	Branch        string // Branch name (e.g., feature/auth)
 	ProjectRoot   string // Project root (contains .bare/)
 	DefaultBranch string // Default branch name (e.g., main)
+	WorkDir       string // Directory hooks run in (default: Path)
 }
 
-// Run executes hook commands with default timeout (30 seconds)
+// HookResult is the outcome of one hook command run by RunCaptured
+type HookResult struct {
+	Command    string `json:"command"`
+	ExitCode   int    `json:"exit_code"`
+	Output     string `json:"output,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// DefaultTimeout is the per-command hook timeout in seconds, used when none
+// (or a non-positive one) is configured
+const DefaultTimeout = 30
+
+// Hook working directory modes (hook_workdir)
+const (
+	WorkdirWorktree = "worktree"
+	WorkdirProject  = "project"
+)
+
+// ResolveWorkDir returns the directory a hook_workdir mode selects: path for
+// WorkdirWorktree (the default), projectRoot for WorkdirProject. It errors
+// for an unknown mode.
+func ResolveWorkDir(mode, path, projectRoot string) (string, error) {
+	switch mode {
+	case "", WorkdirWorktree:
+		return path, nil
+	case WorkdirProject:
+		return projectRoot, nil
+	default:
+		return "", fmt.Errorf("invalid hook_workdir %q (use %q or %q)", mode, WorkdirWorktree, WorkdirProject)
+	}
+}
+
+// dir returns the directory hooks run in
+func (c Context) dir() string {
+	if c.WorkDir != "" {
+		return c.WorkDir
+	}
+	return c.Path
+}
+
+// Run executes hook commands with DefaultTimeout
 // Returns a list of warning messages for failed commands
 func Run(commands []string, ctx Context) []string {
-	return RunWithTimeout(commands, ctx, 30)
+	return RunWithTimeout(commands, ctx, DefaultTimeout)
 }
 
 // RunWithTimeout executes hook commands with specified timeout in seconds
 // Returns a list of warning messages for failed commands
 func RunWithTimeout(commands []string, ctx Context, timeoutSec int) []string {
-	var warnings []string
+	if len(commands) == 0 {
+		return nil
+	}
 
+	var warnings []string
 	for _, cmdStr := range commands {
-		cmdStr = expandTemplates(cmdStr, ctx)
-
-		// Create context with timeout
-		timeout := time.Duration(timeoutSec) * time.Second
-		execCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		if _, err := runCommand(cmdStr, ctx, timeoutSec, os.Stdout, os.Stderr); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return warnings
+}
 
-		cmd := exec.CommandContext(execCtx, "sh", "-c", cmdStr)
-		cmd.Env = append(os.Environ(), buildEnvVars(ctx)...)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+// RunCaptured executes hook commands like RunWithTimeout, but captures each
+// command's combined output instead of writing it to the terminal, so it can
+// be reported in JSON output. A failing command does not stop the rest.
+func RunCaptured(commands []string, ctx Context, timeoutSec int) []HookResult {
+	if len(commands) == 0 {
+		return nil
+	}
 
-		// Set platform-specific process attributes (process group on Unix)
-		setPlatformAttrs(cmd)
+	results := make([]HookResult, 0, len(commands))
+	for _, cmdStr := range commands {
+		result := HookResult{Command: cmdStr}
+		var output bytes.Buffer
+		start := time.Now()
+		exitCode, err := runCommand(cmdStr, ctx, timeoutSec, &output, &output)
+		result.ExitCode = exitCode
+		result.Output = output.String()
+		result.DurationMs = time.Since(start).Milliseconds()
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
 
-		// WaitDelay ensures process cleanup even if context is cancelled
-		cmd.WaitDelay = 3 * time.Second
+// Warnings returns the error of each failed result, matching the warnings
+// RunWithTimeout returns
+func Warnings(results []HookResult) []string {
+	var warnings []string
+	for _, r := range results {
+		if r.Error != "" {
+			warnings = append(warnings, r.Error)
+		}
+	}
+	return warnings
+}
 
-		err := cmd.Run()
-		cancel()
+// RunBlocking executes guard hook commands (pre_add, pre_delete) in order
+// with the specified timeout in seconds. It stops at and returns the first
+// failure, so the caller can abort the operation. Their output goes to
+// stderr so it never mixes with a command's (JSON) output.
+func RunBlocking(commands []string, ctx Context, timeoutSec int) error {
+	if len(commands) == 0 {
+		return nil
+	}
 
-		if err != nil {
-			if execCtx.Err() != nil {
-				// Handle both DeadlineExceeded and Canceled
-				warnings = append(warnings, fmt.Sprintf("%s: %v", cmdStr, execCtx.Err()))
-			} else {
-				warnings = append(warnings, cmdStr+": "+err.Error())
-			}
+	for _, cmdStr := range commands {
+		if _, err := runCommand(cmdStr, ctx, timeoutSec, os.Stderr, os.Stderr); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	return warnings
+// runCommand runs one hook command in the context's directory, writing its
+// output to stdout and stderr. It returns the exit code (-1 if the command
+// did not exit normally) and an error that names the (expanded) command on
+// failure or timeout.
+func runCommand(cmdStr string, ctx Context, timeoutSec int, stdout, stderr io.Writer) (int, error) {
+	cmdStr = expandTemplates(cmdStr, ctx)
+
+	// Create context with timeout; a zero timeout would kill every command
+	// at once
+	if timeoutSec <= 0 {
+		timeoutSec = DefaultTimeout
+	}
+	timeout := time.Duration(timeoutSec) * time.Second
+	execCtx, cancel := context.WithTimeout(context.Background(), timeout)
+
+	cmd := exec.CommandContext(execCtx, "sh", "-c", cmdStr)
+	cmd.Dir = ctx.dir()
+	cmd.Env = append(os.Environ(), buildEnvVars(ctx)...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	// Set platform-specific process attributes (process group on Unix)
+	setPlatformAttrs(cmd)
+
+	// WaitDelay ensures process cleanup even if context is cancelled
+	cmd.WaitDelay = 3 * time.Second
+
+	err := cmd.Run()
+	// Read the context error before cancel, which would always set it
+	ctxErr := execCtx.Err()
+	cancel()
+
+	if err != nil {
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		if ctxErr != nil {
+			// Handle both DeadlineExceeded and Canceled
+			return exitCode, fmt.Errorf("%s: %v", cmdStr, ctxErr)
+		}
+		return exitCode, fmt.Errorf("%s: %s", cmdStr, err.Error())
+	}
+	return 0, nil
 }
 
 // buildEnvVars creates environment variables from context
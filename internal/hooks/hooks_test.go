@@ -1,6 +1,8 @@
 package hooks
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -42,7 +44,7 @@ func TestRun_FailingCommand(t *testing.T) {
 
 func TestRun_ContinuesAfterFailure(t *testing.T) {
 	ctx := Context{
-		Path: "/tmp/test",
+		Path: t.TempDir(),
 	}
 	// First fails, second should still run
 	commands := []string{"false", "true"}
@@ -54,6 +56,53 @@ func TestRun_ContinuesAfterFailure(t *testing.T) {
 	}
 }
 
+func TestRunCaptured(t *testing.T) {
+	ctx := Context{Path: t.TempDir(), Branch: "feature/auth"}
+
+	results := RunCaptured([]string{"echo out; echo err >&2", "exit 3", "echo {{.Branch}}"}, ctx, 30)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results (failures don't stop the rest), got %d", len(results))
+	}
+
+	if results[0].ExitCode != 0 || results[0].Output != "out\nerr\n" || results[0].Error != "" {
+		t.Errorf("unexpected first result: %+v", results[0])
+	}
+	if results[1].ExitCode != 3 || results[1].Error == "" {
+		t.Errorf("expected exit code 3 with an error, got %+v", results[1])
+	}
+	if results[2].Command != "echo {{.Branch}}" || results[2].Output != "feature/auth\n" {
+		t.Errorf("expected configured command and expanded output, got %+v", results[2])
+	}
+
+	if warnings := Warnings(results); len(warnings) != 1 || !strings.Contains(warnings[0], "exit 3") {
+		t.Errorf("expected one warning for the failing command, got %v", warnings)
+	}
+	if results := RunCaptured(nil, ctx, 30); results != nil {
+		t.Errorf("expected no results for empty commands, got %v", results)
+	}
+}
+
+func TestRunBlocking_StopsAtFirstFailure(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	ctx := Context{Path: dir}
+
+	err := RunBlocking([]string{"true", "exit 3", "touch " + marker}, ctx, 30)
+	if err == nil || !strings.Contains(err.Error(), "exit 3") {
+		t.Fatalf("expected error naming the failing command, got %v", err)
+	}
+	if _, statErr := os.Stat(marker); !os.IsNotExist(statErr) {
+		t.Error("expected commands after the failure not to run")
+	}
+
+	if err := RunBlocking([]string{"true"}, ctx, 30); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := RunBlocking(nil, ctx, 30); err != nil {
+		t.Errorf("expected no error for empty commands, got %v", err)
+	}
+}
+
 func TestExpandTemplates(t *testing.T) {
 	ctx := Context{
 		Path:          "/path/to/worktree",
@@ -102,7 +151,7 @@ func TestShellQuote(t *testing.T) {
 
 func TestRun_Timeout(t *testing.T) {
 	ctx := Context{
-		Path:          "/tmp/test",
+		Path:          t.TempDir(),
 		Branch:        "test",
 		ProjectRoot:   "/tmp",
 		DefaultBranch: "main",
@@ -123,7 +172,7 @@ func TestRun_Timeout(t *testing.T) {
 
 func TestRun_NoTimeout(t *testing.T) {
 	ctx := Context{
-		Path:          "/tmp/test",
+		Path:          t.TempDir(),
 		Branch:        "test",
 		ProjectRoot:   "/tmp",
 		DefaultBranch: "main",
@@ -136,3 +185,78 @@ func TestRun_NoTimeout(t *testing.T) {
 		t.Errorf("expected no warnings, got: %v", warnings)
 	}
 }
+
+func TestRun_ZeroTimeoutUsesDefault(t *testing.T) {
+	ctx := Context{
+		Path:          t.TempDir(),
+		Branch:        "test",
+		ProjectRoot:   "/tmp",
+		DefaultBranch: "main",
+	}
+
+	// A zero timeout must not expire before the command gets to run
+	if warnings := RunWithTimeout([]string{"sleep 0.1"}, ctx, 0); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got: %v", warnings)
+	}
+	if err := RunBlocking([]string{"true"}, ctx, -1); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestRun_WorkDir(t *testing.T) {
+	worktree := t.TempDir()
+	other := t.TempDir()
+
+	tests := []struct {
+		name     string
+		workDir  string
+		expected string
+	}{
+		{"default", "", worktree},
+		{"override", other, other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "pwd")
+			ctx := Context{Path: worktree, WorkDir: tt.workDir}
+
+			if warnings := Run([]string{"pwd -P > " + shellQuote(out)}, ctx); len(warnings) != 0 {
+				t.Fatalf("expected no warnings, got %v", warnings)
+			}
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := filepath.EvalSymlinks(tt.expected)
+			if got := strings.TrimSpace(string(data)); got != want {
+				t.Errorf("hook ran in %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestResolveWorkDir(t *testing.T) {
+	tests := []struct {
+		mode     string
+		expected string
+	}{
+		{"", "/wt"},
+		{WorkdirWorktree, "/wt"},
+		{WorkdirProject, "/project"},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveWorkDir(tt.mode, "/wt", "/project")
+		if err != nil {
+			t.Errorf("ResolveWorkDir(%q) error: %v", tt.mode, err)
+		}
+		if got != tt.expected {
+			t.Errorf("ResolveWorkDir(%q) = %q, want %q", tt.mode, got, tt.expected)
+		}
+	}
+
+	if _, err := ResolveWorkDir("elsewhere", "/wt", "/project"); err == nil || !strings.Contains(err.Error(), "hook_workdir") {
+		t.Errorf("expected an invalid hook_workdir error, got %v", err)
+	}
+}
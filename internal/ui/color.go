@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Color modes accepted by --color
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+var colorEnabled = true
+
+// SetColorMode configures styled output: "auto" detects the terminal (and
+// honors NO_COLOR), "always" forces colors even when piped, "never" disables them
+func SetColorMode(mode string) error {
+	switch mode {
+	case ColorAuto, "":
+		profile := termenv.NewOutput(os.Stdout).EnvColorProfile()
+		lipgloss.SetColorProfile(profile)
+		colorEnabled = profile != termenv.Ascii
+	case ColorAlways:
+		lipgloss.SetColorProfile(termenv.TrueColor)
+		colorEnabled = true
+	case ColorNever:
+		lipgloss.SetColorProfile(termenv.Ascii)
+		colorEnabled = false
+	default:
+		return fmt.Errorf("invalid color mode %q (expected auto, always, or never)", mode)
+	}
+	return nil
+}
+
+// ColorEnabled reports whether styled output is currently enabled
+func ColorEnabled() bool {
+	return colorEnabled
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetColorMode(t *testing.T) {
+	defer func() { _ = SetColorMode(ColorAuto) }()
+
+	if err := SetColorMode(ColorAlways); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !ColorEnabled() {
+		t.Error("expected color enabled with always")
+	}
+	if msg := SuccessMsg("done"); !strings.Contains(msg, "\x1b[") {
+		t.Errorf("expected ANSI escapes with always, got %q", msg)
+	}
+
+	if err := SetColorMode(ColorNever); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ColorEnabled() {
+		t.Error("expected color disabled with never")
+	}
+	if msg := SuccessMsg("done"); msg != "✓ done" {
+		t.Errorf("expected plain output with never, got %q", msg)
+	}
+}
+
+func TestSetColorMode_Auto(t *testing.T) {
+	defer func() { _ = SetColorMode(ColorAuto) }()
+
+	// Test output is not a terminal, so auto falls back to plain text
+	if err := SetColorMode(ColorAuto); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if msg := SuccessMsg("done"); msg != "✓ done" {
+		t.Errorf("expected plain output when not a terminal, got %q", msg)
+	}
+}
+
+func TestSetColorMode_Invalid(t *testing.T) {
+	if err := SetColorMode("sometimes"); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
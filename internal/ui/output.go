@@ -10,6 +10,7 @@ const (
 	ErrCodeValidation    = "validation_error"
 	ErrCodeGit           = "git_error"
 	ErrCodeGitHub        = "github_error"
+	ErrCodeGitLab        = "gitlab_error"
 	ErrCodeTimeout       = "timeout_error"
 	ErrCodeNotInProject  = "not_in_project"
 	ErrCodeAlreadyExists = "already_exists"
@@ -46,7 +47,7 @@ func NewCLIError(code, message string) *CLIError {
 		exit = ExitValidation
 	case ErrCodeGit:
 		exit = ExitGit
-	case ErrCodeGitHub:
+	case ErrCodeGitHub, ErrCodeGitLab:
 		exit = ExitGitHub
 	case ErrCodeTimeout:
 		exit = ExitTimeout
@@ -74,6 +74,7 @@ func TestGetExitCode_CLIError(t *testing.T) {
 		{"validation error", ErrCodeValidation, ExitValidation},
 		{"git error", ErrCodeGit, ExitGit},
 		{"github error", ErrCodeGitHub, ExitGitHub},
+		{"gitlab error", ErrCodeGitLab, ExitGitHub},
 		{"timeout error", ErrCodeTimeout, ExitTimeout},
 		{"not in project", ErrCodeNotInProject, ExitError},
 		{"already exists", ErrCodeAlreadyExists, ExitError},